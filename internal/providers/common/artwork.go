@@ -0,0 +1,37 @@
+package common
+
+// provider for the artworks of tvs and movies (ex: logos, banners, disc images)
+// the media is not selected with Configure, it is identified by the external ids given to each call
+type ArtworkProvider interface {
+	Provider
+	ListTVSArtwork(ids []ExternalID) ([]ArtworkData, error)   // the supported ids are tried in order
+	ListMovieArtwork(ids []ExternalID) ([]ArtworkData, error) // the supported ids are tried in order
+}
+
+type ArtworkType string
+
+const (
+	ArtworkPoster       ArtworkType = "poster"
+	ArtworkFanart       ArtworkType = "fanart" // background
+	ArtworkClearLogo    ArtworkType = "clearlogo"
+	ArtworkClearArt     ArtworkType = "clearart"
+	ArtworkBanner       ArtworkType = "banner"
+	ArtworkThumb        ArtworkType = "thumb" // landscape image with the title
+	ArtworkDisc         ArtworkType = "disc"
+	ArtworkCharacter    ArtworkType = "characterart"
+	ArtworkSeasonPoster ArtworkType = "seasonposter"
+	ArtworkSeasonBanner ArtworkType = "seasonbanner"
+	ArtworkSeasonThumb  ArtworkType = "seasonthumb"
+)
+
+// the artworks are returned by order of preference for each type
+type ArtworkData struct {
+	Type     ArtworkType `json:"type"`
+	URL      string      `json:"url"`
+	Preview  string      `json:"preview"`  // smaller version of the image, empty if not available
+	Language string      `json:"language"` // iso 639-1 code, empty if the image contains no text
+	Season   int         `json:"season"`   // season of the season artworks, -1 if the artwork is used for all the seasons
+	Disc     string      `json:"disc"`     // type of disc for the disc artworks (ex: bluray, dvd)
+	Likes    int64       `json:"likes"`
+	ScraperInfo
+}
@@ -0,0 +1,46 @@
+package common
+
+// provider for books, audiobooks and comics
+// ScraperID identifies a book (all its editions), ScraperData can identify one of its editions (ex: found from an isbn)
+type BookProvider interface {
+	Provider
+	SearchBook(title string, author string) ([]SearchData, error) // author can be empty
+	FindBookByExternalID(source ExternalSource, id string) ([]SearchData, error)
+	GetBook() (BookData, error)
+	ListBookEdition() ([]BookEditionData, error)
+	ListBookTag() ([]TagData, error)
+	ListBookPerson() ([]PersonData, error)
+}
+
+// types of book
+const (
+	BookBook      = "book"
+	BookAudiobook = "audiobook"
+	BookComic     = "comic"
+)
+
+type BookData struct {
+	Title       string  `json:"title"`
+	Subtitle    string  `json:"subtitle"`
+	Author      string  `json:"author"` // credited authors, the details are returned by ListBookPerson
+	Overview    string  `json:"overview"`
+	Icon        string  `json:"icon"` // cover
+	Type        string  `json:"type"`
+	Premiered   Date    `json:"premiered"`   // first publication
+	Series      string  `json:"series"`      // name of the series, empty if the book is not part of a series
+	SeriesIndex float64 `json:"seriesIndex"` // position in the series, can be decimal (ex: 2.5 for a novella), 0 if unknown
+	ScraperInfo
+}
+
+type BookEditionData struct {
+	Title     string `json:"title"`
+	Publisher string `json:"publisher"`
+	ISBN      string `json:"isbn"`     // isbn 13 if available, else isbn 10
+	Language  string `json:"language"` // iso 639-2 code (ex: eng)
+	Format    string `json:"format"`   // ex: Paperback, Audio CD
+	Type      string `json:"type"`
+	Pages     int64  `json:"pages"`
+	Icon      string `json:"icon"`
+	Premiered Date   `json:"premiered"`
+	ScraperInfo
+}
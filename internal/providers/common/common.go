@@ -1,12 +1,38 @@
 package common
 
-import log "github.com/sirupsen/logrus"
+import (
+	"errors"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
 
 type Provider interface {
 	Setup(config map[string]string, logger *log.Logger) error
 	Configure(ScraperID string, ScraperData string)
 }
 
+// returned by the change providers (TVShowChangeProvider, MovieChangeProvider) when the changes are not available since the requested date,
+// all the media of the provider must then be refreshed
+var ErrChangesTooOld = errors.New("changes not available since this date")
+
+// databases that can be used to identify an item with FindTVSByExternalID, FindMovieByExternalID, ...
+type ExternalSource string
+
+const (
+	ExternalIMDB        ExternalSource = "imdb"
+	ExternalTMDB        ExternalSource = "tmdb"
+	ExternalTVDB        ExternalSource = "tvdb"
+	ExternalWikidata    ExternalSource = "wikidata"
+	ExternalMusicBrainz ExternalSource = "musicbrainz"
+	ExternalISBN        ExternalSource = "isbn"
+)
+
+type ExternalID struct {
+	Source ExternalSource `json:"source"`
+	ID     string         `json:"id"`
+}
+
 type ScraperInfo struct {
 	ScraperID   string `json:"scraperID"`
 	ScraperName string `json:"scraperName"`
@@ -20,17 +46,120 @@ type TagData struct {
 	Icon  string `json:"icon"`
 }
 
+// type of company, networks broadcast tvs, web channels stream them online, studios produce tvs and movies
+type CompanyType string
+
+const (
+	CompanyNetwork    CompanyType = "network"
+	CompanyWebChannel CompanyType = "webchannel"
+	CompanyStudio     CompanyType = "studio"
+)
+
+// a company is identified by its ScraperName, ScraperID and Type, not by its name
+type CompanyData struct {
+	Name          string      `json:"name"`
+	Logo          string      `json:"logo"`
+	OriginCountry string      `json:"originCountry"`
+	Type          CompanyType `json:"type"`
+	ScraperInfo
+}
+
+// sources of the ratings returned by the providers, in addition to the provider itself
+const (
+	RatingIMDB           = "imdb"
+	RatingRottenTomatoes = "rottentomatoes"
+	RatingMetacritic     = "metacritic"
+)
+
+// score given by a rating source, Value is on a 0-Max scale (ex: 87/100 for 87%)
+type RatingData struct {
+	Source string  `json:"source"`
+	Value  float64 `json:"value"`
+	Max    float64 `json:"max"`
+	Votes  int64   `json:"votes"`
+}
+
+// Role is the department of the person, as named by tmdb (ex: Acting, Directing, Writing)
 type PersonData struct {
 	Name        string `json:"name"`
 	Role        string `json:"role"`
+	Character   string `json:"character"` // character played by an actor, empty for the other roles
 	IsCharacter bool   `json:"isCharacter"`
 }
 
+// roles of the people, they are the departments used by tmdb
+const (
+	RoleActing     = "Acting"
+	RoleDirecting  = "Directing"
+	RoleWriting    = "Writing"
+	RoleProduction = "Production"
+	RoleSound      = "Sound"
+	RoleCamera     = "Camera"
+	RoleEditing    = "Editing"
+	RoleArt        = "Art"
+	RoleCrew       = "Crew"
+)
+
+// keywords of the job titles of each role, the first matching keyword is used
+// the specific titles are listed first (ex: art director is not a director)
+var jobRoles = []struct {
+	keyword string
+	role    string
+}{
+	{"art director", RoleArt},
+	{"casting", RoleProduction},
+	{"director of photography", RoleCamera},
+	{"sound director", RoleSound},
+	{"actor", RoleActing},
+	{"actress", RoleActing},
+	{"guest star", RoleActing},
+	{"voice", RoleActing},
+	{"director", RoleDirecting},
+	{"storyboard", RoleDirecting},
+	{"writer", RoleWriting},
+	{"screenplay", RoleWriting},
+	{"teleplay", RoleWriting},
+	{"story", RoleWriting},
+	{"creator", RoleWriting},
+	{"author", RoleWriting},
+	{"novel", RoleWriting},
+	{"translat", RoleWriting},
+	{"illustrat", RoleArt},
+	{"series composition", RoleWriting},
+	{"producer", RoleProduction},
+	{"music", RoleSound},
+	{"composer", RoleSound},
+	{"sound", RoleSound},
+	{"editor", RoleEditing},
+	{"photography", RoleCamera},
+	{"camera", RoleCamera},
+	{"design", RoleArt},
+	{"art", RoleArt},
+}
+
+// Returns the role of a job title (ex: Directing for Episode Director), RoleCrew if the job is unknown
+// the providers without departments use it to return the same roles as tmdb
+func JobRole(job string) string {
+	job = strings.ToLower(strings.TrimSpace(job))
+	for _, i := range []string{RoleActing, RoleDirecting, RoleWriting, RoleProduction, RoleSound, RoleCamera, RoleEditing, RoleArt} {
+		if job == strings.ToLower(i) {
+			return i
+		}
+	}
+	for _, i := range jobRoles {
+		if strings.Contains(job, i.keyword) {
+			return i.role
+		}
+	}
+	return RoleCrew
+}
+
 type SearchData struct {
 	Title     string `json:"title"`
 	Overview  string `json:"overview"`
 	Icon      string `json:"icon"`
-	Premiered int64  `json:"premiered"`
+	Premiered Date   `json:"premiered"`
+	Adult     bool   `json:"adult"`
 	ScraperInfo
 }
 
@@ -38,8 +167,9 @@ type UpcomingData struct {
 	Title     string `json:"title"`
 	Overview  string `json:"overview"`
 	Icon      string `json:"icon"`
-	Premiered int64  `json:"premiered"`
-	ID1       int64  `json:"id1"` // season for tvs
-	ID2       int64  `json:"id2"` // episode for tvs
+	Premiered Date   `json:"premiered"`
+	ID1       int64  `json:"id1"`      // season for tvs
+	ID2       int64  `json:"id2"`      // episode for tvs
+	Timezone  string `json:"timezone"` // timezone of the network (ex: America/New_York), Premiered is always in UTC
 	ScraperInfo
 }
@@ -0,0 +1,40 @@
+package common
+
+import "testing"
+
+func TestJobRole(t *testing.T) {
+	tests := []struct {
+		job  string
+		role string
+	}{
+		{"Actor", RoleActing},
+		{"Guest Star", RoleActing},
+		{"Directing", RoleDirecting},
+		{" writing ", RoleWriting},
+		{"Director", RoleDirecting},
+		{"Episode Director", RoleDirecting},
+		{"Animation Director (eps 1-3)", RoleDirecting},
+		{"Art Director", RoleArt},
+		{"Casting Director", RoleProduction},
+		{"Director of Photography", RoleCamera},
+		{"Sound Director", RoleSound},
+		{"Storyboard", RoleDirecting},
+		{"Original Story", RoleWriting},
+		{"Creator", RoleWriting},
+		{"Series Composition", RoleWriting},
+		{"Executive Producer", RoleProduction},
+		{"Music", RoleSound},
+		{"Editor", RoleEditing},
+		{"Character Design", RoleArt},
+		{"Author", RoleWriting},
+		{"Translator", RoleWriting},
+		{"Illustrations", RoleArt},
+		{"Key Animation", RoleCrew},
+		{"", RoleCrew},
+	}
+	for _, i := range tests {
+		if role := JobRole(i.job); role != i.role {
+			t.Errorf("JobRole(%q) = %q, want %q", i.job, role, i.role)
+		}
+	}
+}
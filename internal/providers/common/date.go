@@ -0,0 +1,118 @@
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type DatePrecision int
+
+const (
+	DateUnknown DatePrecision = 0 // no date available
+	DateYear    DatePrecision = 1 // only the year is known
+	DateMonth   DatePrecision = 2 // the year and month are known
+	DateDay     DatePrecision = 3 // the full date is known
+	DateTime    DatePrecision = 4 // the date and the time are known
+)
+
+// date which can be unknown or only partially known (ex: only the year of release is available)
+type Date struct {
+	Time      time.Time
+	Precision DatePrecision
+}
+
+// formats used to parse and print a date for each precision
+var dateLayouts = map[DatePrecision]string{
+	DateYear:  "2006",
+	DateMonth: "2006-01",
+	DateDay:   "2006-01-02",
+	DateTime:  time.RFC3339,
+}
+
+func NewDate(t time.Time, precision DatePrecision) Date {
+	if precision == DateUnknown || t.IsZero() {
+		return Date{}
+	}
+	return Date{Time: t.UTC(), Precision: precision}
+}
+
+// Parse a date with one of the formats: 2006-01-02T15:04:05Z07:00, 2006-01-02, 2006-01 or 2006
+// the precision of the returned date depends on the format, an unknown date is returned if the value cannot be parsed
+func ParseDate(value string) Date {
+	value = strings.TrimSpace(value)
+	for _, precision := range []DatePrecision{DateTime, DateDay, DateMonth, DateYear} {
+		t, err := time.Parse(dateLayouts[precision], value)
+		if err == nil {
+			return NewDate(t, precision)
+		}
+	}
+	return Date{}
+}
+
+func (d Date) IsKnown() bool {
+	return d.Precision != DateUnknown
+}
+
+// returns the unix timestamp of the date, 0 if the date is unknown
+func (d Date) Unix() int64 {
+	if !d.IsKnown() {
+		return 0
+	}
+	return d.Time.Unix()
+}
+
+// returns the year of the date, 0 if the date is unknown
+func (d Date) Year() int {
+	if !d.IsKnown() {
+		return 0
+	}
+	return d.Time.Year()
+}
+
+// returns true if d is before o, unknown dates are considered after known dates
+func (d Date) Before(o Date) bool {
+	if !d.IsKnown() || !o.IsKnown() {
+		return d.IsKnown() && !o.IsKnown()
+	}
+	return d.Time.Before(o.Time)
+}
+
+// returns the date with the format of its precision (ex: 2006-01 for DateMonth), an empty string if unknown
+func (d Date) String() string {
+	if !d.IsKnown() {
+		return ""
+	}
+	return d.Time.Format(dateLayouts[d.Precision])
+}
+
+// a date is encoded as a string with the format of its precision, or null if unknown
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.IsKnown() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// a date can be decoded from null, a string or a unix timestamp (format used before the precision was available)
+func (d *Date) UnmarshalJSON(data []byte) error {
+	*d = Date{}
+
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case string:
+		*d = ParseDate(v)
+	case float64:
+		t := time.Unix(int64(v), 0)
+		// 0 and the zero time were used for unknown dates
+		if v != 0 && t.Year() > 1 {
+			*d = NewDate(t, DateDay)
+		}
+	}
+	return nil
+}
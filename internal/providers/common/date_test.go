@@ -0,0 +1,144 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value     string
+		precision DatePrecision
+		str       string
+		year      int
+	}{
+		{"2010-05-12T20:30:00Z", DateTime, "2010-05-12T20:30:00Z", 2010},
+		{"2010-05-12", DateDay, "2010-05-12", 2010},
+		{" 2010-05-12 ", DateDay, "2010-05-12", 2010},
+		{"2010-05", DateMonth, "2010-05", 2010},
+		{"2010", DateYear, "2010", 2010},
+		{"", DateUnknown, "", 0},
+		{"unknown", DateUnknown, "", 0},
+		{"2010-13-01", DateUnknown, "", 0},
+		{"0000-00-00", DateUnknown, "", 0},
+	}
+	for _, i := range tests {
+		d := ParseDate(i.value)
+		if d.Precision != i.precision {
+			t.Errorf("ParseDate(%q).Precision = %d, want %d", i.value, d.Precision, i.precision)
+		}
+		if d.String() != i.str {
+			t.Errorf("ParseDate(%q).String() = %q, want %q", i.value, d.String(), i.str)
+		}
+		if d.Year() != i.year {
+			t.Errorf("ParseDate(%q).Year() = %d, want %d", i.value, d.Year(), i.year)
+		}
+		if d.IsKnown() != (i.precision != DateUnknown) {
+			t.Errorf("ParseDate(%q).IsKnown() = %t", i.value, d.IsKnown())
+		}
+	}
+}
+
+func TestNewDate(t *testing.T) {
+	if d := NewDate(time.Time{}, DateDay); d.IsKnown() {
+		t.Errorf("NewDate(zero time) = %v, want an unknown date", d)
+	}
+	if d := NewDate(time.Now(), DateUnknown); d.IsKnown() {
+		t.Errorf("NewDate(DateUnknown) = %v, want an unknown date", d)
+	}
+	local := time.Date(2010, 5, 12, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*3600))
+	if d := NewDate(local, DateTime); d.Time.Location() != time.UTC || !d.Time.Equal(local) {
+		t.Errorf("NewDate(%v) = %v, want the same instant in UTC", local, d.Time)
+	}
+}
+
+func TestDateUnix(t *testing.T) {
+	tests := []struct {
+		date Date
+		unix int64
+	}{
+		{Date{}, 0},
+		{ParseDate("1970-01-01"), 0},
+		{ParseDate("2010-05-12"), 1273622400},
+		{ParseDate("2010"), 1262304000},
+		{ParseDate("1960-01-01"), -315619200},
+	}
+	for _, i := range tests {
+		if u := i.date.Unix(); u != i.unix {
+			t.Errorf("%q.Unix() = %d, want %d", i.date, u, i.unix)
+		}
+	}
+}
+
+func TestDateBefore(t *testing.T) {
+	old := ParseDate("2001-01-01")
+	recent := ParseDate("2010-05-12")
+	unknown := Date{}
+	tests := []struct {
+		d, o   Date
+		before bool
+	}{
+		{old, recent, true},
+		{recent, old, false},
+		{old, old, false},
+		{old, unknown, true},
+		{unknown, old, false},
+		{unknown, unknown, false},
+	}
+	for _, i := range tests {
+		if b := i.d.Before(i.o); b != i.before {
+			t.Errorf("%q.Before(%q) = %t, want %t", i.d, i.o, b, i.before)
+		}
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	tests := []struct {
+		date Date
+		json string
+	}{
+		{Date{}, `null`},
+		{ParseDate("2010"), `"2010"`},
+		{ParseDate("2010-05"), `"2010-05"`},
+		{ParseDate("2010-05-12"), `"2010-05-12"`},
+		{ParseDate("2010-05-12T20:30:00Z"), `"2010-05-12T20:30:00Z"`},
+	}
+	for _, i := range tests {
+		data, err := json.Marshal(i.date)
+		if err != nil || string(data) != i.json {
+			t.Errorf("json.Marshal(%q) = %s, %v, want %s", i.date, data, err, i.json)
+		}
+		d := Date{}
+		err = json.Unmarshal([]byte(i.json), &d)
+		if err != nil || d != i.date {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", i.json, d, err, i.date)
+		}
+	}
+}
+
+func TestDateUnmarshalTimestamp(t *testing.T) {
+	tests := []struct {
+		json string
+		date Date
+	}{
+		// unix timestamps were used before the precision was available
+		{`1273622400`, ParseDate("2010-05-12")},
+		// 0 and the zero time were used for unknown dates
+		{`0`, Date{}},
+		{`-62135596800`, Date{}},
+		{`""`, Date{}},
+		{`true`, Date{}},
+	}
+	for _, i := range tests {
+		d := Date{Precision: DateDay}
+		err := json.Unmarshal([]byte(i.json), &d)
+		if err != nil || d != i.date {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", i.json, d, err, i.date)
+		}
+	}
+	d := Date{}
+	if err := json.Unmarshal([]byte(`{`), &d); err == nil {
+		t.Error("json.Unmarshal({) returned no error")
+	}
+}
@@ -9,14 +9,28 @@ const (
 	Filler     FillerType = 3 // nothing related to the original support
 )
 
+// returns the name of the type, used as the value of the filler tag of the episodes
+func (f FillerType) String() string {
+	switch f {
+	case Canon:
+		return "canon"
+	case Adaptation:
+		return "adaptation"
+	case Mixed:
+		return "mixed"
+	case Filler:
+		return "filler"
+	}
+	return ""
+}
+
 type FillerData struct {
-	Filler FillerType
-	Index  int // absolute number since the start of the serie (ex: if s1 contains 20 ep and we want the 3rd ep of s2, index will be: 23)
+	Filler FillerType `json:"filler"`
+	Index  int        `json:"index"` // absolute number since the start of the serie (ex: if s1 contains 20 ep and we want the 3rd ep of s2, index will be: 23)
 }
 
 type FillerProvider interface {
 	Provider
-	NewFillerProvider() FillerProvider
 	SearchFiller(name string) ([]SearchData, error)
-	GetFiller() (FillerData, error)
+	ListFiller() ([]FillerData, error) // returns the type of each episode of the show selected with Configure
 }
@@ -1,25 +1,44 @@
 package common
 
+import "time"
+
 type MovieProvider interface {
 	Provider
 	SearchMovie(name string, year int) ([]SearchData, error)
+	FindMovieByExternalID(source ExternalSource, id string) ([]SearchData, error)
 	ListMovieTag() ([]TagData, error)
+	ListMovieCompany() ([]CompanyData, error)
 	ListMoviePerson() ([]PersonData, error)
 	GetMovie() (MovieData, error)
 	GetMovieUpcoming() (UpcomingData, error)
 	GetMovieCollection() (MovieCollectionData, error)
 }
 
+// optional interface implemented by the movie providers able to list the movies modified since a given date
+type MovieChangeProvider interface {
+	MovieChangedSince(since time.Time) ([]string, error) // returns the ScraperID of the modified movies
+}
+
+// optional interface implemented by the movie providers reading data stored next to the media files (ex: nfo files)
+// the search is made from the path of the movie file or folder instead of its name
+// if the ScraperID of the results is a path, the scraper stores it relative to the library and configures the provider with the full path
+type LocalMovieProvider interface {
+	SearchMovieFromPath(path string) ([]SearchData, error)
+}
+
 type MovieData struct {
-	Title      string `json:"title"`
-	Overview   string `json:"overview"`
-	Icon       string `json:"icon"`
-	Fanart     string `json:"fanart"`
-	Website    string `json:"website"`
-	Trailer    string `json:"trailer"`
-	Premiered  int64  `json:"premiered"`
-	Rating     int64  `json:"rating"`
-	Collection int64  `json:"collection"`
+	Title      string        `json:"title"`
+	Overview   string        `json:"overview"`
+	Icon       string        `json:"icon"`
+	Fanart     string        `json:"fanart"`
+	Website    string        `json:"website"`
+	Trailer    string        `json:"trailer"`
+	Trailers   []TrailerData `json:"trailers"`
+	Premiered  Date          `json:"premiered"`
+	Rating     int64         `json:"rating"`
+	Ratings    []RatingData  `json:"ratings"` // detailed ratings, from several sources if available
+	Awards     string        `json:"awards"`
+	Collection int64         `json:"collection"`
 	ScraperInfo
 }
 
@@ -28,7 +47,7 @@ type MovieCollectionData struct {
 	Overview  string `json:"overview"`
 	Icon      string `json:"icon"`
 	Fanart    string `json:"fanart"`
-	Premiered int64  `json:"premiered"`
+	Premiered Date   `json:"premiered"`
 	Rating    int64  `json:"rating"`
 	ScraperInfo
 }
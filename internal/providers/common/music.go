@@ -0,0 +1,73 @@
+package common
+
+// provider for artists, albums and tracks
+// the item selected with Configure is an artist for GetArtist and ListArtistAlbum, an album for GetAlbum and ListAlbumTrack, and a track for GetTrack
+type MusicProvider interface {
+	Provider
+	SearchArtist(name string) ([]SearchData, error)
+	SearchAlbum(name string, artist string) ([]SearchData, error) // artist can be empty
+	SearchTrack(name string, artist string) ([]SearchData, error) // artist can be empty
+	FindMusicByExternalID(source ExternalSource, id string) ([]SearchData, error)
+	GetArtist() (ArtistData, error)
+	ListArtistAlbum() ([]SearchData, error)
+	ListArtistTag() ([]TagData, error)
+	GetAlbum() (AlbumData, error)
+	ListAlbumTrack() ([]TrackData, error)
+	ListAlbumTag() ([]TagData, error)
+	GetTrack() (TrackData, error)
+}
+
+// type of artist
+const (
+	ArtistPerson = "person"
+	ArtistGroup  = "group"
+)
+
+type ArtistData struct {
+	Name     string `json:"name"`
+	SortName string `json:"sortName"` // ex: Beatles, The
+	Overview string `json:"overview"`
+	Icon     string `json:"icon"`
+	Type     string `json:"type"`    // ArtistPerson, ArtistGroup or empty if unknown
+	Country  string `json:"country"` // iso 3166-1 code
+	Begin    Date   `json:"begin"`   // birth date or formation date
+	End      Date   `json:"end"`     // death date or dissolution date
+	Website  string `json:"website"`
+	ScraperInfo
+}
+
+// types of album
+const (
+	AlbumAlbum       = "album"
+	AlbumSingle      = "single"
+	AlbumEP          = "ep"
+	AlbumSoundtrack  = "soundtrack"
+	AlbumLive        = "live"
+	AlbumCompilation = "compilation"
+	AlbumOther       = "other"
+)
+
+type AlbumData struct {
+	Title      string `json:"title"`
+	Artist     string `json:"artist"` // credited artists (ex: Simon & Garfunkel)
+	Overview   string `json:"overview"`
+	Icon       string `json:"icon"` // front cover
+	Fanart     string `json:"fanart"`
+	Type       string `json:"type"`
+	Premiered  Date   `json:"premiered"`
+	TrackCount int64  `json:"trackCount"`
+	Rating     int64  `json:"rating"`
+	ScraperInfo
+}
+
+type TrackData struct {
+	Title     string `json:"title"`
+	Artist    string `json:"artist"`
+	Album     string `json:"album"`
+	Disc      int64  `json:"disc"`
+	Track     int64  `json:"track"`
+	Duration  int64  `json:"duration"` // in seconds
+	Premiered Date   `json:"premiered"`
+	Rating    int64  `json:"rating"`
+	ScraperInfo
+}
@@ -0,0 +1,40 @@
+package common
+
+import "errors"
+
+// returned by the override providers when no overrides were written for a media,
+// the other errors are invalid overrides (ex: a file which cannot be parsed)
+var ErrNoOverride = errors.New("no override")
+
+// optional interface implemented by the tvs providers reading metadata written by the user in the tvs folder
+// the fields set in the overrides take precedence over the data of the selected provider, empty fields are ignored
+type TVShowOverrideProvider interface {
+	GetTVSOverride(path string) (TVSOverrideData, error) // path of the tvs folder, ErrNoOverride if there are no overrides
+}
+
+// optional interface implemented by the movie providers reading metadata written by the user next to the movie
+type MovieOverrideProvider interface {
+	GetMovieOverride(path string) (MovieOverrideData, error) // path of the movie file or folder, ErrNoOverride if there are no overrides
+}
+
+type TVSOverrideData struct {
+	TVS      TVSData               `json:"tvs"`
+	Seasons  map[int]TVSSeasonData `json:"seasons"`
+	Episodes []TVSEpisodeData      `json:"episodes"` // Season and Episode identify the overridden episode
+	Tags     []TagData             `json:"tags"`     // added to the tags of the selected provider
+}
+
+type MovieOverrideData struct {
+	Movie MovieData `json:"movie"`
+	Tags  []TagData `json:"tags"` // added to the tags of the selected provider
+}
+
+// returns the overrides of an episode
+func (o TVSOverrideData) Episode(season int, episode int) (TVSEpisodeData, bool) {
+	for _, i := range o.Episodes {
+		if i.Season == int64(season) && i.Episode == int64(episode) {
+			return i, true
+		}
+	}
+	return TVSEpisodeData{}, false
+}
@@ -3,17 +3,22 @@ package common
 type PersonProvider interface {
 	Provider
 	SearchPerson(name string) ([]SearchData, error)
+	FindPersonByExternalID(source ExternalSource, id string) ([]SearchData, error)
 	GetPerson() (PersonDetails, error)
 }
 
 type PersonDetails struct {
-	Birthdate   int64  `json:"birthdate"`
-	Deathdate   int64  `json:"deathdate"`
-	Gender      int64  `json:"gender"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Icon        string `json:"icon"`
-	KnownFor    string `json:"knownFor"`
-	Rating      int64  `json:"rating"`
+	Birthdate   Date         `json:"birthdate"`
+	Deathdate   Date         `json:"deathdate"`
+	Gender      int64        `json:"gender"`
+	Name        string       `json:"name"`
+	Aliases     []string     `json:"aliases"`
+	Birthplace  string       `json:"birthplace"`
+	Nationality string       `json:"nationality"`
+	Description string       `json:"description"`
+	Icon        string       `json:"icon"`
+	KnownFor    string       `json:"knownFor"`
+	Rating      int64        `json:"rating"`
+	ExternalIDs []ExternalID `json:"externalIDs"` // ids of the person in other databases, used to enrich the data from other providers
 	ScraperInfo
 }
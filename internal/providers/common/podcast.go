@@ -0,0 +1,36 @@
+package common
+
+// provider for podcasts, ScraperID is the url of the feed
+type PodcastProvider interface {
+	Provider
+	GetPodcast() (PodcastData, error)
+	ListPodcastEpisode() ([]PodcastEpisodeData, error)           // sorted by publication date, oldest first
+	MatchPodcastEpisode(path string) (PodcastEpisodeData, error) // returns the episode of a downloaded audio or video file
+}
+
+type PodcastData struct {
+	Title    string `json:"title"`
+	Overview string `json:"overview"`
+	Icon     string `json:"icon"`
+	Website  string `json:"website"`
+	Author   string `json:"author"`
+	Language string `json:"language"`
+	ScraperInfo
+}
+
+// the season and episode numbers are the ones of the feed if available,
+// else the season is the year of publication and the episode is built from the publication date (ex: 3152 for the second episode of march 15)
+type PodcastEpisodeData struct {
+	GUID          string `json:"guid"` // unique id of the episode in the feed
+	Title         string `json:"title"`
+	Overview      string `json:"overview"`
+	Icon          string `json:"icon"`
+	Premiered     Date   `json:"premiered"` // publication date
+	Duration      int64  `json:"duration"`  // in seconds, 0 if unknown
+	Season        int64  `json:"season"`
+	Episode       int64  `json:"episode"`
+	EnclosureURL  string `json:"enclosureURL"` // url of the audio or video file
+	EnclosureType string `json:"enclosureType"`
+	EnclosureSize int64  `json:"enclosureSize"` // in bytes, 0 if unknown
+	ScraperInfo
+}
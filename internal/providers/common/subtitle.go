@@ -0,0 +1,89 @@
+package common
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// provider for the subtitles of movies and episodes
+// the media is not selected with Configure, it is described by the query given to SearchSubtitle
+type SubtitleProvider interface {
+	Provider
+	SearchSubtitle(query SubtitleQuery) ([]SubtitleData, error) // results are sorted by match quality, best first
+	DownloadSubtitle(sub SubtitleData) ([]byte, error)          // returns the content of a subtitle found by SearchSubtitle
+}
+
+// description of the media of a subtitle search, the providers use the most precise data they support
+type SubtitleQuery struct {
+	Hash      string   // hash of the video file, see SubtitleHash
+	Size      int64    // size of the video file, in bytes
+	FileName  string   // name of the video file, without its folder
+	IMDB      string   // imdb id of the movie or of the tvs
+	Title     string   // title of the movie or of the tvs
+	Season    int      // -1 for movies
+	Episode   int      // -1 for movies
+	Languages []string // iso 639-1 codes, by order of preference
+}
+
+// how a subtitle was matched with the query, from the most to the least precise
+type SubtitleMatch int
+
+const (
+	SubtitleMatchHash     SubtitleMatch = 0 // made for this exact video file
+	SubtitleMatchFileName SubtitleMatch = 1 // made for a file with the same name (same release)
+	SubtitleMatchIMDB     SubtitleMatch = 2 // made for the same movie or episode, may not be synchronized
+	SubtitleMatchEpisode  SubtitleMatch = 3 // made for an episode with the same title, season and episode number
+)
+
+type SubtitleData struct {
+	Language        string        `json:"language"` // iso 639-1 code
+	Format          string        `json:"format"`   // extension of the file (ex: srt, ass)
+	Match           SubtitleMatch `json:"match"`
+	HearingImpaired bool          `json:"hearingImpaired"`
+	Forced          bool          `json:"forced"` // only contains the translations of the foreign parts
+	ScraperInfo
+}
+
+// size of the chunks read at the start and the end of the file by SubtitleHash
+const subtitleHashChunk = 64 * 1024
+
+// Compute the hash of a video file used to identify it in the subtitle databases (OpenSubtitles hash)
+// the hash is the sum of the size of the file and of the 64 bit words of its first and last 64KB
+// returns the hash as a 16 characters hexadecimal string and the size of the file
+func SubtitleHash(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+	size := info.Size()
+	if size < subtitleHashChunk {
+		return "", size, errors.New("file too small to compute a hash: " + path)
+	}
+
+	hash := uint64(size)
+	buf := make([]byte, subtitleHashChunk)
+	for _, offset := range []int64{0, size - subtitleHashChunk} {
+		_, err = f.ReadAt(buf, offset)
+		if err != nil && err != io.EOF {
+			return "", size, err
+		}
+		for i := 0; i < subtitleHashChunk; i += 8 {
+			hash += binary.LittleEndian.Uint64(buf[i : i+8])
+		}
+	}
+
+	h := strconv.FormatUint(hash, 16)
+	for len(h) < 16 {
+		h = "0" + h
+	}
+	return h, size, nil
+}
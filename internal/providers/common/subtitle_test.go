@@ -0,0 +1,70 @@
+package common
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// write a file of the given size, filled with zeros except for the 64 bit words written at the given offsets
+// the files are sparse, so the large sizes do not use disk space
+func writeHashFile(t *testing.T, size int64, words map[int64]uint64) string {
+	p := filepath.Join(t.TempDir(), "video.mkv")
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := f.Truncate(size); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 8)
+	for offset, w := range words {
+		binary.LittleEndian.PutUint64(buf, w)
+		if _, err := f.WriteAt(buf, offset); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return p
+}
+
+func TestSubtitleHash(t *testing.T) {
+	// the hash is the size plus the sum of the 64 bit little endian words of the first and the last 64KB
+	tests := []struct {
+		name  string
+		size  int64
+		words map[int64]uint64
+		hash  string
+	}{
+		{"zeros", 131072, nil, "0000000000020000"},
+		{"first and last word", 131072, map[int64]uint64{0: 1, 131064: 0x1000}, "0000000000021001"},
+		{"middle is ignored", 200000, map[int64]uint64{65536: 0xffff, 100000: 0xffff}, "0000000000030d40"},
+		{"last chunk ends at the end of the file", 200000, map[int64]uint64{134464: 2, 199992: 3}, "0000000000030d45"},
+		{"overflow", 131072, map[int64]uint64{0: 0xffffffffffffffff, 8: 0xffffffffffffffff}, "000000000001fffe"},
+		// the chunks overlap when the file is smaller than 128KB, the common words are counted twice
+		{"overlapping chunks", 65544, map[int64]uint64{8: 5}, "0000000000010012"},
+		{"single chunk", 65536, map[int64]uint64{0: 0x10}, "0000000000010020"},
+		// size over 4GB, which does not fit in 32 bits
+		{"large file", 4295033000, map[int64]uint64{0: 0x0102030405060708}, "01020305050707b0"},
+	}
+	for _, i := range tests {
+		p := writeHashFile(t, i.size, i.words)
+		hash, size, err := SubtitleHash(p)
+		if err != nil {
+			t.Errorf("%s: SubtitleHash() returned %v", i.name, err)
+			continue
+		}
+		if hash != i.hash || size != i.size {
+			t.Errorf("%s: SubtitleHash() = %s, %d, want %s, %d", i.name, hash, size, i.hash, i.size)
+		}
+	}
+
+	p := writeHashFile(t, 65535, nil)
+	if _, size, err := SubtitleHash(p); err == nil || size != 65535 {
+		t.Errorf("SubtitleHash(65535 bytes) = %d, %v, want an error", size, err)
+	}
+	if _, _, err := SubtitleHash(filepath.Join(t.TempDir(), "missing.mkv")); err == nil {
+		t.Error("SubtitleHash(missing file) returned no error")
+	}
+}
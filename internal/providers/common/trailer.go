@@ -0,0 +1,81 @@
+package common
+
+import (
+	"sort"
+	"strings"
+)
+
+type TrailerData struct {
+	Title     string `json:"title"`
+	Link      string `json:"link"`
+	Site      string `json:"site"`     // ex: youtube, vimeo
+	Type      string `json:"type"`     // ex: trailer, teaser
+	Language  string `json:"language"` // iso 639-1 code, empty if unknown
+	Official  bool   `json:"official"`
+	Published Date   `json:"published"`
+}
+
+// rules used to select the default trailer of a media
+type TrailerPolicy struct {
+	Languages    []string // preferred languages, by order of preference, trailers in other languages are used as a last resort
+	Types        []string // accepted types, by order of preference, all types are accepted if empty
+	Sites        []string // accepted sites, all sites are accepted if empty
+	OfficialOnly bool     // ignore unofficial trailers
+	Newest       bool     // prefer the most recent trailer instead of the oldest one
+}
+
+func NewTrailerPolicy() TrailerPolicy {
+	return TrailerPolicy{Languages: []string{}, Types: []string{"trailer", "teaser"}, Sites: []string{}, OfficialOnly: false, Newest: false}
+}
+
+// returns the index of elem in list (case insensitive), len(list) if not found
+func rank(list []string, elem string) int {
+	for i, x := range list {
+		if strings.EqualFold(x, elem) {
+			return i
+		}
+	}
+	return len(list)
+}
+
+// Select the default trailer from a list of trailers
+// trailers are sorted by type, language, official flag and publication date
+// if no trailer matches the policy, an empty TrailerData is returned
+func SelectTrailer(trailers []TrailerData, policy TrailerPolicy) TrailerData {
+	candidates := []TrailerData{}
+	for _, i := range trailers {
+		if len(policy.Types) > 0 && rank(policy.Types, i.Type) == len(policy.Types) {
+			continue
+		}
+		if len(policy.Sites) > 0 && rank(policy.Sites, i.Site) == len(policy.Sites) {
+			continue
+		}
+		if policy.OfficialOnly && !i.Official {
+			continue
+		}
+		candidates = append(candidates, i)
+	}
+
+	if len(candidates) == 0 {
+		return TrailerData{}
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		x, y := candidates[a], candidates[b]
+		if r1, r2 := rank(policy.Types, x.Type), rank(policy.Types, y.Type); r1 != r2 {
+			return r1 < r2
+		}
+		if r1, r2 := rank(policy.Languages, x.Language), rank(policy.Languages, y.Language); r1 != r2 {
+			return r1 < r2
+		}
+		if x.Official != y.Official {
+			return x.Official
+		}
+		if policy.Newest {
+			return y.Published.Before(x.Published)
+		}
+		return x.Published.Before(y.Published)
+	})
+
+	return candidates[0]
+}
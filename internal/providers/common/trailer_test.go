@@ -0,0 +1,49 @@
+package common
+
+import "testing"
+
+func TestSelectTrailer(t *testing.T) {
+	trailers := []TrailerData{
+		{Link: "teaser-en", Site: "youtube", Type: "teaser", Language: "en", Official: true, Published: ParseDate("2010-01-01")},
+		{Link: "trailer-fr", Site: "youtube", Type: "Trailer", Language: "fr", Official: true, Published: ParseDate("2010-02-01")},
+		{Link: "trailer-en-old", Site: "vimeo", Type: "trailer", Language: "en", Official: true, Published: ParseDate("2010-03-01")},
+		{Link: "trailer-en-new", Site: "youtube", Type: "trailer", Language: "en", Official: true, Published: ParseDate("2010-04-01")},
+		{Link: "trailer-en-fan", Site: "youtube", Type: "trailer", Language: "en", Official: false, Published: ParseDate("2009-01-01")},
+		{Link: "clip-de", Site: "youtube", Type: "clip", Language: "de", Official: true},
+	}
+	newest := NewTrailerPolicy()
+	newest.Newest = true
+	tests := []struct {
+		name   string
+		policy TrailerPolicy
+		link   string
+	}{
+		{"default", NewTrailerPolicy(), "trailer-fr"},
+		{"language", TrailerPolicy{Languages: []string{"en"}, Types: []string{"trailer"}}, "trailer-en-old"},
+		{"language order", TrailerPolicy{Languages: []string{"de", "fr", "en"}, Types: []string{"trailer"}}, "trailer-fr"},
+		{"newest", TrailerPolicy{Languages: []string{"en"}, Types: []string{"trailer"}, Newest: true}, "trailer-en-new"},
+		{"newest without language", newest, "trailer-en-new"},
+		{"site", TrailerPolicy{Languages: []string{"en"}, Types: []string{"trailer"}, Sites: []string{"Vimeo"}}, "trailer-en-old"},
+		{"type order", TrailerPolicy{Languages: []string{"en"}, Types: []string{"teaser", "trailer"}}, "teaser-en"},
+		{"all types", TrailerPolicy{Languages: []string{"de"}}, "clip-de"},
+		{"no match", TrailerPolicy{Types: []string{"featurette"}}, ""},
+		{"official only", TrailerPolicy{Sites: []string{"dailymotion"}, OfficialOnly: true}, ""},
+	}
+	for _, i := range tests {
+		if tr := SelectTrailer(trailers, i.policy); tr.Link != i.link {
+			t.Errorf("%s: SelectTrailer() = %q, want %q", i.name, tr.Link, i.link)
+		}
+	}
+
+	// unofficial trailers are kept unless the policy requires official ones
+	fan := []TrailerData{{Link: "fan", Type: "trailer"}}
+	if tr := SelectTrailer(fan, NewTrailerPolicy()); tr.Link != "fan" {
+		t.Errorf("SelectTrailer(unofficial) = %q, want %q", tr.Link, "fan")
+	}
+	if tr := SelectTrailer(fan, TrailerPolicy{OfficialOnly: true}); tr.Link != "" {
+		t.Errorf("SelectTrailer(unofficial, official only) = %q, want no trailer", tr.Link)
+	}
+	if tr := SelectTrailer(nil, NewTrailerPolicy()); tr.Link != "" {
+		t.Errorf("SelectTrailer(nil) = %q, want no trailer", tr.Link)
+	}
+}
@@ -1,36 +1,46 @@
 package common
 
+import "time"
+
 type TVShowProvider interface {
 	Provider
 	SearchTVS(name string) ([]SearchData, error)
+	FindTVSByExternalID(source ExternalSource, id string) ([]SearchData, error)
 	GetTVS() (TVSData, error)
 	GetTVSSeason(season int) (TVSSeasonData, error)
 	GetTVSEpisode(season int, episode int) (TVSEpisodeData, error)
 	ListTVSTag() ([]TagData, error)
+	ListTVSCompany() ([]CompanyData, error)
 	ListTVSPerson() ([]PersonData, error)
+	ListTVSEpisodePerson(season int, episode int) ([]PersonData, error)
 	GetTVSUpcoming() (UpcomingData, error)
+	ListEpisodeGroups() ([]EpisodeGroupData, error)
 }
 
 type TVSData struct {
-	Title     string `json:"title"`
-	Overview  string `json:"overview"`
-	Icon      string `json:"icon"`
-	Fanart    string `json:"fanart"`
-	Website   string `json:"website"`
-	Trailer   string `json:"trailer"`
-	Premiered int64  `json:"premiered"`
-	Rating    int64  `json:"rating"`
+	Title     string        `json:"title"`
+	Overview  string        `json:"overview"`
+	Icon      string        `json:"icon"`
+	Fanart    string        `json:"fanart"`
+	Website   string        `json:"website"`
+	Trailer   string        `json:"trailer"`
+	Trailers  []TrailerData `json:"trailers"`
+	Premiered Date          `json:"premiered"`
+	Rating    int64         `json:"rating"`
+	Ratings   []RatingData  `json:"ratings"` // detailed ratings, from several sources if available
+	Awards    string        `json:"awards"`
 	ScraperInfo
 }
 
 type TVSSeasonData struct {
-	Title     string `json:"title"`
-	Overview  string `json:"overview"`
-	Icon      string `json:"icon"`
-	Fanart    string `json:"fanart"`
-	Trailer   string `json:"trailer"`
-	Premiered int64  `json:"premiered"`
-	Rating    int64  `json:"rating"`
+	Title        string `json:"title"`
+	Overview     string `json:"overview"`
+	Icon         string `json:"icon"`
+	Fanart       string `json:"fanart"`
+	Trailer      string `json:"trailer"`
+	Premiered    Date   `json:"premiered"`
+	Rating       int64  `json:"rating"`
+	EpisodeCount int64  `json:"episodeCount"` // number of episodes of the season, 0 if unknown
 	ScraperInfo
 }
 
@@ -38,9 +48,45 @@ type TVSEpisodeData struct {
 	Title     string `json:"title"`
 	Overview  string `json:"overview"`
 	Icon      string `json:"icon"`
-	Premiered int64  `json:"premiered"`
+	Premiered Date   `json:"premiered"`
 	Rating    int64  `json:"rating"`
 	Season    int64  `json:"season"`
 	Episode   int64  `json:"episode"`
 	ScraperInfo
 }
+
+// optional interface implemented by the tvs providers able to list the shows modified since a given date
+type TVShowChangeProvider interface {
+	TVSChangedSince(since time.Time) ([]string, error) // returns the ScraperID of the modified shows
+}
+
+// optional interface implemented by the tvs providers able to list all the episodes of a tvs in a single request
+type TVShowEpisodeListProvider interface {
+	ListTVSEpisode() ([]TVSEpisodeData, error) // Season and Episode are set for each episode
+}
+
+// optional interface implemented by the tvs providers reading the metadata embedded in the video files (ex: mkv or mp4 tags)
+// or matching the files with an external list of episodes (ex: podcast feed)
+// used to identify the episodes whose file names do not contain the season and episode numbers
+type TVShowFileTagProvider interface {
+	GetTVSEpisodeFromFile(path string) (TVSEpisodeData, error) // Season and Episode are always set, an error is returned if they are not found
+}
+
+// optional interface implemented by the tvs providers reading data stored next to the media files (ex: nfo files)
+// the search is made from the path of the tvs folder instead of its name
+// if the ScraperID of the results is a path, the scraper stores it relative to the library and configures the provider with the full path
+// the local artworks (Icon, Fanart) are returned as full paths, the scraper also stores them relative to the library
+type LocalTVShowProvider interface {
+	SearchTVSFromPath(path string) ([]SearchData, error)
+}
+
+// alternative ordering of the episodes of a tvs (dvd order, absolute order, story arcs, ...)
+// the group is selected by passing its ScraperData to Configure
+type EpisodeGroupData struct {
+	Title        string `json:"title"`
+	Overview     string `json:"overview"`
+	Type         string `json:"type"`
+	EpisodeCount int64  `json:"episodeCount"`
+	GroupCount   int64  `json:"groupCount"`
+	ScraperInfo
+}
@@ -0,0 +1,533 @@
+package scraper
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/scraper/common"
+	"github.com/zogwine/metadata/internal/util"
+)
+
+// name of the composite provider in the scraper configuration
+const CompositeName = "composite"
+
+// fields of the composite provider, each field can use its own order of providers
+// the fields apply to the tvs, seasons and episodes
+const (
+	CompositeTitle     = "title"
+	CompositeOverview  = "overview"
+	CompositeIcon      = "icon"
+	CompositeFanart    = "fanart"
+	CompositeWebsite   = "website"
+	CompositeTrailer   = "trailer"
+	CompositePremiered = "premiered"
+	CompositeRating    = "rating"
+	CompositeAwards    = "awards"
+	CompositeTags      = "tags"
+	CompositeCompanies = "companies"
+	CompositePersons   = "persons"
+)
+
+// identifiers of a media in one of the providers of a composite provider
+type CompositeMember struct {
+	ScraperID   string `json:"scraperID"`
+	ScraperData string `json:"scraperData"`
+}
+
+// settings and identifiers of the media of a composite provider
+// the configuration contains the default order of the providers (ex: providers: tmdb,nfo,omdb)
+// and optionally an order for each field (ex: rating: omdb,tmdb or overview: nfo,tmdb)
+// the first provider of the default order identifies the media, the media is then searched in the other providers
+// ScraperData contains the identifiers of the media in each provider, encoded in json
+type compositeBase struct {
+	Names       []string            // default order of the providers
+	Fields      map[string][]string // order of the providers for each field
+	Path        string              // path of the media, used to find it in the local providers (ex: nfo)
+	LibPath     string              // path of the library, the ids of the local providers are stored relative to it
+	ScraperName string
+	ScraperID   string
+	ScraperData string
+	Logger      *log.Logger
+	members     map[string]CompositeMember
+	resolved    bool
+	isLocal     func(name string) bool // returns true if the provider identifies the media by its path
+}
+
+// read the order of the providers, unknown providers are ignored
+func (c *compositeBase) setup(config map[string]string, logger *log.Logger, exists func(name string) bool) error {
+	c.Logger = logger
+	c.Names = []string{}
+	c.Fields = map[string][]string{}
+	for key, val := range config {
+		names := []string{}
+		for _, i := range strings.Split(val, ",") {
+			i = strings.TrimSpace(i)
+			if i == "" || i == CompositeName {
+				continue
+			}
+			if !exists(i) {
+				c.Logger.WithFields(log.Fields{"entity": "scraper", "file": "composite", "function": "setup"}).Warnf("unknown provider: %s", i)
+				continue
+			}
+			names = append(names, i)
+		}
+		if key == "providers" {
+			c.Names = names
+		} else {
+			c.Fields[key] = names
+		}
+	}
+	if len(c.Names) == 0 {
+		return errors.New("no provider configured for the composite provider")
+	}
+	return nil
+}
+
+func (c *compositeBase) Configure(ScraperID string, ScraperData string) {
+	c.ScraperID = ScraperID
+	c.ScraperData = ScraperData
+	c.resolved = false
+	c.members = map[string]CompositeMember{}
+	if json.Unmarshal([]byte(ScraperData), &c.members) != nil || c.members == nil {
+		// the identifiers of the primary provider are used directly
+		c.members = map[string]CompositeMember{c.Names[0]: {ScraperID: ScraperID, ScraperData: ScraperData}}
+	}
+	if _, ok := c.members[c.Names[0]]; !ok {
+		c.members[c.Names[0]] = CompositeMember{ScraperID: ScraperID}
+	}
+}
+
+// returns the order of the providers for a field
+func (c *compositeBase) order(field string) []string {
+	if names, ok := c.Fields[field]; ok && len(names) > 0 {
+		return names
+	}
+	return c.Names
+}
+
+// returns the names of all the providers used by the fields, the default order first
+func (c *compositeBase) allNames() []string {
+	ret := append([]string{}, c.Names...)
+	fields := []string{}
+	for k := range c.Fields {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	for _, k := range fields {
+		for _, i := range c.Fields[k] {
+			if !util.Contains(ret, i) {
+				ret = append(ret, i)
+			}
+		}
+	}
+	return ret
+}
+
+// returns the id of a member as stored in ScraperData, the paths of the local providers are relative to the library
+func (c *compositeBase) storedID(name string, id string) string {
+	if c.isLocal(name) {
+		return RelativeScraperID(c.LibPath, id)
+	}
+	return id
+}
+
+// returns the id of a member as expected by its provider
+func (c *compositeBase) providerID(name string, id string) string {
+	if c.isLocal(name) {
+		return AbsoluteScraperID(c.LibPath, id)
+	}
+	return id
+}
+
+// encode the identifiers of the members in ScraperData
+func (c *compositeBase) setMember(name string, res common.SearchData) {
+	c.members[name] = CompositeMember{ScraperID: c.storedID(name, res.ScraperID), ScraperData: res.ScraperData}
+	raw, err := json.Marshal(c.members)
+	if err == nil {
+		c.ScraperData = string(raw)
+	}
+}
+
+// wrap the search results of the primary provider
+func (c *compositeBase) wrapResults(res []common.SearchData) []common.SearchData {
+	ret := []common.SearchData{}
+	for _, i := range res {
+		i.ScraperID = c.storedID(c.Names[0], i.ScraperID)
+		raw, err := json.Marshal(map[string]CompositeMember{c.Names[0]: {ScraperID: i.ScraperID, ScraperData: i.ScraperData}})
+		if err != nil {
+			continue
+		}
+		i.ScraperName = c.ScraperName
+		i.ScraperData = string(raw)
+		ret = append(ret, i)
+	}
+	return ret
+}
+
+// returns the value of the first provider of the order having a set value
+func compositeValue[D any, T any](order []string, data map[string]D, get func(D) T, isSet func(T) bool) T {
+	var zero T
+	for _, i := range order {
+		d, ok := data[i]
+		if !ok {
+			continue
+		}
+		if val := get(d); isSet(val) {
+			return val
+		}
+	}
+	return zero
+}
+
+// returns the first value of the order, and the first available value if the providers of the order have no value
+func compositeFirst[D any](order []string, names []string, data map[string]D) (D, bool) {
+	for _, list := range [][]string{order, names} {
+		for _, i := range list {
+			if d, ok := data[i]; ok {
+				return d, true
+			}
+		}
+	}
+	var zero D
+	return zero, false
+}
+
+func isString(val string) bool    { return val != "" }
+func isDate(val common.Date) bool { return val.IsKnown() }
+func isRating(val int64) bool     { return val > 0 }
+func isList[T any](val []T) bool  { return len(val) > 0 }
+
+// composite tvs provider, see compositeBase
+// the composite provider uses its own instances of the providers, they are only configured for the media of the composite provider
+type CompositeTVShowProvider struct {
+	compositeBase
+	Providers    map[string]common.TVShowProvider
+	constructors map[string]func() common.TVShowProvider
+	config       map[string]map[string]string // configuration of the providers, used to setup the instances
+}
+
+func NewCompositeTVShowProvider(constructors map[string]func() common.TVShowProvider, config map[string]map[string]string) *CompositeTVShowProvider {
+	c := &CompositeTVShowProvider{compositeBase: compositeBase{ScraperName: CompositeName}, Providers: map[string]common.TVShowProvider{}, constructors: constructors, config: config}
+	c.isLocal = func(name string) bool {
+		_, ok := c.Providers[name].(common.LocalTVShowProvider)
+		return ok
+	}
+	return c
+}
+
+// configure the order of the providers and create the instances of the providers used
+func (c *CompositeTVShowProvider) Setup(config map[string]string, logger *log.Logger) error {
+	err := c.setup(config, logger, func(name string) bool {
+		_, ok := c.constructors[name]
+		return ok
+	})
+	if err != nil {
+		return err
+	}
+	for _, i := range c.allNames() {
+		c.Providers[i] = c.constructors[i]()
+		c.Providers[i].Setup(c.config[i], logger)
+	}
+	return nil
+}
+
+// returns the composite provider, the wrappers adding the optional interfaces of the primary provider return the provider they wrap
+func (c *CompositeTVShowProvider) composite() *CompositeTVShowProvider {
+	return c
+}
+
+// implemented by the composite provider and its wrappers
+type compositeTVShowWrapper interface {
+	composite() *CompositeTVShowProvider
+}
+
+// returns the composite provider, nil if the provider is not a composite provider
+func asCompositeTVShowProvider(provider common.TVShowProvider) *CompositeTVShowProvider {
+	if c, ok := provider.(compositeTVShowWrapper); ok {
+		return c.composite()
+	}
+	return nil
+}
+
+// the composite provider only supports the local search and the change feed if its primary provider supports them
+// must be called after Setup
+func (c *CompositeTVShowProvider) withPrimaryInterfaces() common.TVShowProvider {
+	_, local := c.Providers[c.Names[0]].(common.LocalTVShowProvider)
+	_, changes := c.Providers[c.Names[0]].(common.TVShowChangeProvider)
+	switch {
+	case local && changes:
+		return compositeLocalChangeTVShowProvider{c}
+	case local:
+		return compositeLocalTVShowProvider{c}
+	case changes:
+		return compositeChangeTVShowProvider{c}
+	}
+	return c
+}
+
+type compositeLocalTVShowProvider struct{ *CompositeTVShowProvider }
+
+func (c compositeLocalTVShowProvider) SearchTVSFromPath(path string) ([]common.SearchData, error) {
+	return c.searchTVSFromPath(path)
+}
+
+type compositeChangeTVShowProvider struct{ *CompositeTVShowProvider }
+
+func (c compositeChangeTVShowProvider) TVSChangedSince(since time.Time) ([]string, error) {
+	return c.tvsChangedSince(since)
+}
+
+type compositeLocalChangeTVShowProvider struct{ *CompositeTVShowProvider }
+
+func (c compositeLocalChangeTVShowProvider) SearchTVSFromPath(path string) ([]common.SearchData, error) {
+	return c.searchTVSFromPath(path)
+}
+
+func (c compositeLocalChangeTVShowProvider) TVSChangedSince(since time.Time) ([]string, error) {
+	return c.tvsChangedSince(since)
+}
+
+// returns the provider configured for the media, nil if the media was not found in the provider
+func (c *CompositeTVShowProvider) member(name string) common.TVShowProvider {
+	c.resolve()
+	m, ok := c.members[name]
+	if !ok || m.ScraperID == "" {
+		return nil
+	}
+	p := c.Providers[name]
+	p.Configure(c.providerID(name, m.ScraperID), m.ScraperData)
+	return p
+}
+
+// find the media in the providers which do not know it yet
+// the local providers search from the path of the tvs, the others from the title and year given by the primary provider
+func (c *CompositeTVShowProvider) resolve() {
+	if c.resolved {
+		return
+	}
+	c.resolved = true
+
+	primary := common.TVSData{}
+	for _, name := range c.allNames() {
+		if _, ok := c.members[name]; ok {
+			continue
+		}
+		p := c.Providers[name]
+
+		if local, ok := p.(common.LocalTVShowProvider); ok && c.Path != "" {
+			res, err := local.SearchTVSFromPath(c.Path)
+			if err == nil && len(res) > 0 {
+				c.setMember(name, res[0])
+			}
+			continue
+		}
+
+		if primary.Title == "" {
+			m := c.members[c.Names[0]]
+			c.Providers[c.Names[0]].Configure(c.providerID(c.Names[0], m.ScraperID), m.ScraperData)
+			data, err := c.Providers[c.Names[0]].GetTVS()
+			if err != nil || data.Title == "" {
+				return
+			}
+			primary = data
+		}
+		res, err := p.SearchTVS(primary.Title)
+		if err != nil {
+			continue
+		}
+		selected, err := SelectBestItem(res, primary.Title, primary.Premiered.Year())
+		if err != nil {
+			// the provider may not know the premiere date
+			selected, err = SelectBestItem(res, primary.Title, 0)
+		}
+		if err == nil {
+			c.setMember(name, selected)
+		}
+	}
+}
+
+// search made by the primary provider
+func (c *CompositeTVShowProvider) SearchTVS(name string) ([]common.SearchData, error) {
+	res, err := c.Providers[c.Names[0]].SearchTVS(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrapResults(res), nil
+}
+
+// search made by the primary provider, if it reads the data stored in the tvs folder
+func (c *CompositeTVShowProvider) searchTVSFromPath(path string) ([]common.SearchData, error) {
+	res, err := c.Providers[c.Names[0]].(common.LocalTVShowProvider).SearchTVSFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrapResults(res), nil
+}
+
+func (c *CompositeTVShowProvider) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	res, err := c.Providers[c.Names[0]].FindTVSByExternalID(source, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrapResults(res), nil
+}
+
+// the changes of the primary provider are used, the composite tvs share its ScraperID
+func (c *CompositeTVShowProvider) tvsChangedSince(since time.Time) ([]string, error) {
+	return c.Providers[c.Names[0]].(common.TVShowChangeProvider).TVSChangedSince(since)
+}
+
+func (c *CompositeTVShowProvider) GetTVS() (common.TVSData, error) {
+	data := map[string]common.TVSData{}
+	for _, i := range c.allNames() {
+		if p := c.member(i); p != nil {
+			if d, err := p.GetTVS(); err == nil {
+				data[i] = d
+			}
+		}
+	}
+	first, ok := compositeFirst(c.Names[:1], c.Names, data)
+	if !ok {
+		return common.TVSData{}, errors.New("no data")
+	}
+
+	return common.TVSData{
+		Title:       compositeValue(c.order(CompositeTitle), data, func(d common.TVSData) string { return d.Title }, isString),
+		Overview:    compositeValue(c.order(CompositeOverview), data, func(d common.TVSData) string { return d.Overview }, isString),
+		Icon:        compositeValue(c.order(CompositeIcon), data, func(d common.TVSData) string { return d.Icon }, isString),
+		Fanart:      compositeValue(c.order(CompositeFanart), data, func(d common.TVSData) string { return d.Fanart }, isString),
+		Website:     compositeValue(c.order(CompositeWebsite), data, func(d common.TVSData) string { return d.Website }, isString),
+		Trailer:     compositeValue(c.order(CompositeTrailer), data, func(d common.TVSData) string { return d.Trailer }, isString),
+		Trailers:    compositeValue(c.order(CompositeTrailer), data, func(d common.TVSData) []common.TrailerData { return d.Trailers }, isList[common.TrailerData]),
+		Premiered:   compositeValue(c.order(CompositePremiered), data, func(d common.TVSData) common.Date { return d.Premiered }, isDate),
+		Rating:      compositeValue(c.order(CompositeRating), data, func(d common.TVSData) int64 { return d.Rating }, isRating),
+		Ratings:     compositeValue(c.order(CompositeRating), data, func(d common.TVSData) []common.RatingData { return d.Ratings }, isList[common.RatingData]),
+		Awards:      compositeValue(c.order(CompositeAwards), data, func(d common.TVSData) string { return d.Awards }, isString),
+		ScraperInfo: common.ScraperInfo{ScraperName: c.ScraperName, ScraperID: c.ScraperID, ScraperData: c.ScraperData, ScraperLink: first.ScraperLink},
+	}, nil
+}
+
+// the scraper info of the season is the one of the primary provider, or of the first provider having the season
+func (c *CompositeTVShowProvider) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	data := map[string]common.TVSSeasonData{}
+	for _, i := range c.allNames() {
+		if p := c.member(i); p != nil {
+			if d, err := p.GetTVSSeason(season); err == nil {
+				data[i] = d
+			}
+		}
+	}
+	first, ok := compositeFirst(c.Names[:1], c.allNames(), data)
+	if !ok {
+		return common.TVSSeasonData{}, errors.New("no data")
+	}
+
+	return common.TVSSeasonData{
+		Title:     compositeValue(c.order(CompositeTitle), data, func(d common.TVSSeasonData) string { return d.Title }, isString),
+		Overview:  compositeValue(c.order(CompositeOverview), data, func(d common.TVSSeasonData) string { return d.Overview }, isString),
+		Icon:      compositeValue(c.order(CompositeIcon), data, func(d common.TVSSeasonData) string { return d.Icon }, isString),
+		Fanart:    compositeValue(c.order(CompositeFanart), data, func(d common.TVSSeasonData) string { return d.Fanart }, isString),
+		Trailer:   compositeValue(c.order(CompositeTrailer), data, func(d common.TVSSeasonData) string { return d.Trailer }, isString),
+		Premiered: compositeValue(c.order(CompositePremiered), data, func(d common.TVSSeasonData) common.Date { return d.Premiered }, isDate),
+		Rating:    compositeValue(c.order(CompositeRating), data, func(d common.TVSSeasonData) int64 { return d.Rating }, isRating),
+		// the episodes are numbered by the primary provider
+		EpisodeCount: first.EpisodeCount,
+		ScraperInfo:  first.ScraperInfo,
+	}, nil
+}
+
+// the scraper info of the episode is the one of the primary provider, or of the first provider having the episode
+func (c *CompositeTVShowProvider) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	data := map[string]common.TVSEpisodeData{}
+	for _, i := range c.allNames() {
+		if p := c.member(i); p != nil {
+			if d, err := p.GetTVSEpisode(season, episode); err == nil {
+				data[i] = d
+			}
+		}
+	}
+	first, ok := compositeFirst(c.Names[:1], c.allNames(), data)
+	if !ok {
+		return common.TVSEpisodeData{}, errors.New("no data")
+	}
+
+	return common.TVSEpisodeData{
+		Title:       compositeValue(c.order(CompositeTitle), data, func(d common.TVSEpisodeData) string { return d.Title }, isString),
+		Overview:    compositeValue(c.order(CompositeOverview), data, func(d common.TVSEpisodeData) string { return d.Overview }, isString),
+		Icon:        compositeValue(c.order(CompositeIcon), data, func(d common.TVSEpisodeData) string { return d.Icon }, isString),
+		Premiered:   compositeValue(c.order(CompositePremiered), data, func(d common.TVSEpisodeData) common.Date { return d.Premiered }, isDate),
+		Rating:      compositeValue(c.order(CompositeRating), data, func(d common.TVSEpisodeData) int64 { return d.Rating }, isRating),
+		Season:      int64(season),
+		Episode:     int64(episode),
+		ScraperInfo: first.ScraperInfo,
+	}, nil
+}
+
+// the lists are not merged, the list of the first provider of the order returning items is used
+func (c *CompositeTVShowProvider) ListTVSTag() ([]common.TagData, error) {
+	for _, i := range c.order(CompositeTags) {
+		if p := c.member(i); p != nil {
+			if res, err := p.ListTVSTag(); err == nil && len(res) > 0 {
+				return res, nil
+			}
+		}
+	}
+	return []common.TagData{}, nil
+}
+
+func (c *CompositeTVShowProvider) ListTVSCompany() ([]common.CompanyData, error) {
+	for _, i := range c.order(CompositeCompanies) {
+		if p := c.member(i); p != nil {
+			if res, err := p.ListTVSCompany(); err == nil && len(res) > 0 {
+				return res, nil
+			}
+		}
+	}
+	return []common.CompanyData{}, nil
+}
+
+func (c *CompositeTVShowProvider) ListTVSPerson() ([]common.PersonData, error) {
+	for _, i := range c.order(CompositePersons) {
+		if p := c.member(i); p != nil {
+			if res, err := p.ListTVSPerson(); err == nil && len(res) > 0 {
+				return res, nil
+			}
+		}
+	}
+	return []common.PersonData{}, nil
+}
+
+func (c *CompositeTVShowProvider) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	for _, i := range c.order(CompositePersons) {
+		if p := c.member(i); p != nil {
+			if res, err := p.ListTVSEpisodePerson(season, episode); err == nil && len(res) > 0 {
+				return res, nil
+			}
+		}
+	}
+	return []common.PersonData{}, nil
+}
+
+func (c *CompositeTVShowProvider) GetTVSUpcoming() (common.UpcomingData, error) {
+	for _, i := range c.order(CompositePremiered) {
+		if p := c.member(i); p != nil {
+			if res, err := p.GetTVSUpcoming(); err == nil {
+				return res, nil
+			}
+		}
+	}
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+// the episode groups change the numbering of the episodes, only the groups of the primary provider are used
+func (c *CompositeTVShowProvider) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	p := c.member(c.Names[0])
+	if p == nil {
+		return []common.EpisodeGroupData{}, errors.New("no data")
+	}
+	return p.ListEpisodeGroups()
+}
@@ -1,29 +1,31 @@
-package scraper
-
-import (
-	"errors"
-
-	"github.com/zogwine/metadata/internal/database"
-	"github.com/zogwine/metadata/internal/status"
-)
-
-type ScraperScanConfig struct {
-	AutoAdd            bool
-	AddUnknown         bool
-	Enable3DScan       bool
-	MaxConcurrentScans int64
-}
-
-func StartScan(s *status.Status, mediaType database.MediaType, lib int64, conf ScraperScanConfig) error {
-
-	switch mediaType {
-	case database.MediaTypeTvs:
-		if lib == 0 {
-			return errors.New("library id is required for tvshow scan")
-		}
-		tv := NewTVSScraper(s)
-		return tv.Scan(lib, conf)
-	}
-
-	return errors.New("unsupported media type")
-}
+package scraper
+
+import (
+	"errors"
+
+	"github.com/zogwine/metadata/internal/database"
+	"github.com/zogwine/metadata/internal/status"
+)
+
+type ScraperScanConfig struct {
+	AutoAdd            bool
+	AddUnknown         bool
+	Enable3DScan       bool
+	MaxConcurrentScans int64
+	ChangedSince       int64    // if > 0, also refresh the items modified by their provider since this date (unix timestamp)
+	SubtitleLanguages  []string // preferred languages of the subtitles (iso 639-1), subtitles are not downloaded if empty
+}
+
+func StartScan(s *status.Status, mediaType database.MediaType, lib int64, conf ScraperScanConfig) error {
+
+	switch mediaType {
+	case database.MediaTypeTvs:
+		if lib == 0 {
+			return errors.New("library id is required for tvshow scan")
+		}
+		tv := NewTVSScraper(s)
+		return tv.Scan(lib, conf)
+	}
+
+	return errors.New("unsupported media type")
+}
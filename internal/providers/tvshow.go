@@ -1,543 +1,1108 @@
-package scraper
-
-import (
-	"context"
-	"errors"
-	"io/fs"
-	"os"
-	"path"
-	"path/filepath"
-	"regexp"
-	"strconv"
-	"strings"
-	"sync"
-	"time"
-
-	log "github.com/sirupsen/logrus"
-	"github.com/zogwine/metadata/internal/database"
-	"github.com/zogwine/metadata/internal/file"
-	"github.com/zogwine/metadata/internal/scraper/common"
-	"github.com/zogwine/metadata/internal/status"
-	"github.com/zogwine/metadata/internal/util"
-	"golang.org/x/sync/semaphore"
-)
-
-type TVSScraper struct {
-	MediaType     database.MediaType
-	IDLib         int64
-	LibPath       string
-	AutoAdd       bool
-	AddUnknown    bool
-	App           *status.Status
-	Providers     map[string]common.TVShowProvider
-	ProviderNames []string // list used to keep the order of preferences
-	RegexSeason   *regexp.Regexp
-	RegexEpisode  *regexp.Regexp
-}
-
-func (t *TVSScraper) getProviderFromName(pname string) (common.TVShowProvider, error) {
-	for name, prov := range t.Providers {
-		if name == pname {
-			return prov, nil
-		}
-	}
-	return nil, errors.New("provider " + pname + " not found")
-}
-
-func (t *TVSScraper) loadTVSPlugins() error {
-	names, config, err := ListScraperConfiguration(t.App, database.MediaTypeTvs)
-
-	if err != nil {
-		return err
-	}
-
-	for _, i := range names {
-		pl, err := util.LoadPlugin("TVShowProvider", "./plugins/scraper/"+i)
-		if err == nil {
-			p, ok := pl.(func() common.TVShowProvider)
-			if ok {
-				t.Providers[i] = p()
-				t.Providers[i].Setup(config[i], t.App.Log)
-				t.ProviderNames = append(t.ProviderNames, i)
-			}
-		}
-	}
-
-	t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "loadTVSPlugins"}).Info("loaded providers: " + strings.Join(t.ProviderNames, ","))
-
-	if len(t.Providers) == 0 {
-		return errors.New("no provider loaded")
-	}
-
-	return nil
-}
-
-func NewTVSScraper(s *status.Status) TVSScraper {
-	seasonReg := regexp.MustCompile(`(?i)(?:s)(\d+)(?:e)`)
-	epReg := regexp.MustCompile(`(?i)(?:s\d+e)(\d+)`)
-	t := TVSScraper{MediaType: database.MediaTypeTvs, IDLib: 0, AutoAdd: false, AddUnknown: true, App: s, Providers: map[string]common.TVShowProvider{}, ProviderNames: []string{}, RegexSeason: seasonReg, RegexEpisode: epReg}
-	err := t.loadTVSPlugins()
-	if err != nil {
-		t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "NewTVSScraper"}).Warn(err)
-	}
-	return t
-}
-
-func (t *TVSScraper) Scan(idlib int64, conf ScraperScanConfig) error {
-	t.IDLib = idlib
-	t.AutoAdd = conf.AutoAdd
-	t.AddUnknown = conf.AddUnknown
-	ctx := context.Background()
-
-	// get library base path
-	lib, err := t.App.DB.GetLibrary(ctx, t.IDLib)
-	if err != nil {
-		return errors.New("unable to retreive library path: " + err.Error())
-	}
-	t.LibPath = lib.Path
-
-	// get data for existing tvs
-	tvsData, err := t.App.DB.ListShow(ctx, 0)
-	if err != nil {
-		return err
-	}
-
-	tvsPaths := []string{}
-	for _, i := range tvsData {
-		tvsPaths = append(tvsPaths, i.Path)
-	}
-
-	// list items at this path
-	items, err := os.ReadDir(t.LibPath)
-	if err != nil {
-		return err
-	}
-
-	if conf.MaxConcurrentScans < 2 {
-		for _, i := range items {
-			t.processItemScan(i, tvsPaths, tvsData)
-		}
-	} else {
-		// TODO: fix bug with scaper when running a lot of concurrent goroutines (ex: 10)
-		sem := semaphore.NewWeighted(conf.MaxConcurrentScans) // semaphore used to limit the number of concurrent goroutines running
-		var wg sync.WaitGroup
-
-		for _, i := range items {
-			wg.Add(1)
-			sem.Acquire(context.Background(), 1)
-			go func(i fs.DirEntry, tvsPaths []string, tvsData []database.ListShowRow) {
-				defer wg.Done()
-				t.processItemScan(i, tvsPaths, tvsData)
-				sem.Release(1)
-			}(i, tvsPaths, tvsData)
-		}
-		wg.Wait()
-	}
-
-	return nil
-}
-
-// process each folder found at the root of our library, i.e. the tv shows
-func (t *TVSScraper) processItemScan(i fs.DirEntry, tvsPaths []string, tvsData []database.ListShowRow) {
-	var err error
-
-	if i.IsDir() {
-		// keep only the folders
-		currentShow := util.Index(tvsPaths, i.Name())
-		logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "Scan", "tvs": i.Name()}
-		t.App.Log.WithFields(logF).Debugf("processing tvs: %q", i.Name())
-
-		data := database.ListShowRow{}
-		if currentShow > -1 {
-			// if there is already an entry for this tvs
-			t.App.Log.WithFields(logF).Trace("tvs already in database")
-			data = tvsData[currentShow]
-			if data.UpdateMode > 0 {
-				// if updates are allowed
-				if data.ScraperID == "" || data.ScraperName == "" || data.ScraperName == " " {
-					// if no scraper is associated to this tvs, just re-run a search
-					t.App.Log.WithFields(logF).Trace("add tvs")
-					data, err = t.addTVS(data)
-				} else {
-					// else, update tvs metadata
-					t.App.Log.WithFields(logF).Trace("update tvs")
-					data, err = t.updateTVS(data)
-				}
-			} else {
-				t.App.Log.WithFields(logF).Trace("no update needed")
-			}
-		} else {
-			// if this is a newly discovered tvs
-			t.App.Log.WithFields(logF).Trace("new tvs: " + i.Name())
-			data.Title = i.Name()
-			data, err = t.addTVS(data)
-		}
-
-		if err == nil && data.ScraperID != "" {
-			// if a scraper is associated, update episodes
-			t.App.Log.WithFields(logF).Trace("update episodes")
-			err = t.updateTVSEpisodes(data)
-		}
-
-		if err != nil {
-			t.App.Log.WithFields(logF).Error(err)
-		}
-	}
-}
-
-func (t *TVSScraper) addTVS(data database.ListShowRow) (database.ListShowRow, error) {
-	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "addTVS", "tvs": data.Title}
-	searchResults := []common.SearchData{}
-	var err error
-
-	// retreive search results for each provider
-	for _, i := range t.ProviderNames {
-		res, err := t.Providers[i].SearchTVS(data.Title)
-		if err == nil {
-			searchResults = append(searchResults, res...)
-		}
-	}
-
-	if len(searchResults) == 0 && !t.AddUnknown {
-		return data, errors.New("no data avaiable for show " + data.Title)
-	}
-
-	if data.ID == 0 {
-		// if this is a new tvs
-		// create a new entry in the database
-		data.ID, err = t.App.DB.AddShow(context.Background(), database.AddShowParams{
-			Title:   data.Title,
-			IDLib:   t.IDLib,
-			AddDate: time.Now().Unix(),
-		})
-		if err != nil {
-			return data, err
-		}
-	}
-
-	if t.AutoAdd {
-		// if we want to try to automatically select the best result
-		selected, err := SelectBestItem(searchResults, data.Title, 0)
-		if err == nil {
-			t.App.Log.WithFields(logF).Tracef("auto select: %s: %s", selected.ScraperName, selected.ScraperID)
-			// if a result was selected
-			t.UpdateWithSelectionResult(data.ID, SelectionResult{ScraperName: selected.ScraperName, ScraperID: selected.ScraperID, ScraperData: selected.ScraperData})
-			data.ScraperID = selected.ScraperID
-			data.ScraperName = selected.ScraperName
-			data.ScraperData = selected.ScraperData
-			data.Path = data.Title
-			// force tvs update
-			return t.updateTVS(data)
-		} else {
-			t.App.Log.WithFields(logF).Trace("auto select failed, add multiple results")
-			AddMultipleResults(t.App, database.MediaTypeTvs, data.ID, searchResults, data.Title)
-		}
-	} else {
-		t.App.Log.WithFields(logF).Trace("add multiple results")
-		AddMultipleResults(t.App, database.MediaTypeTvs, data.ID, searchResults, data.Title)
-	}
-
-	return data, nil
-}
-
-// update tvs, tags and people metadata
-func (t *TVSScraper) updateTVS(data database.ListShowRow) (database.ListShowRow, error) {
-	ctx := context.Background()
-	provider, err := t.getProviderFromName(data.ScraperName)
-	if err != nil {
-		return data, err
-	}
-	provider.Configure(data.ScraperID, data.ScraperData)
-
-	// update tvs metadata
-	tvsData, err := provider.GetTVS()
-	if err != nil {
-		return data, err
-	}
-	err = t.App.DB.UpdateShow(ctx, database.UpdateShowParams{
-		Title:       tvsData.Title,
-		Overview:    tvsData.Overview,
-		Icon:        tvsData.Icon,
-		Fanart:      tvsData.Fanart,
-		Website:     tvsData.Website,
-		Trailer:     tvsData.Trailer,
-		Premiered:   tvsData.Premiered,
-		Rating:      tvsData.Rating,
-		ScraperLink: tvsData.ScraperInfo.ScraperLink,
-		ScraperData: tvsData.ScraperInfo.ScraperData,
-		UpdateDate:  time.Now().Unix(),
-		ID:          data.ID,
-		UpdateMode:  -1,
-	})
-	if err != nil {
-		return data, err
-	}
-	data.Title = tvsData.Title
-	data.ScraperData = tvsData.ScraperInfo.ScraperData
-	data.Premiered = tvsData.Premiered
-
-	// update tags
-	tagData, err := provider.ListTVSTag()
-	if err != nil {
-		return data, err
-	}
-	for _, i := range tagData {
-		AddTag(t.App, database.MediaTypeTvs, data.ID, i)
-	}
-
-	// update people
-	persData, err := provider.ListTVSPerson()
-	if err != nil {
-		return data, err
-	}
-	for _, i := range persData {
-		AddPerson(t.App, database.MediaTypeTvs, data.ID, i)
-	}
-
-	return data, nil
-}
-
-// update tvs seasons and episodes metadata
-func (t *TVSScraper) updateTVSEpisodes(data database.ListShowRow) error {
-	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "updateTVSEpisodes", "tvs": data.Title}
-
-	// get path to the root tvs folder
-	tvsPath := filepath.Join(t.LibPath, data.Path)
-	t.App.Log.WithFields(logF).Tracef("processing episodes in: %s", tvsPath)
-
-	// get provider
-	provider, err := t.getProviderFromName(data.ScraperName)
-	if err != nil {
-		return err
-	}
-	provider.Configure(data.ScraperID, data.ScraperData)
-
-	// list and update existing seasons
-	seasons, err := t.updateTVSSeasons(provider, data.ID)
-	if err != nil {
-		return err
-	}
-
-	// for each file in the tvs folder
-	for _, i := range ListFiles(tvsPath, true) {
-		t.App.Log.WithFields(logF).Tracef("processing episode: %s", i)
-		p := filepath.Join(data.Path, i)
-		if file.IsVideo(t.App, p) {
-			t.updateTVSEpisode(provider, &seasons, p, data.ID)
-		}
-	}
-
-	return nil
-}
-
-// update existing seasons for a tvshow, returns the list of existing season numbers
-func (t *TVSScraper) updateTVSSeasons(provider common.TVShowProvider, idshow int64) ([]int64, error) {
-	ctx := context.Background()
-	seasonData, err := t.App.DB.ListShowSeason(ctx, database.ListShowSeasonParams{IDUser: 0, IDShow: idshow})
-	if err != nil {
-		return []int64{}, err
-	}
-	seasons := []int64{}
-	for _, i := range seasonData {
-		if i.UpdateMode > 0 {
-			// update the seasons if needed
-			seasonData, err := provider.GetTVSSeason(int(i.Season))
-			if err == nil {
-				t.App.DB.UpdateShowSeason(ctx, database.UpdateShowSeasonParams{
-					Title:       seasonData.Title,
-					Overview:    seasonData.Overview,
-					Icon:        seasonData.Icon,
-					Season:      i.Season,
-					Fanart:      seasonData.Fanart,
-					Premiered:   seasonData.Premiered,
-					Rating:      seasonData.Rating,
-					Trailer:     seasonData.Trailer,
-					ScraperName: seasonData.ScraperInfo.ScraperName,
-					ScraperData: seasonData.ScraperInfo.ScraperData,
-					ScraperID:   seasonData.ScraperInfo.ScraperID,
-					ScraperLink: seasonData.ScraperInfo.ScraperLink,
-					UpdateDate:  time.Now().Unix(),
-					UpdateMode:  -1,
-					IDShow:      idshow,
-				})
-			} else {
-				t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "updateTVSSeasons", "tvs": idshow}).Error(err)
-			}
-		}
-
-		seasons = append(seasons, i.Season)
-	}
-	return seasons, nil
-}
-
-// update a tvshow episode based on the provided file path and idshow
-// takes a seasons argument with a pointer to a list of the existing seasons for this show, this list will be modified if a new season is added
-func (t *TVSScraper) updateTVSEpisode(provider common.TVShowProvider, seasons *[]int64, p string, idshow int64) {
-	ctx := context.Background()
-	filename := path.Base(p)
-	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "updateTVSEpisode", "tvs": filename}
-
-	videoData, err := t.App.DB.GetVideoFileFromPath(ctx, database.GetVideoFileFromPathParams{IDLib: t.IDLib, Path: p})
-	if err == nil {
-		t.App.Log.WithFields(logF).Trace("update episode")
-
-		episodeData, err := t.App.DB.GetShowEpisode(ctx, database.GetShowEpisodeParams{IDUser: 0, ID: videoData.MediaData})
-		if err == nil && episodeData.UpdateMode > 0 {
-			err = file.UpdateVideoFile(t.App, t.IDLib, p)
-			epData, err := provider.GetTVSEpisode(int(episodeData.Season), int(episodeData.Episode))
-			if err == nil {
-				t.App.DB.UpdateShowEpisode(ctx, database.UpdateShowEpisodeParams{
-					Title:       epData.Title,
-					Overview:    epData.Overview,
-					Icon:        epData.Icon,
-					Premiered:   epData.Premiered,
-					Rating:      epData.Rating,
-					ScraperID:   epData.ScraperInfo.ScraperID,
-					ScraperName: epData.ScraperInfo.ScraperName,
-					ScraperData: epData.ScraperInfo.ScraperData,
-					ScraperLink: epData.ScraperInfo.ScraperLink,
-					UpdateDate:  time.Now().Unix(),
-					UpdateMode:  -1,
-					ID:          episodeData.ID,
-				})
-			} else {
-				t.App.Log.WithFields(logF).Error(err)
-			}
-		} else {
-			t.App.Log.WithFields(logF).Tracef("no update requested or error: %s", err)
-		}
-	} else {
-		t.App.Log.WithFields(logF).Trace("no existing entry for this episode")
-		// if there are no existing entries for this episodes
-
-		// extract season and episode number from filename
-		searchSeason := t.RegexSeason.FindStringSubmatch(filename)
-		searchEpisode := t.RegexEpisode.FindStringSubmatch(filename)
-
-		if len(searchSeason) > 1 && searchSeason[1] != "" && len(searchEpisode) > 1 && searchEpisode[1] != "" {
-			season, _ := strconv.Atoi(string(searchSeason[1]))
-			episode, _ := strconv.Atoi(string(searchEpisode[1]))
-
-			if !util.Contains(*seasons, int64(season)) {
-				t.App.Log.WithFields(logF).Tracef("unknown season: %d", season)
-				// if the season is unknown, add it
-				seasonData, err := provider.GetTVSSeason(season)
-				if err == nil {
-					t.App.DB.AddShowSeason(ctx, database.AddShowSeasonParams{
-						Title:       seasonData.Title,
-						Overview:    seasonData.Overview,
-						Icon:        seasonData.Icon,
-						Season:      int64(season),
-						Fanart:      seasonData.Fanart,
-						Premiered:   seasonData.Premiered,
-						Rating:      seasonData.Rating,
-						Trailer:     seasonData.Trailer,
-						ScraperName: seasonData.ScraperInfo.ScraperName,
-						ScraperData: seasonData.ScraperInfo.ScraperData,
-						ScraperID:   seasonData.ScraperInfo.ScraperID,
-						ScraperLink: seasonData.ScraperInfo.ScraperLink,
-						AddDate:     time.Now().Unix(),
-						UpdateMode:  -1,
-						IDShow:      idshow,
-					})
-				} else {
-					t.App.Log.WithFields(logF).Error(err)
-					t.App.DB.AddShowSeason(ctx, database.AddShowSeasonParams{
-						Title:       "Season " + strconv.Itoa(season),
-						Season:      int64(season),
-						ScraperName: seasonData.ScraperInfo.ScraperName,
-						ScraperData: seasonData.ScraperInfo.ScraperData,
-						ScraperID:   seasonData.ScraperInfo.ScraperID,
-						ScraperLink: seasonData.ScraperInfo.ScraperLink,
-						AddDate:     time.Now().Unix(),
-						UpdateMode:  -1,
-						IDShow:      idshow,
-					})
-				}
-				*seasons = append(*seasons, int64(season))
-			}
-
-			// add the episode
-			epData, err := provider.GetTVSEpisode(season, episode)
-			if err == nil {
-				t.App.Log.WithFields(logF).Tracef("add episode: %d for season: %d", episode, season)
-				idEp, err := t.App.DB.AddShowEpisode(ctx, database.AddShowEpisodeParams{
-					Title:       epData.Title,
-					Overview:    epData.Overview,
-					Icon:        epData.Icon,
-					Premiered:   epData.Premiered,
-					Rating:      epData.Rating,
-					Season:      int64(season),
-					Episode:     int64(episode),
-					ScraperName: epData.ScraperInfo.ScraperName,
-					ScraperID:   epData.ScraperInfo.ScraperID,
-					ScraperData: epData.ScraperInfo.ScraperData,
-					ScraperLink: epData.ScraperInfo.ScraperLink,
-					AddDate:     time.Now().Unix(),
-					UpdateMode:  -1,
-					IDShow:      idshow,
-				})
-				if err == nil {
-					_, err = file.AddVideoFile(t.App, t.IDLib, p, database.MediaTypeTvsEpisode, idEp, false)
-					if err != nil {
-						t.App.Log.WithFields(logF).Error(err)
-					}
-				} else {
-					t.App.Log.WithFields(logF).Error(err)
-				}
-			} else if t.AddUnknown {
-				t.App.Log.WithFields(logF).Warn("no data found for s" + strconv.Itoa(season) + "e" + strconv.Itoa(episode) + ", adding empty val")
-				// if no data is found but addUnknown is enabled
-				idEp, err := t.App.DB.AddShowEpisode(ctx, database.AddShowEpisodeParams{
-					Title:      filename,
-					AddDate:    time.Now().Unix(),
-					UpdateMode: -1,
-					Season:     int64(season),
-					Episode:    int64(episode),
-					IDShow:     idshow,
-				})
-				if err == nil {
-					_, err = file.AddVideoFile(t.App, t.IDLib, p, database.MediaTypeTvsEpisode, idEp, false)
-					if err != nil {
-						t.App.Log.WithFields(logF).Error(err)
-					}
-				} else {
-					t.App.Log.WithFields(logF).Error(err)
-				}
-			} else {
-				t.App.Log.WithFields(logF).Warn("no data found for s" + strconv.Itoa(season) + "e" + strconv.Itoa(episode))
-			}
-		} else {
-			t.App.Log.WithFields(logF).Warn("unable to extract season/episode info for: " + string(filename))
-		}
-	}
-}
-
-func (t *TVSScraper) UpdateWithSelectionResult(id int64, selection SelectionResult) error {
-	ctx := context.Background()
-	// update tvs
-	err := t.App.DB.UpdateShow(ctx, database.UpdateShowParams{ScraperID: selection.ScraperID, ScraperName: selection.ScraperName, ScraperData: selection.ScraperData, UpdateMode: 1, ID: id})
-	if err != nil {
-		return err
-	}
-	// purge outdated data
-	// force rescan of seasons and episodes
-	err = t.App.DB.UpdateShowAllSeasons(ctx, database.UpdateShowAllSeasonsParams{IDShow: id, ScraperName: " ", ScraperID: "0", UpdateMode: 1})
-	if err != nil {
-		return err
-	}
-	err = t.App.DB.UpdateShowAllEpisodes(ctx, database.UpdateShowAllEpisodesParams{IDShow: id, ScraperName: " ", ScraperID: "0", UpdateMode: 1})
-	if err != nil {
-		return err
-	}
-	// delete tags and people
-	err = t.App.DB.DeleteAllTagLinks(ctx, database.DeleteAllTagLinksParams{MediaType: database.MediaTypeTvs, MediaData: id})
-	if err != nil {
-		return err
-	}
-	err = t.App.DB.DeleteAllPersonLinks(ctx, database.DeleteAllPersonLinksParams{MediaType: database.MediaTypeTvs, MediaData: id})
-	if err != nil {
-		return err
-	}
-	return nil
-}
+package scraper
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/database"
+	"github.com/zogwine/metadata/internal/file"
+	"github.com/zogwine/metadata/internal/scraper/common"
+	"github.com/zogwine/metadata/internal/status"
+	"github.com/zogwine/metadata/internal/util"
+	"golang.org/x/sync/semaphore"
+)
+
+type TVSScraper struct {
+	MediaType     database.MediaType
+	IDLib         int64
+	LibPath       string
+	AutoAdd       bool
+	AddUnknown    bool
+	App           *status.Status
+	Providers     map[string]common.TVShowProvider
+	ProviderNames []string // list used to keep the order of preferences
+	RegexSeason   *regexp.Regexp
+	RegexEpisode  *regexp.Regexp
+	Changes       map[string]map[string]bool // provider name -> ScraperID of the modified shows, the providers without change feed have no entry
+	ChangedAll    map[string]bool            // providers whose changes are not available since the requested date, all their shows are refreshed
+	Fillers       map[string]common.FillerProvider
+	FillerNames   []string    // list used to keep the order of preferences
+	FillerLock    *sync.Mutex // the filler providers are shared by the concurrent scans, a show is selected with Configure
+	Subtitles     map[string]common.SubtitleProvider
+	SubtitleNames []string // list used to keep the order of preferences
+	SubLanguages  []string // preferred languages of the subtitles downloaded for the new episodes
+}
+
+func (t *TVSScraper) getProviderFromName(pname string) (common.TVShowProvider, error) {
+	for name, prov := range t.Providers {
+		if name == pname {
+			return prov, nil
+		}
+	}
+	return nil, errors.New("provider " + pname + " not found")
+}
+
+func (t *TVSScraper) loadTVSPlugins() error {
+	names, config, err := ListScraperConfiguration(t.App, database.MediaTypeTvs)
+
+	if err != nil {
+		return err
+	}
+
+	constructors := map[string]func() common.TVShowProvider{}
+	for _, i := range names {
+		pl, err := util.LoadPlugin("TVShowProvider", "./plugins/scraper/"+i)
+		if err == nil {
+			p, ok := pl.(func() common.TVShowProvider)
+			if ok {
+				constructors[i] = p
+				t.Providers[i] = p()
+				t.Providers[i].Setup(config[i], t.App.Log)
+				t.ProviderNames = append(t.ProviderNames, i)
+			}
+		}
+		// filler providers only classify the episodes of the shows found by the other providers
+		pl, err = util.LoadPlugin("FillerProvider", "./plugins/scraper/"+i)
+		if err == nil {
+			p, ok := pl.(func() common.FillerProvider)
+			if ok {
+				t.Fillers[i] = p()
+				t.Fillers[i].Setup(config[i], t.App.Log)
+				t.FillerNames = append(t.FillerNames, i)
+			}
+		}
+		// subtitle providers are used for the video files of the new episodes
+		pl, err = util.LoadPlugin("SubtitleProvider", "./plugins/scraper/"+i)
+		if err == nil {
+			p, ok := pl.(func() common.SubtitleProvider)
+			if ok {
+				t.Subtitles[i] = p()
+				t.Subtitles[i].Setup(config[i], t.App.Log)
+				t.SubtitleNames = append(t.SubtitleNames, i)
+			}
+		}
+	}
+
+	// the composite provider merges the data of the other providers, it keeps its position in the order of preferences
+	if util.Contains(names, CompositeName) {
+		c := NewCompositeTVShowProvider(constructors, config)
+		err = c.Setup(config[CompositeName], t.App.Log)
+		if err == nil {
+			t.Providers[CompositeName] = c.withPrimaryInterfaces()
+			t.ProviderNames = []string{}
+			for _, i := range names {
+				if _, ok := t.Providers[i]; ok {
+					t.ProviderNames = append(t.ProviderNames, i)
+				}
+			}
+		} else {
+			t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "loadTVSPlugins"}).Warn(err)
+		}
+	}
+
+	t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "loadTVSPlugins"}).Info("loaded providers: " + strings.Join(t.ProviderNames, ","))
+	if len(t.FillerNames) > 0 {
+		t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "loadTVSPlugins"}).Info("loaded filler providers: " + strings.Join(t.FillerNames, ","))
+	}
+	if len(t.SubtitleNames) > 0 {
+		t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "loadTVSPlugins"}).Info("loaded subtitle providers: " + strings.Join(t.SubtitleNames, ","))
+	}
+
+	if len(t.Providers) == 0 {
+		return errors.New("no provider loaded")
+	}
+
+	return nil
+}
+
+func NewTVSScraper(s *status.Status) TVSScraper {
+	seasonReg := regexp.MustCompile(`(?i)(?:s)(\d+)(?:e)`)
+	epReg := regexp.MustCompile(`(?i)(?:s\d+e)(\d+)`)
+	t := TVSScraper{MediaType: database.MediaTypeTvs, IDLib: 0, AutoAdd: false, AddUnknown: true, App: s, Providers: map[string]common.TVShowProvider{}, ProviderNames: []string{}, Fillers: map[string]common.FillerProvider{}, FillerNames: []string{}, FillerLock: &sync.Mutex{}, Subtitles: map[string]common.SubtitleProvider{}, SubtitleNames: []string{}, RegexSeason: seasonReg, RegexEpisode: epReg}
+	err := t.loadTVSPlugins()
+	if err != nil {
+		t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "NewTVSScraper"}).Warn(err)
+	}
+	return t
+}
+
+func (t *TVSScraper) Scan(idlib int64, conf ScraperScanConfig) error {
+	t.IDLib = idlib
+	t.AutoAdd = conf.AutoAdd
+	t.AddUnknown = conf.AddUnknown
+	t.SubLanguages = conf.SubtitleLanguages
+	ctx := context.Background()
+
+	// get library base path
+	lib, err := t.App.DB.GetLibrary(ctx, t.IDLib)
+	if err != nil {
+		return errors.New("unable to retreive library path: " + err.Error())
+	}
+	t.LibPath = lib.Path
+
+	// list the shows modified since the last refresh
+	if conf.ChangedSince > 0 {
+		t.loadChanges(time.Unix(conf.ChangedSince, 0))
+	}
+
+	// get data for existing tvs
+	tvsData, err := t.App.DB.ListShow(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	tvsPaths := []string{}
+	for _, i := range tvsData {
+		tvsPaths = append(tvsPaths, i.Path)
+	}
+
+	// list items at this path
+	items, err := os.ReadDir(t.LibPath)
+	if err != nil {
+		return err
+	}
+
+	if conf.MaxConcurrentScans < 2 {
+		for _, i := range items {
+			t.processItemScan(i, tvsPaths, tvsData)
+		}
+	} else {
+		// TODO: fix bug with scaper when running a lot of concurrent goroutines (ex: 10)
+		sem := semaphore.NewWeighted(conf.MaxConcurrentScans) // semaphore used to limit the number of concurrent goroutines running
+		var wg sync.WaitGroup
+
+		for _, i := range items {
+			wg.Add(1)
+			sem.Acquire(context.Background(), 1)
+			go func(i fs.DirEntry, tvsPaths []string, tvsData []database.ListShowRow) {
+				defer wg.Done()
+				t.processItemScan(i, tvsPaths, tvsData)
+				sem.Release(1)
+			}(i, tvsPaths, tvsData)
+		}
+		wg.Wait()
+	}
+
+	return nil
+}
+
+// process each folder found at the root of our library, i.e. the tv shows
+func (t *TVSScraper) processItemScan(i fs.DirEntry, tvsPaths []string, tvsData []database.ListShowRow) {
+	var err error
+	refresh := false
+
+	if i.IsDir() {
+		// keep only the folders
+		currentShow := util.Index(tvsPaths, i.Name())
+		logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "Scan", "tvs": i.Name()}
+		t.App.Log.WithFields(logF).Debugf("processing tvs: %q", i.Name())
+
+		data := database.ListShowRow{}
+		if currentShow > -1 {
+			// if there is already an entry for this tvs
+			t.App.Log.WithFields(logF).Trace("tvs already in database")
+			data = tvsData[currentShow]
+			if data.UpdateMode > 0 {
+				// if updates are allowed
+				if data.ScraperID == "" || data.ScraperName == "" || data.ScraperName == " " {
+					// if no scraper is associated to this tvs, just re-run a search
+					t.App.Log.WithFields(logF).Trace("add tvs")
+					data, err = t.addTVS(data)
+				} else {
+					// else, update tvs metadata
+					t.App.Log.WithFields(logF).Trace("update tvs")
+					data, err = t.updateTVS(data)
+				}
+			} else if t.isChanged(data) {
+				// else, if the remote data changed, update tvs and its episodes
+				t.App.Log.WithFields(logF).Trace("remote data changed, update tvs")
+				data, err = t.updateTVS(data)
+				refresh = true
+			} else {
+				t.App.Log.WithFields(logF).Trace("no update needed")
+			}
+		} else {
+			// if this is a newly discovered tvs
+			t.App.Log.WithFields(logF).Trace("new tvs: " + i.Name())
+			data.Title = i.Name()
+			data, err = t.addTVS(data)
+		}
+
+		if err == nil && data.ScraperID != "" {
+			// if a scraper is associated, update episodes
+			t.App.Log.WithFields(logF).Trace("update episodes")
+			err = t.updateTVSEpisodes(data, refresh)
+		}
+
+		if err != nil {
+			t.App.Log.WithFields(logF).Error(err)
+		}
+	}
+}
+
+func (t *TVSScraper) addTVS(data database.ListShowRow) (database.ListShowRow, error) {
+	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "addTVS", "tvs": data.Title}
+	searchResults := []common.SearchData{}
+	var err error
+
+	// local metadata (ex: nfo files) stored in the tvs folder are preferred over a search
+	found, foundErr := t.findLocalTVS(data)
+	if foundErr != nil {
+		// if the folder name contains an external id (ex: tt1234567), use it instead of a search by title
+		found, foundErr = t.findTVSByExternalID(data.Title)
+	}
+
+	if foundErr != nil {
+		// retreive search results for each provider
+		for _, i := range t.ProviderNames {
+			res, err := t.Providers[i].SearchTVS(data.Title)
+			if err == nil {
+				searchResults = append(searchResults, res...)
+			}
+		}
+
+		if len(searchResults) == 0 && !t.AddUnknown {
+			return data, errors.New("no data avaiable for show " + data.Title)
+		}
+	}
+
+	if data.ID == 0 {
+		// if this is a new tvs
+		// create a new entry in the database
+		data.ID, err = t.App.DB.AddShow(context.Background(), database.AddShowParams{
+			Title:   data.Title,
+			IDLib:   t.IDLib,
+			AddDate: time.Now().Unix(),
+		})
+		if err != nil {
+			return data, err
+		}
+	}
+
+	if foundErr == nil {
+		t.App.Log.WithFields(logF).Tracef("direct select: %s: %s", found.ScraperName, found.ScraperID)
+		return t.selectTVS(data, found)
+	}
+
+	if t.AutoAdd {
+		// if we want to try to automatically select the best result
+		selected, err := SelectBestItem(searchResults, data.Title, 0)
+		if err == nil {
+			t.App.Log.WithFields(logF).Tracef("auto select: %s: %s", selected.ScraperName, selected.ScraperID)
+			// if a result was selected
+			return t.selectTVS(data, selected)
+		} else {
+			t.App.Log.WithFields(logF).Trace("auto select failed, add multiple results")
+			AddMultipleResults(t.App, database.MediaTypeTvs, data.ID, searchResults, data.Title)
+		}
+	} else {
+		t.App.Log.WithFields(logF).Trace("add multiple results")
+		AddMultipleResults(t.App, database.MediaTypeTvs, data.ID, searchResults, data.Title)
+	}
+
+	return data, nil
+}
+
+// select the tvs in a provider
+// the composite provider also needs the path of the tvs to find it in the local providers
+func (t *TVSScraper) configureProvider(provider common.TVShowProvider, data database.ListShowRow) {
+	provider.Configure(t.providerID(provider, data.ScraperID), data.ScraperData)
+	if c := asCompositeTVShowProvider(provider); c != nil {
+		path := data.Path
+		if path == "" {
+			path = data.Title
+		}
+		c.Path = filepath.Join(t.LibPath, path)
+	}
+}
+
+// returns the ScraperID expected by a provider
+// the local providers identify a tvs by its path, which is stored relative to the library
+func (t *TVSScraper) providerID(provider common.TVShowProvider, id string) string {
+	if c := asCompositeTVShowProvider(provider); c != nil {
+		// the composite provider converts the ids of its members itself
+		c.LibPath = t.LibPath
+		return id
+	}
+	if _, ok := provider.(common.LocalTVShowProvider); ok {
+		return AbsoluteScraperID(t.LibPath, id)
+	}
+	return id
+}
+
+// find a tvs from the local metadata stored in its folder
+// the first local provider returning a result is used
+func (t *TVSScraper) findLocalTVS(data database.ListShowRow) (common.SearchData, error) {
+	path := data.Path
+	if path == "" {
+		path = data.Title
+	}
+	for _, i := range t.ProviderNames {
+		local, ok := t.Providers[i].(common.LocalTVShowProvider)
+		if !ok {
+			continue
+		}
+		if c := asCompositeTVShowProvider(t.Providers[i]); c != nil {
+			c.LibPath = t.LibPath
+		}
+		res, err := local.SearchTVSFromPath(filepath.Join(t.LibPath, path))
+		if err == nil && len(res) > 0 {
+			res[0].ScraperID = RelativeScraperID(t.LibPath, res[0].ScraperID)
+			return res[0], nil
+		}
+	}
+	return common.SearchData{}, errors.New("no data")
+}
+
+// returns the overrides written by the user in the tvs folder
+// the first override provider returning data is used, nil is returned if there are no valid overrides
+func (t *TVSScraper) getTVSOverride(data database.ListShowRow) *common.TVSOverrideData {
+	path := data.Path
+	if path == "" {
+		path = data.Title
+	}
+	for _, i := range t.ProviderNames {
+		p, ok := t.Providers[i].(common.TVShowOverrideProvider)
+		if !ok {
+			continue
+		}
+		o, err := p.GetTVSOverride(filepath.Join(t.LibPath, path))
+		if err == nil {
+			return &o
+		}
+		if !errors.Is(err, common.ErrNoOverride) {
+			// the overrides written by the user cannot be read, they are ignored until they are fixed
+			logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "getTVSOverride", "tvs": data.Title}
+			t.App.Log.WithFields(logF).Warnf("invalid overrides: %s: %s", filepath.Join(t.LibPath, path), err)
+		}
+	}
+	return nil
+}
+
+// apply the overrides of a season, the season is created from the overrides if the provider has no data
+func overrideTVSSeason(o *common.TVSOverrideData, season int, data common.TVSSeasonData, err error) (common.TVSSeasonData, error) {
+	if o == nil {
+		return data, err
+	}
+	s, ok := o.Seasons[season]
+	if !ok {
+		return data, err
+	}
+	if err != nil {
+		return s, nil
+	}
+	return MergeTVSSeasonData(data, s), nil
+}
+
+// apply the overrides of an episode, the episode is created from the overrides if the provider has no data
+func overrideTVSEpisode(o *common.TVSOverrideData, season int, episode int, data common.TVSEpisodeData, err error) (common.TVSEpisodeData, error) {
+	if o == nil {
+		return data, err
+	}
+	e, ok := o.Episode(season, episode)
+	if !ok {
+		return data, err
+	}
+	if err != nil {
+		return e, nil
+	}
+	return MergeTVSEpisodeData(data, e), nil
+}
+
+// find a tvs from the external ids contained in its name
+// the first provider returning a result is used
+func (t *TVSScraper) findTVSByExternalID(name string) (common.SearchData, error) {
+	for _, id := range ExtractExternalIDs(name) {
+		for _, i := range t.ProviderNames {
+			res, err := t.Providers[i].FindTVSByExternalID(id.Source, id.ID)
+			if err == nil && len(res) > 0 {
+				return res[0], nil
+			}
+		}
+	}
+	return common.SearchData{}, errors.New("no data")
+}
+
+// associate a search result to a tvs and update its metadata
+func (t *TVSScraper) selectTVS(data database.ListShowRow, selected common.SearchData) (database.ListShowRow, error) {
+	t.UpdateWithSelectionResult(data.ID, SelectionResult{ScraperName: selected.ScraperName, ScraperID: selected.ScraperID, ScraperData: selected.ScraperData})
+	data.ScraperID = selected.ScraperID
+	data.ScraperName = selected.ScraperName
+	data.ScraperData = selected.ScraperData
+	data.Path = data.Title
+	// force tvs update
+	return t.updateTVS(data)
+}
+
+// update tvs, tags, companies and people metadata
+func (t *TVSScraper) updateTVS(data database.ListShowRow) (database.ListShowRow, error) {
+	ctx := context.Background()
+	provider, err := t.getProviderFromName(data.ScraperName)
+	if err != nil {
+		return data, err
+	}
+	t.configureProvider(provider, data)
+
+	// update tvs metadata
+	tvsData, err := provider.GetTVS()
+	if err != nil {
+		return data, err
+	}
+	override := t.getTVSOverride(data)
+	if override != nil {
+		tvsData = MergeTVSData(tvsData, override.TVS)
+	}
+	tvsData.Icon = RelativeArtwork(t.LibPath, tvsData.Icon)
+	tvsData.Fanart = RelativeArtwork(t.LibPath, tvsData.Fanart)
+	// the default trailer is selected from the list if the provider did not select one
+	if tvsData.Trailer == "" {
+		tvsData.Trailer = common.SelectTrailer(tvsData.Trailers, common.NewTrailerPolicy()).Link
+	}
+	err = t.App.DB.UpdateShow(ctx, database.UpdateShowParams{
+		Title:       tvsData.Title,
+		Overview:    tvsData.Overview,
+		Icon:        tvsData.Icon,
+		Fanart:      tvsData.Fanart,
+		Website:     tvsData.Website,
+		Trailer:     tvsData.Trailer,
+		Premiered:   NullDate(tvsData.Premiered),
+		Rating:      tvsData.Rating,
+		ScraperLink: tvsData.ScraperInfo.ScraperLink,
+		ScraperData: tvsData.ScraperInfo.ScraperData,
+		UpdateDate:  time.Now().Unix(),
+		ID:          data.ID,
+		UpdateMode:  -1,
+	})
+	if err != nil {
+		return data, err
+	}
+	data.Title = tvsData.Title
+	data.ScraperData = tvsData.ScraperInfo.ScraperData
+	data.Premiered = NullDate(tvsData.Premiered)
+
+	// the tags, companies and people are linked again from the new data
+	err = t.deleteTVSLinks(data.ID)
+	if err != nil {
+		return data, err
+	}
+
+	// update tags
+	tagData, err := provider.ListTVSTag()
+	if err != nil {
+		return data, err
+	}
+	if override != nil {
+		tagData = append(tagData, override.Tags...)
+	}
+	tagData = append(tagData, TrailerTags(tvsData.Trailers, tvsData.Trailer)...)
+	tagData = append(tagData, RatingTags(tvsData.Ratings, tvsData.Awards)...)
+	for _, i := range tagData {
+		AddTag(t.App, database.MediaTypeTvs, data.ID, i)
+	}
+
+	// update companies
+	companyData, err := provider.ListTVSCompany()
+	if err != nil {
+		return data, err
+	}
+	for _, i := range companyData {
+		AddCompany(t.App, database.MediaTypeTvs, data.ID, i)
+	}
+
+	// update people
+	persData, err := provider.ListTVSPerson()
+	if err != nil {
+		return data, err
+	}
+	for _, i := range persData {
+		AddPerson(t.App, database.MediaTypeTvs, data.ID, i)
+	}
+
+	return data, nil
+}
+
+// list the shows modified since a given date for each provider having a change feed
+func (t *TVSScraper) loadChanges(since time.Time) {
+	t.Changes = map[string]map[string]bool{}
+	t.ChangedAll = map[string]bool{}
+	for name, prov := range t.Providers {
+		p, ok := prov.(common.TVShowChangeProvider)
+		if !ok {
+			continue
+		}
+		ids, err := p.TVSChangedSince(since)
+		if errors.Is(err, common.ErrChangesTooOld) {
+			t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "loadChanges"}).Infof("changes of %s not available since %s, all its shows will be refreshed", name, since.Format(time.RFC3339))
+			t.ChangedAll[name] = true
+			continue
+		}
+		if err != nil {
+			t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "loadChanges"}).Errorf("unable to list the shows modified by %s, they will not be refreshed: %v", name, err)
+			continue
+		}
+		t.Changes[name] = map[string]bool{}
+		for _, id := range ids {
+			t.Changes[name][id] = true
+		}
+	}
+}
+
+// returns true if the provider data of a tvs changed since the last refresh
+// if the provider has no change feed or if the feed could not be retreived, the tvs is not refreshed
+// if the changes are not available since the requested date, all the shows of the provider are refreshed
+func (t *TVSScraper) isChanged(data database.ListShowRow) bool {
+	if t.Changes == nil || data.ScraperID == "" {
+		return false
+	}
+	if t.ChangedAll[data.ScraperName] {
+		return true
+	}
+	ids, ok := t.Changes[data.ScraperName]
+	if !ok {
+		return false
+	}
+	return ids[data.ScraperID]
+}
+
+// update tvs seasons and episodes metadata
+// if refresh is true, existing seasons and episodes are updated even if no update was requested
+func (t *TVSScraper) updateTVSEpisodes(data database.ListShowRow, refresh bool) error {
+	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "updateTVSEpisodes", "tvs": data.Title}
+
+	// get path to the root tvs folder
+	tvsPath := filepath.Join(t.LibPath, data.Path)
+	t.App.Log.WithFields(logF).Tracef("processing episodes in: %s", tvsPath)
+
+	// get provider
+	provider, err := t.getProviderFromName(data.ScraperName)
+	if err != nil {
+		return err
+	}
+	t.configureProvider(provider, data)
+
+	// metadata written by the user, applied over the data of the provider
+	override := t.getTVSOverride(data)
+	// fillers of the tvs, loaded when the first episode is added or updated
+	fillers := &tvsFillers{}
+
+	// list and update existing seasons
+	seasons, err := t.updateTVSSeasons(provider, override, data.ID, refresh)
+	if err != nil {
+		return err
+	}
+
+	// for each file in the tvs folder
+	for _, i := range ListFiles(tvsPath, true) {
+		t.App.Log.WithFields(logF).Tracef("processing episode: %s", i)
+		p := filepath.Join(data.Path, i)
+		if file.IsVideo(t.App, p) {
+			t.updateTVSEpisode(provider, override, fillers, &seasons, p, data, refresh)
+		}
+	}
+
+	return nil
+}
+
+// update existing seasons for a tvshow, returns the list of existing season numbers
+// if refresh is true, all the seasons are updated
+func (t *TVSScraper) updateTVSSeasons(provider common.TVShowProvider, override *common.TVSOverrideData, idshow int64, refresh bool) ([]int64, error) {
+	ctx := context.Background()
+	seasonData, err := t.App.DB.ListShowSeason(ctx, database.ListShowSeasonParams{IDUser: 0, IDShow: idshow})
+	if err != nil {
+		return []int64{}, err
+	}
+	seasons := []int64{}
+	for _, i := range seasonData {
+		if i.UpdateMode > 0 || refresh {
+			// update the seasons if needed
+			seasonData, err := provider.GetTVSSeason(int(i.Season))
+			seasonData, err = overrideTVSSeason(override, int(i.Season), seasonData, err)
+			if err == nil {
+				t.App.DB.UpdateShowSeason(ctx, database.UpdateShowSeasonParams{
+					Title:       seasonData.Title,
+					Overview:    seasonData.Overview,
+					Icon:        RelativeArtwork(t.LibPath, seasonData.Icon),
+					Season:      i.Season,
+					Fanart:      RelativeArtwork(t.LibPath, seasonData.Fanart),
+					Premiered:   NullDate(seasonData.Premiered),
+					Rating:      seasonData.Rating,
+					Trailer:     seasonData.Trailer,
+					ScraperName: seasonData.ScraperInfo.ScraperName,
+					ScraperData: seasonData.ScraperInfo.ScraperData,
+					ScraperID:   RelativeScraperID(t.LibPath, seasonData.ScraperInfo.ScraperID),
+					ScraperLink: seasonData.ScraperInfo.ScraperLink,
+					UpdateDate:  time.Now().Unix(),
+					UpdateMode:  -1,
+					IDShow:      idshow,
+				})
+			} else {
+				t.App.Log.WithFields(log.Fields{"entity": "scraper", "file": "tvshow", "function": "updateTVSSeasons", "tvs": idshow}).Error(err)
+			}
+		}
+
+		seasons = append(seasons, i.Season)
+	}
+	return seasons, nil
+}
+
+// update a tvshow episode based on the provided file path and tvs
+// takes a seasons argument with a pointer to a list of the existing seasons for this show, this list will be modified if a new season is added
+// if refresh is true, an existing episode is updated even if no update was requested
+func (t *TVSScraper) updateTVSEpisode(provider common.TVShowProvider, override *common.TVSOverrideData, fillers *tvsFillers, seasons *[]int64, p string, tvs database.ListShowRow, refresh bool) {
+	ctx := context.Background()
+	idshow := tvs.ID
+	filename := path.Base(p)
+	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "updateTVSEpisode", "tvs": filename}
+
+	videoData, err := t.App.DB.GetVideoFileFromPath(ctx, database.GetVideoFileFromPathParams{IDLib: t.IDLib, Path: p})
+	if err == nil {
+		t.App.Log.WithFields(logF).Trace("update episode")
+
+		episodeData, err := t.App.DB.GetShowEpisode(ctx, database.GetShowEpisodeParams{IDUser: 0, ID: videoData.MediaData})
+		if err == nil {
+			t.addTVSEpisodeSubtitles(tvs, p, int(episodeData.Season), int(episodeData.Episode))
+		}
+		if err == nil && (episodeData.UpdateMode > 0 || refresh) {
+			err = file.UpdateVideoFile(t.App, t.IDLib, p)
+			epData, err := provider.GetTVSEpisode(int(episodeData.Season), int(episodeData.Episode))
+			epData, err = overrideTVSEpisode(override, int(episodeData.Season), int(episodeData.Episode), epData, err)
+			if err == nil {
+				t.App.DB.UpdateShowEpisode(ctx, database.UpdateShowEpisodeParams{
+					Title:       epData.Title,
+					Overview:    epData.Overview,
+					Icon:        RelativeArtwork(t.LibPath, epData.Icon),
+					Premiered:   NullDate(epData.Premiered),
+					Rating:      epData.Rating,
+					ScraperID:   RelativeScraperID(t.LibPath, epData.ScraperInfo.ScraperID),
+					ScraperName: epData.ScraperInfo.ScraperName,
+					ScraperData: epData.ScraperInfo.ScraperData,
+					ScraperLink: epData.ScraperInfo.ScraperLink,
+					UpdateDate:  time.Now().Unix(),
+					UpdateMode:  -1,
+					ID:          episodeData.ID,
+				})
+				err = t.App.DB.DeleteAllPersonLinks(ctx, database.DeleteAllPersonLinksParams{MediaType: database.MediaTypeTvsEpisode, MediaData: episodeData.ID})
+				if err == nil {
+					err = t.updateTVSEpisodePerson(provider, int(episodeData.Season), int(episodeData.Episode), episodeData.ID)
+				}
+				if err == nil {
+					// the filler tag is the only tag linked to the episodes
+					err = t.App.DB.DeleteAllTagLinks(ctx, database.DeleteAllTagLinksParams{MediaType: database.MediaTypeTvsEpisode, MediaData: episodeData.ID})
+				}
+				if err == nil {
+					err = t.updateTVSEpisodeFiller(provider, tvs, fillers, episodeData.Season, episodeData.Episode, episodeData.ID)
+				}
+				if err != nil {
+					t.App.Log.WithFields(logF).Error(err)
+				}
+			} else {
+				t.App.Log.WithFields(logF).Error(err)
+			}
+		} else {
+			t.App.Log.WithFields(logF).Tracef("no update requested or error: %s", err)
+		}
+	} else {
+		t.App.Log.WithFields(logF).Trace("no existing entry for this episode")
+		// if there are no existing entries for this episodes
+
+		// extract season and episode number from filename, or from the tags embedded in the file
+		season, episode, ok := t.episodeNumber(p)
+		if ok {
+			if !util.Contains(*seasons, int64(season)) {
+				t.App.Log.WithFields(logF).Tracef("unknown season: %d", season)
+				// if the season is unknown, add it
+				seasonData, err := provider.GetTVSSeason(season)
+				seasonData, err = overrideTVSSeason(override, season, seasonData, err)
+				if err == nil {
+					t.App.DB.AddShowSeason(ctx, database.AddShowSeasonParams{
+						Title:       seasonData.Title,
+						Overview:    seasonData.Overview,
+						Icon:        RelativeArtwork(t.LibPath, seasonData.Icon),
+						Season:      int64(season),
+						Fanart:      RelativeArtwork(t.LibPath, seasonData.Fanart),
+						Premiered:   NullDate(seasonData.Premiered),
+						Rating:      seasonData.Rating,
+						Trailer:     seasonData.Trailer,
+						ScraperName: seasonData.ScraperInfo.ScraperName,
+						ScraperData: seasonData.ScraperInfo.ScraperData,
+						ScraperID:   RelativeScraperID(t.LibPath, seasonData.ScraperInfo.ScraperID),
+						ScraperLink: seasonData.ScraperInfo.ScraperLink,
+						AddDate:     time.Now().Unix(),
+						UpdateMode:  -1,
+						IDShow:      idshow,
+					})
+				} else {
+					t.App.Log.WithFields(logF).Error(err)
+					t.App.DB.AddShowSeason(ctx, database.AddShowSeasonParams{
+						Title:       "Season " + strconv.Itoa(season),
+						Season:      int64(season),
+						ScraperName: seasonData.ScraperInfo.ScraperName,
+						ScraperData: seasonData.ScraperInfo.ScraperData,
+						ScraperID:   RelativeScraperID(t.LibPath, seasonData.ScraperInfo.ScraperID),
+						ScraperLink: seasonData.ScraperInfo.ScraperLink,
+						AddDate:     time.Now().Unix(),
+						UpdateMode:  -1,
+						IDShow:      idshow,
+					})
+				}
+				*seasons = append(*seasons, int64(season))
+			}
+
+			// add the episode
+			epData, err := provider.GetTVSEpisode(season, episode)
+			epData, err = overrideTVSEpisode(override, season, episode, epData, err)
+			if err == nil {
+				t.App.Log.WithFields(logF).Tracef("add episode: %d for season: %d", episode, season)
+				idEp, err := t.App.DB.AddShowEpisode(ctx, database.AddShowEpisodeParams{
+					Title:       epData.Title,
+					Overview:    epData.Overview,
+					Icon:        RelativeArtwork(t.LibPath, epData.Icon),
+					Premiered:   NullDate(epData.Premiered),
+					Rating:      epData.Rating,
+					Season:      int64(season),
+					Episode:     int64(episode),
+					ScraperName: epData.ScraperInfo.ScraperName,
+					ScraperID:   RelativeScraperID(t.LibPath, epData.ScraperInfo.ScraperID),
+					ScraperData: epData.ScraperInfo.ScraperData,
+					ScraperLink: epData.ScraperInfo.ScraperLink,
+					AddDate:     time.Now().Unix(),
+					UpdateMode:  -1,
+					IDShow:      idshow,
+				})
+				if err == nil {
+					_, err = file.AddVideoFile(t.App, t.IDLib, p, database.MediaTypeTvsEpisode, idEp, false)
+					if err != nil {
+						t.App.Log.WithFields(logF).Error(err)
+					}
+					t.addTVSEpisodeSubtitles(tvs, p, season, episode)
+					err = t.updateTVSEpisodePerson(provider, season, episode, idEp)
+					if err == nil {
+						err = t.updateTVSEpisodeFiller(provider, tvs, fillers, int64(season), int64(episode), idEp)
+					}
+					if err != nil {
+						t.App.Log.WithFields(logF).Error(err)
+					}
+				} else {
+					t.App.Log.WithFields(logF).Error(err)
+				}
+			} else if t.AddUnknown {
+				t.App.Log.WithFields(logF).Warn("no data found for s" + strconv.Itoa(season) + "e" + strconv.Itoa(episode) + ", adding empty val")
+				// if no data is found but addUnknown is enabled
+				idEp, err := t.App.DB.AddShowEpisode(ctx, database.AddShowEpisodeParams{
+					Title:      filename,
+					AddDate:    time.Now().Unix(),
+					UpdateMode: -1,
+					Season:     int64(season),
+					Episode:    int64(episode),
+					IDShow:     idshow,
+				})
+				if err == nil {
+					_, err = file.AddVideoFile(t.App, t.IDLib, p, database.MediaTypeTvsEpisode, idEp, false)
+					if err != nil {
+						t.App.Log.WithFields(logF).Error(err)
+					}
+					t.addTVSEpisodeSubtitles(tvs, p, season, episode)
+				} else {
+					t.App.Log.WithFields(logF).Error(err)
+				}
+			} else {
+				t.App.Log.WithFields(logF).Warn("no data found for s" + strconv.Itoa(season) + "e" + strconv.Itoa(episode))
+			}
+		} else {
+			t.App.Log.WithFields(logF).Warn("unable to extract season/episode info for: " + string(filename))
+		}
+	}
+}
+
+// returns the season and episode numbers of an episode file
+// the numbers are extracted from the filename, the tags embedded in the file are used if the filename does not contain them
+func (t *TVSScraper) episodeNumber(p string) (int, int, bool) {
+	filename := path.Base(p)
+	searchSeason := t.RegexSeason.FindStringSubmatch(filename)
+	searchEpisode := t.RegexEpisode.FindStringSubmatch(filename)
+	if len(searchSeason) > 1 && searchSeason[1] != "" && len(searchEpisode) > 1 && searchEpisode[1] != "" {
+		season, _ := strconv.Atoi(string(searchSeason[1]))
+		episode, _ := strconv.Atoi(string(searchEpisode[1]))
+		return season, episode, true
+	}
+
+	for _, i := range t.ProviderNames {
+		tags, ok := t.Providers[i].(common.TVShowFileTagProvider)
+		if !ok {
+			continue
+		}
+		data, err := tags.GetTVSEpisodeFromFile(filepath.Join(t.LibPath, p))
+		if err == nil {
+			return int(data.Season), int(data.Episode), true
+		}
+	}
+	return 0, 0, false
+}
+
+// download the missing subtitles of an episode in the preferred languages, next to its video file
+// for each language, the best match of the first provider returning results is used
+// the languages which already have a subtitle file (ex: episode.en.srt) are skipped, so the providers are only requested for the missing ones
+func (t *TVSScraper) addTVSEpisodeSubtitles(tvs database.ListShowRow, p string, season int, episode int) {
+	if len(t.SubtitleNames) == 0 || len(t.SubLanguages) == 0 {
+		return
+	}
+	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "addTVSEpisodeSubtitles", "tvs": tvs.Title}
+
+	videoPath := filepath.Join(t.LibPath, p)
+	base := strings.TrimSuffix(videoPath, filepath.Ext(videoPath))
+	files, err := os.ReadDir(filepath.Dir(videoPath))
+	if err != nil {
+		t.App.Log.WithFields(logF).Error(err)
+		return
+	}
+	missing := []string{}
+	for _, lang := range t.SubLanguages {
+		prefix := filepath.Base(base) + "." + lang + "."
+		found := false
+		for _, i := range files {
+			if strings.HasPrefix(i.Name(), prefix) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, lang)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	query := common.SubtitleQuery{
+		FileName: path.Base(p),
+		Title:    tvs.Title,
+		Season:   season,
+		Episode:  episode,
+	}
+	for _, id := range ExtractExternalIDs(tvs.Path) {
+		if id.Source == common.ExternalIMDB {
+			query.IMDB = id.ID
+		}
+	}
+	hash, size, err := common.SubtitleHash(videoPath)
+	if err == nil {
+		query.Hash = hash
+		query.Size = size
+	}
+
+	for _, lang := range missing {
+		query.Languages = []string{lang}
+		for _, i := range t.SubtitleNames {
+			res, err := t.Subtitles[i].SearchSubtitle(query)
+			if err != nil || len(res) == 0 {
+				continue
+			}
+			sub := res[0]
+			dest := base + "." + sub.Language
+			if sub.Forced {
+				dest += ".forced"
+			}
+			dest += "." + sub.Format
+			if _, err := os.Stat(dest); err == nil {
+				t.App.Log.WithFields(logF).Tracef("subtitle already exists: %s", dest)
+				break
+			}
+			data, err := t.Subtitles[i].DownloadSubtitle(sub)
+			if err == nil {
+				err = os.WriteFile(dest, data, 0644)
+			}
+			if err != nil {
+				t.App.Log.WithFields(logF).Error(err)
+				continue
+			}
+			t.App.Log.WithFields(logF).Debugf("subtitle added: %s: %s", i, dest)
+			break
+		}
+	}
+}
+
+// link the guest stars and crew of an episode
+func (t *TVSScraper) updateTVSEpisodePerson(provider common.TVShowProvider, season int, episode int, idep int64) error {
+	persData, err := provider.ListTVSEpisodePerson(season, episode)
+	if err != nil {
+		return err
+	}
+	for _, i := range persData {
+		AddPerson(t.App, database.MediaTypeTvsEpisode, idep, i)
+	}
+	return nil
+}
+
+// type of the episodes of a tvs (canon, filler, ...), from the first filler provider knowing the tvs
+// fillers are indexed by absolute number, the offset of a season is the number of episodes of the previous seasons given by the tvs provider
+type tvsFillers struct {
+	loaded  bool
+	fillers map[int]common.FillerType
+	offsets map[int64]int64
+}
+
+// tag an episode with its type, the fillers of the tvs are loaded by the first tagged episode
+// episodes unknown to the filler provider, and specials, are not tagged
+func (t *TVSScraper) updateTVSEpisodeFiller(provider common.TVShowProvider, tvs database.ListShowRow, f *tvsFillers, season int64, episode int64, idep int64) error {
+	if len(t.FillerNames) == 0 {
+		return nil
+	}
+	if !f.loaded {
+		f.loaded = true
+		fillers, err := t.listTVSFiller(tvs)
+		if err != nil {
+			return err
+		}
+		if len(fillers) == 0 {
+			return nil
+		}
+		offsets, err := t.seasonOffsets(provider, tvs.ID)
+		if err != nil {
+			return err
+		}
+		f.fillers = fillers
+		f.offsets = offsets
+	}
+	offset, ok := f.offsets[season]
+	if !ok {
+		return nil
+	}
+	filler, ok := f.fillers[int(offset+episode)]
+	if !ok {
+		return nil
+	}
+	return AddTag(t.App, database.MediaTypeTvsEpisode, idep, common.TagData{Name: "filler", Value: filler.String()})
+}
+
+// returns the type of the episodes of a tvs, by absolute number
+// the tvs is searched by title in the filler providers, the best result of the first provider finding it is used
+func (t *TVSScraper) listTVSFiller(tvs database.ListShowRow) (map[int]common.FillerType, error) {
+	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "listTVSFiller", "tvs": tvs.Title}
+
+	t.FillerLock.Lock()
+	defer t.FillerLock.Unlock()
+
+	fillers := map[int]common.FillerType{}
+	for _, i := range t.FillerNames {
+		res, err := t.Fillers[i].SearchFiller(tvs.Title)
+		if err != nil || len(res) == 0 {
+			continue
+		}
+		selected, err := SelectBestItem(res, tvs.Title, 0)
+		if err != nil {
+			continue
+		}
+		t.Fillers[i].Configure(selected.ScraperID, selected.ScraperData)
+		list, err := t.Fillers[i].ListFiller()
+		if err != nil {
+			return fillers, err
+		}
+		t.App.Log.WithFields(logF).Tracef("fillers found: %s: %s", i, selected.ScraperID)
+		for _, f := range list {
+			fillers[f.Index] = f.Filler
+		}
+		return fillers, nil
+	}
+	return fillers, nil
+}
+
+// returns the absolute number offset of each season of a tvs, from the episode counts of the provider
+// the seasons after a season whose episode count is unknown are left out, as their offset cannot be computed
+func (t *TVSScraper) seasonOffsets(provider common.TVShowProvider, idshow int64) (map[int64]int64, error) {
+	logF := log.Fields{"entity": "scraper", "file": "tvshow", "function": "seasonOffsets", "tvs": idshow}
+
+	// episode count of each season, from the list of episodes if the provider can return it in a single request
+	counts := map[int64]int64{}
+	if p, ok := provider.(common.TVShowEpisodeListProvider); ok {
+		episodes, err := p.ListTVSEpisode()
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range episodes {
+			if i.Episode > counts[i.Season] {
+				counts[i.Season] = i.Episode
+			}
+		}
+	}
+
+	seasonData, err := t.App.DB.ListShowSeason(context.Background(), database.ListShowSeasonParams{IDUser: 0, IDShow: idshow})
+	if err != nil {
+		return nil, err
+	}
+	last := int64(0)
+	for _, i := range seasonData {
+		if i.Season > last {
+			last = i.Season
+		}
+	}
+
+	offsets := map[int64]int64{}
+	offset := int64(0)
+	for season := int64(1); season <= last; season++ {
+		offsets[season] = offset
+		count, ok := counts[season]
+		if !ok {
+			seasonData, err := provider.GetTVSSeason(int(season))
+			if err == nil {
+				count = seasonData.EpisodeCount
+			}
+		}
+		if count <= 0 {
+			t.App.Log.WithFields(logF).Warnf("unknown episode count of season %d, the fillers of the next seasons are skipped", season)
+			break
+		}
+		offset += count
+	}
+	return offsets, nil
+}
+
+// list the episode groups (alternative orderings) available for a tvs
+// a group can then be selected by passing its ScraperData to UpdateWithSelectionResult
+func (t *TVSScraper) ListEpisodeGroups(scraperName string, scraperID string) ([]common.EpisodeGroupData, error) {
+	provider, err := t.getProviderFromName(scraperName)
+	if err != nil {
+		return nil, err
+	}
+	provider.Configure(t.providerID(provider, scraperID), "")
+	return provider.ListEpisodeGroups()
+}
+
+func (t *TVSScraper) UpdateWithSelectionResult(id int64, selection SelectionResult) error {
+	ctx := context.Background()
+	// update tvs
+	err := t.App.DB.UpdateShow(ctx, database.UpdateShowParams{ScraperID: selection.ScraperID, ScraperName: selection.ScraperName, ScraperData: selection.ScraperData, UpdateMode: 1, ID: id})
+	if err != nil {
+		return err
+	}
+	// purge outdated data
+	// force rescan of seasons and episodes
+	err = t.App.DB.UpdateShowAllSeasons(ctx, database.UpdateShowAllSeasonsParams{IDShow: id, ScraperName: " ", ScraperID: "0", UpdateMode: 1})
+	if err != nil {
+		return err
+	}
+	err = t.App.DB.UpdateShowAllEpisodes(ctx, database.UpdateShowAllEpisodesParams{IDShow: id, ScraperName: " ", ScraperID: "0", UpdateMode: 1})
+	if err != nil {
+		return err
+	}
+	// delete tags, companies and people
+	return t.deleteTVSLinks(id)
+}
+
+// delete the tags (including the companies) and people linked to a tvs, updateTVS links them again
+func (t *TVSScraper) deleteTVSLinks(id int64) error {
+	ctx := context.Background()
+	err := t.App.DB.DeleteAllTagLinks(ctx, database.DeleteAllTagLinksParams{MediaType: database.MediaTypeTvs, MediaData: id})
+	if err != nil {
+		return err
+	}
+	return t.App.DB.DeleteAllPersonLinks(ctx, database.DeleteAllPersonLinksParams{MediaType: database.MediaTypeTvs, MediaData: id})
+}
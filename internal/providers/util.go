@@ -1,186 +1,382 @@
-package scraper
-
-import (
-	"context"
-	"encoding/json"
-	"errors"
-	"time"
-
-	fuzzy "github.com/paul-mannino/go-fuzzywuzzy"
-	database "github.com/zogwine/metadata/internal/database"
-	"github.com/zogwine/metadata/internal/scraper/common"
-	"github.com/zogwine/metadata/internal/status"
-	"github.com/zogwine/metadata/internal/util"
-)
-
-// scraper definition
-type Scraper interface {
-	Scan(idlib int64, conf ScraperScanConfig) error
-	UpdateWithSelectionResult(mediaData int64, selection SelectionResult) error
-}
-
-type SelectionResult struct {
-	ScraperName string
-	ScraperID   string
-	ScraperData string
-}
-
-// Select the best SearchData from an array based on a provided title and an optionnal year
-// if no matching item is found or that the score is too low, an error is returned
-func SelectBestItem(items []common.SearchData, title string, year int) (common.SearchData, error) {
-	searchItems := []common.SearchData{}
-
-	if year > 0 {
-		for _, i := range items {
-			if time.Unix(i.Premiered, 0).Year() == year {
-				searchItems = append(searchItems, i)
-			}
-		}
-	} else {
-		searchItems = items
-	}
-
-	names := []string{}
-	for _, i := range searchItems {
-		names = append(names, i.Title)
-	}
-
-	match, err := fuzzy.ExtractOne(title, names)
-
-	if err == nil && match.Score > 85 {
-		return searchItems[util.Index(names, match.Match)], nil
-	}
-
-	return common.SearchData{}, errors.New("no data")
-}
-
-// Returns a list of the enabled scrapers and map of scraper name to config for a specific mediaType sorted by priority
-func ListScraperConfiguration(s *status.Status, mediaType database.MediaType) ([]string, map[string](map[string]string), error) {
-	ctx := context.Background()
-	names := []string{}
-	config := map[string](map[string]string){}
-
-	data, err := s.DB.ListScraperForType(ctx, mediaType)
-	if err != nil {
-		return names, config, err
-	}
-
-	for _, i := range data {
-		if i.Enabled {
-			names = append(names, i.Provider)
-			x := make(map[string]string)
-			err = json.Unmarshal(i.Settings, &x)
-			if err != nil {
-				return names, config, err
-			}
-			config[i.Provider] = x
-		}
-	}
-
-	return names, config, nil
-}
-
-// Add multiple results for a given mediaType/mediaData to the database
-// also deletes the previous entries for the given mediaType/mediaData
-func AddMultipleResults(s *status.Status, mediaType database.MediaType, mediaData int64, searchResults []common.SearchData, name string) error {
-	ctx := context.Background()
-	err := s.DB.DeleteMultipleResultsByMedia(ctx, database.DeleteMultipleResultsByMediaParams{MediaType: mediaType, MediaData: mediaData})
-	if err != nil {
-		return err
-	}
-
-	jsonData, err := json.Marshal(searchResults)
-	if err != nil {
-		return err
-	}
-
-	return s.DB.AddMultipleResults(ctx, database.AddMultipleResultsParams{MediaType: mediaType, MediaData: mediaData, Data: json.RawMessage(jsonData), Name: name})
-}
-
-// Select the result at index id for the given mediaType/mediaData
-// and returns the selected SearchData
-func SelectScraperResult(s *status.Status, mediaType database.MediaType, mediaData int64, id int) (common.SearchData, error) {
-	ctx := context.Background()
-
-	data, err := s.DB.GetMultipleResultsByMedia(ctx, database.GetMultipleResultsByMediaParams{MediaType: mediaType, MediaData: mediaData})
-	if err != nil {
-		return common.SearchData{}, err
-	}
-
-	searchData := []common.SearchData{}
-	err = json.Unmarshal(data.Data, &searchData)
-	if err != nil {
-		return common.SearchData{}, err
-	}
-
-	sc, err := getScraperFromMediaType(s, mediaType)
-	if err != nil {
-		return common.SearchData{}, err
-	}
-	err = sc.UpdateWithSelectionResult(mediaData, SelectionResult{ScraperName: searchData[id].ScraperName, ScraperID: searchData[id].ScraperID, ScraperData: searchData[id].ScraperData})
-	if err != nil {
-		return common.SearchData{}, err
-	}
-
-	if len(searchData) < id {
-		return common.SearchData{}, errors.New("invalid id")
-	}
-
-	err = s.DB.DeleteMultipleResultsByMedia(ctx, database.DeleteMultipleResultsByMediaParams{MediaType: mediaType, MediaData: mediaData})
-	if err != nil {
-		return common.SearchData{}, err
-	}
-
-	return searchData[id], nil
-}
-
-// Link a tag to a mediaType/mediaData in the database
-// if the tag doesn't exists, it is automatically created
-func AddTag(s *status.Status, mediaType database.MediaType, mediaData int64, tag common.TagData) error {
-	ctx := context.Background()
-	var tagID int64
-
-	tagData, err := s.DB.GetTagByValue(ctx, database.GetTagByValueParams{Name: tag.Name, Value: tag.Value})
-	if err != nil || tagData.Name == "" {
-		// if tag does not exists, add it
-		tagID, err = s.DB.AddTag(ctx, database.AddTagParams{Name: tag.Name, Value: tag.Value, Icon: tag.Icon})
-		if err != nil {
-			return err
-		}
-	} else {
-		tagID = tagData.ID
-	}
-
-	// create link between tag and mediaType/mediaData
-	return s.DB.AddTagLink(ctx, database.AddTagLinkParams{IDTag: tagID, MediaType: mediaType, MediaData: mediaData})
-}
-
-// Link a person to a mediaType/mediaData in the database
-// if the person doesn't exists, it is automatically created
-func AddPerson(s *status.Status, mediaType database.MediaType, mediaData int64, person common.PersonData) error {
-	ctx := context.Background()
-	var personID int64
-
-	personData, err := s.DB.GetPersonByName(ctx, person.Name)
-	if err != nil || personData.Name == "" {
-		// if person does not exists, add it
-		personID, err = s.DB.AddPerson(ctx, database.AddPersonParams{Name: person.Name})
-		if err != nil {
-			return err
-		}
-	} else {
-		personID = personData.ID
-	}
-
-	// create link between person and mediaType/mediaData
-	return s.DB.AddPersonLink(ctx, database.AddPersonLinkParams{IDPerson: personID, MediaType: mediaType, MediaData: mediaData})
-}
-
-func getScraperFromMediaType(s *status.Status, mediaType database.MediaType) (Scraper, error) {
-	if mediaType == database.MediaTypeTvs {
-		t := NewTVSScraper(s)
-		return &t, nil
-	} else {
-		return nil, errors.New("no registered scraper for this mediatype")
-	}
-}
+package scraper
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	fuzzy "github.com/paul-mannino/go-fuzzywuzzy"
+	database "github.com/zogwine/metadata/internal/database"
+	"github.com/zogwine/metadata/internal/scraper/common"
+	"github.com/zogwine/metadata/internal/status"
+	"github.com/zogwine/metadata/internal/util"
+)
+
+// scraper definition
+type Scraper interface {
+	Scan(idlib int64, conf ScraperScanConfig) error
+	UpdateWithSelectionResult(mediaData int64, selection SelectionResult) error
+}
+
+type SelectionResult struct {
+	ScraperName string
+	ScraperID   string
+	ScraperData string
+}
+
+// Select the best SearchData from an array based on a provided title and an optionnal year
+// if no matching item is found or that the score is too low, an error is returned
+func SelectBestItem(items []common.SearchData, title string, year int) (common.SearchData, error) {
+	searchItems := []common.SearchData{}
+
+	if year > 0 {
+		for _, i := range items {
+			if i.Premiered.Year() == year {
+				searchItems = append(searchItems, i)
+			}
+		}
+	} else {
+		searchItems = items
+	}
+
+	names := []string{}
+	for _, i := range searchItems {
+		names = append(names, i.Title)
+	}
+
+	match, err := fuzzy.ExtractOne(title, names)
+
+	if err == nil && match.Score > 85 {
+		return searchItems[util.Index(names, match.Match)], nil
+	}
+
+	return common.SearchData{}, errors.New("no data")
+}
+
+// regexes used to extract the external ids from a name, in order of preference
+var externalIDRegs = []struct {
+	source common.ExternalSource
+	reg    *regexp.Regexp
+}{
+	{common.ExternalIMDB, regexp.MustCompile(`\b(tt\d{7,})\b`)},
+	{common.ExternalTVDB, regexp.MustCompile(`(?i)\btvdb(?:id)?[-=_ ]?(\d+)\b`)},
+}
+
+// Extract the external ids (imdb, tvdb) contained in a file or folder name
+// ex: "Show (2010) [tt1234567]" or "Show {tvdb-12345}"
+// the ids are returned in order of preference
+func ExtractExternalIDs(name string) []common.ExternalID {
+	ids := []common.ExternalID{}
+
+	for _, i := range externalIDRegs {
+		match := i.reg.FindStringSubmatch(name)
+		if len(match) > 1 {
+			ids = append(ids, common.ExternalID{Source: i.source, ID: match[1]})
+		}
+	}
+
+	return ids
+}
+
+// Returns a list of the enabled scrapers and map of scraper name to config for a specific mediaType sorted by priority
+func ListScraperConfiguration(s *status.Status, mediaType database.MediaType) ([]string, map[string](map[string]string), error) {
+	ctx := context.Background()
+	names := []string{}
+	config := map[string](map[string]string){}
+
+	data, err := s.DB.ListScraperForType(ctx, mediaType)
+	if err != nil {
+		return names, config, err
+	}
+
+	for _, i := range data {
+		if i.Enabled {
+			names = append(names, i.Provider)
+			x := make(map[string]string)
+			err = json.Unmarshal(i.Settings, &x)
+			if err != nil {
+				return names, config, err
+			}
+			config[i.Provider] = x
+		}
+	}
+
+	return names, config, nil
+}
+
+// Add multiple results for a given mediaType/mediaData to the database
+// also deletes the previous entries for the given mediaType/mediaData
+func AddMultipleResults(s *status.Status, mediaType database.MediaType, mediaData int64, searchResults []common.SearchData, name string) error {
+	ctx := context.Background()
+	err := s.DB.DeleteMultipleResultsByMedia(ctx, database.DeleteMultipleResultsByMediaParams{MediaType: mediaType, MediaData: mediaData})
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(searchResults)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.AddMultipleResults(ctx, database.AddMultipleResultsParams{MediaType: mediaType, MediaData: mediaData, Data: json.RawMessage(jsonData), Name: name})
+}
+
+// Select the result at index id for the given mediaType/mediaData
+// and returns the selected SearchData
+func SelectScraperResult(s *status.Status, mediaType database.MediaType, mediaData int64, id int) (common.SearchData, error) {
+	ctx := context.Background()
+
+	data, err := s.DB.GetMultipleResultsByMedia(ctx, database.GetMultipleResultsByMediaParams{MediaType: mediaType, MediaData: mediaData})
+	if err != nil {
+		return common.SearchData{}, err
+	}
+
+	searchData := []common.SearchData{}
+	err = json.Unmarshal(data.Data, &searchData)
+	if err != nil {
+		return common.SearchData{}, err
+	}
+
+	sc, err := getScraperFromMediaType(s, mediaType)
+	if err != nil {
+		return common.SearchData{}, err
+	}
+	err = sc.UpdateWithSelectionResult(mediaData, SelectionResult{ScraperName: searchData[id].ScraperName, ScraperID: searchData[id].ScraperID, ScraperData: searchData[id].ScraperData})
+	if err != nil {
+		return common.SearchData{}, err
+	}
+
+	if len(searchData) < id {
+		return common.SearchData{}, errors.New("invalid id")
+	}
+
+	err = s.DB.DeleteMultipleResultsByMedia(ctx, database.DeleteMultipleResultsByMediaParams{MediaType: mediaType, MediaData: mediaData})
+	if err != nil {
+		return common.SearchData{}, err
+	}
+
+	return searchData[id], nil
+}
+
+// Link a tag to a mediaType/mediaData in the database
+// if the tag doesn't exists, it is automatically created
+func AddTag(s *status.Status, mediaType database.MediaType, mediaData int64, tag common.TagData) error {
+	ctx := context.Background()
+	var tagID int64
+
+	tagData, err := s.DB.GetTagByValue(ctx, database.GetTagByValueParams{Name: tag.Name, Value: tag.Value})
+	if err != nil || tagData.Name == "" {
+		// if tag does not exists, add it
+		tagID, err = s.DB.AddTag(ctx, database.AddTagParams{Name: tag.Name, Value: tag.Value, Icon: tag.Icon})
+		if err != nil {
+			return err
+		}
+	} else {
+		tagID = tagData.ID
+	}
+
+	// create link between tag and mediaType/mediaData
+	return s.DB.AddTagLink(ctx, database.AddTagLinkParams{IDTag: tagID, MediaType: mediaType, MediaData: mediaData})
+}
+
+// Link a person to a mediaType/mediaData in the database
+// if the person doesn't exists, it is automatically created
+func AddPerson(s *status.Status, mediaType database.MediaType, mediaData int64, person common.PersonData) error {
+	ctx := context.Background()
+	var personID int64
+
+	personData, err := s.DB.GetPersonByName(ctx, person.Name)
+	if err != nil || personData.Name == "" {
+		// if person does not exists, add it
+		personID, err = s.DB.AddPerson(ctx, database.AddPersonParams{Name: person.Name})
+		if err != nil {
+			return err
+		}
+	} else {
+		personID = personData.ID
+	}
+
+	// create link between person and mediaType/mediaData
+	return s.DB.AddPersonLink(ctx, database.AddPersonLinkParams{IDPerson: personID, MediaType: mediaType, MediaData: mediaData})
+}
+
+// Link a company to a mediaType/mediaData in the database
+// the database has no company table, so companies are stored as tags named after their type
+func AddCompany(s *status.Status, mediaType database.MediaType, mediaData int64, company common.CompanyData) error {
+	return AddTag(s, mediaType, mediaData, CompanyTag(company))
+}
+
+// Tag used to store a company (ex: network: HBO)
+// studios keep the production tag used before the companies were returned separately
+func CompanyTag(company common.CompanyData) common.TagData {
+	name := string(company.Type)
+	if company.Type == common.CompanyStudio {
+		name = "production"
+	}
+	return common.TagData{Name: name, Value: company.Name, Icon: company.Logo}
+}
+
+// Tags used to store the other trailers of a media, the database only has a column for the default trailer
+func TrailerTags(trailers []common.TrailerData, selected string) []common.TagData {
+	tags := []common.TagData{}
+	for _, i := range trailers {
+		if i.Link != "" && i.Link != selected {
+			tags = append(tags, common.TagData{Name: "trailer", Value: i.Link})
+		}
+	}
+	return tags
+}
+
+// Tags used to store the detailed ratings and the awards of a media, the database only has a column for the main rating
+// the value of a rating tag is the source followed by the score (ex: imdb 8.7/10)
+func RatingTags(ratings []common.RatingData, awards string) []common.TagData {
+	tags := []common.TagData{}
+	for _, i := range ratings {
+		if i.Source != "" && i.Max > 0 {
+			value := i.Source + " " + strconv.FormatFloat(i.Value, 'f', -1, 64) + "/" + strconv.FormatFloat(i.Max, 'f', -1, 64)
+			tags = append(tags, common.TagData{Name: "rating", Value: value})
+		}
+	}
+	if awards != "" {
+		tags = append(tags, common.TagData{Name: "awards", Value: awards})
+	}
+	return tags
+}
+
+// Convert a date to the value stored in the database
+// unknown dates are stored as NULL, so they are not shown as 1970
+func NullDate(d common.Date) sql.NullInt64 {
+	if !d.IsKnown() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: d.Unix(), Valid: true}
+}
+
+// Convert the path used as ScraperID by a local provider (ex: nfo) to a path relative to the library
+// so the media keeps its provider when the library is moved, the other ids are returned unchanged
+func RelativeScraperID(libPath string, id string) string {
+	return relativePath(libPath, id)
+}
+
+// Convert the local artworks returned by a provider (ex: nfo posters) to paths relative to the library
+// urls and paths outside of the library are returned unchanged
+func RelativeArtwork(libPath string, p string) string {
+	return relativePath(libPath, p)
+}
+
+func relativePath(libPath string, p string) string {
+	if !filepath.IsAbs(p) {
+		return p
+	}
+	rel, err := filepath.Rel(libPath, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return p
+	}
+	return rel
+}
+
+// Convert a ScraperID stored relative to the library back to the path expected by a local provider
+// urls and absolute paths (stored before the library relative paths) are returned unchanged
+func AbsoluteScraperID(libPath string, id string) string {
+	if id == "" || filepath.IsAbs(id) || strings.Contains(id, "://") {
+		return id
+	}
+	return filepath.Join(libPath, id)
+}
+
+// Merge the overrides written by the user into the data of a provider
+// non empty fields of the override take precedence, the scraper info of the provider is kept
+func MergeTVSData(data common.TVSData, o common.TVSData) common.TVSData {
+	data.Title = mergeString(data.Title, o.Title)
+	data.Overview = mergeString(data.Overview, o.Overview)
+	data.Icon = mergeString(data.Icon, o.Icon)
+	data.Fanart = mergeString(data.Fanart, o.Fanart)
+	data.Website = mergeString(data.Website, o.Website)
+	data.Trailer = mergeString(data.Trailer, o.Trailer)
+	data.Awards = mergeString(data.Awards, o.Awards)
+	if len(o.Trailers) > 0 {
+		data.Trailers = o.Trailers
+	}
+	if len(o.Ratings) > 0 {
+		data.Ratings = o.Ratings
+	}
+	if o.Premiered.IsKnown() {
+		data.Premiered = o.Premiered
+	}
+	if o.Rating > 0 {
+		data.Rating = o.Rating
+	}
+	return data
+}
+
+func MergeTVSSeasonData(data common.TVSSeasonData, o common.TVSSeasonData) common.TVSSeasonData {
+	data.Title = mergeString(data.Title, o.Title)
+	data.Overview = mergeString(data.Overview, o.Overview)
+	data.Icon = mergeString(data.Icon, o.Icon)
+	data.Fanart = mergeString(data.Fanart, o.Fanart)
+	data.Trailer = mergeString(data.Trailer, o.Trailer)
+	if o.Premiered.IsKnown() {
+		data.Premiered = o.Premiered
+	}
+	if o.Rating > 0 {
+		data.Rating = o.Rating
+	}
+	return data
+}
+
+func MergeTVSEpisodeData(data common.TVSEpisodeData, o common.TVSEpisodeData) common.TVSEpisodeData {
+	data.Title = mergeString(data.Title, o.Title)
+	data.Overview = mergeString(data.Overview, o.Overview)
+	data.Icon = mergeString(data.Icon, o.Icon)
+	if o.Premiered.IsKnown() {
+		data.Premiered = o.Premiered
+	}
+	if o.Rating > 0 {
+		data.Rating = o.Rating
+	}
+	return data
+}
+
+func MergeMovieData(data common.MovieData, o common.MovieData) common.MovieData {
+	data.Title = mergeString(data.Title, o.Title)
+	data.Overview = mergeString(data.Overview, o.Overview)
+	data.Icon = mergeString(data.Icon, o.Icon)
+	data.Fanart = mergeString(data.Fanart, o.Fanart)
+	data.Website = mergeString(data.Website, o.Website)
+	data.Trailer = mergeString(data.Trailer, o.Trailer)
+	data.Awards = mergeString(data.Awards, o.Awards)
+	if len(o.Trailers) > 0 {
+		data.Trailers = o.Trailers
+	}
+	if len(o.Ratings) > 0 {
+		data.Ratings = o.Ratings
+	}
+	if o.Premiered.IsKnown() {
+		data.Premiered = o.Premiered
+	}
+	if o.Rating > 0 {
+		data.Rating = o.Rating
+	}
+	return data
+}
+
+func mergeString(val string, override string) string {
+	if override != "" {
+		return override
+	}
+	return val
+}
+
+func getScraperFromMediaType(s *status.Status, mediaType database.MediaType) (Scraper, error) {
+	if mediaType == database.MediaTypeTvs {
+		t := NewTVSScraper(s)
+		return &t, nil
+	} else {
+		return nil, errors.New("no registered scraper for this mediatype")
+	}
+}
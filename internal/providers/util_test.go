@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zogwine/metadata/internal/scraper/common"
+)
+
+func TestExtractExternalIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []common.ExternalID
+	}{
+		{"Show (2010)", []common.ExternalID{}},
+		{"Show (2010) [tt1234567]", []common.ExternalID{{Source: common.ExternalIMDB, ID: "tt1234567"}}},
+		{"Show tt12345678", []common.ExternalID{{Source: common.ExternalIMDB, ID: "tt12345678"}}},
+		{"Show {tvdb-12345}", []common.ExternalID{{Source: common.ExternalTVDB, ID: "12345"}}},
+		{"Show [tvdbid=12345]", []common.ExternalID{{Source: common.ExternalTVDB, ID: "12345"}}},
+		{"Show TVDB 12345", []common.ExternalID{{Source: common.ExternalTVDB, ID: "12345"}}},
+		{"Show [tvdb-12345] [tt1234567]", []common.ExternalID{{Source: common.ExternalIMDB, ID: "tt1234567"}, {Source: common.ExternalTVDB, ID: "12345"}}},
+		// too short for an imdb id, or part of a word
+		{"Show tt123456", []common.ExternalID{}},
+		{"Showtt1234567", []common.ExternalID{}},
+		{"Show mytvdb-12345", []common.ExternalID{}},
+	}
+	for _, i := range tests {
+		ids := ExtractExternalIDs(i.name)
+		if !reflect.DeepEqual(ids, i.ids) {
+			t.Errorf("ExtractExternalIDs(%q) = %v, want %v", i.name, ids, i.ids)
+		}
+	}
+}
+
+func TestTrailerTags(t *testing.T) {
+	trailers := []common.TrailerData{
+		{Link: "https://www.youtube.com/watch?v=a"},
+		{Link: ""},
+		{Link: "https://www.youtube.com/watch?v=b"},
+	}
+	tags := TrailerTags(trailers, "https://www.youtube.com/watch?v=a")
+	want := []common.TagData{{Name: "trailer", Value: "https://www.youtube.com/watch?v=b"}}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("TrailerTags() = %v, want %v", tags, want)
+	}
+}
+
+func TestRatingTags(t *testing.T) {
+	ratings := []common.RatingData{
+		{Source: common.RatingIMDB, Value: 8.7, Max: 10, Votes: 1000},
+		{Source: common.RatingRottenTomatoes, Value: 94, Max: 100},
+		{Source: "", Value: 5, Max: 10},
+		{Source: common.RatingMetacritic, Value: 80, Max: 0},
+	}
+	tags := RatingTags(ratings, "Won 2 Oscars.")
+	want := []common.TagData{
+		{Name: "rating", Value: "imdb 8.7/10"},
+		{Name: "rating", Value: "rottentomatoes 94/100"},
+		{Name: "awards", Value: "Won 2 Oscars."},
+	}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("RatingTags() = %v, want %v", tags, want)
+	}
+	if tags := RatingTags(nil, ""); len(tags) != 0 {
+		t.Errorf("RatingTags(nil, \"\") = %v, want no tags", tags)
+	}
+}
@@ -0,0 +1,483 @@
+package anilist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// languages of the titles
+const (
+	TitleRomaji  = "romaji"
+	TitleEnglish = "english"
+	TitleNative  = "native"
+)
+
+// episode ordering where all the episodes of the seasons are numbered in a single season
+const OrderAbsolute = "absolute"
+
+// maximum number of seasons followed in the relations of a tvs
+const maxSeasons = 30
+
+// fields requested for each media
+const mediaFields = `
+	id idMal type format status episodes isAdult countryOfOrigin siteUrl
+	title { romaji english native }
+	description(asHtml: false)
+	startDate { year month day }
+	coverImage { extraLarge large }
+	bannerImage
+	averageScore
+	popularity
+	genres
+	tags { name rank isMediaSpoiler isAdult }
+	trailer { id site }
+	studios { edges { isMain node { id name isAnimationStudio siteUrl } } }
+	nextAiringEpisode { airingAt episode }
+	airingSchedule(perPage: 50) { nodes { airingAt episode } }
+	streamingEpisodes { title thumbnail }
+	relations { edges { relationType node { id type format } } }
+`
+
+// provider for the AniList graphql api
+// ScraperID is the id of a media, ScraperData is the episode ordering (empty or OrderAbsolute)
+type AniList struct {
+	APIURL        string // url of the api, can be changed to use a local server
+	TitleLanguage string // language of the titles (TitleRomaji, TitleEnglish or TitleNative)
+	IncludeAdult  bool
+	SearchResults int // number of results returned by a search
+	ScraperName   string
+	ScraperID     string
+	ScraperData   string
+	Logger        *log.Logger
+	seasons       []AniListMedia // seasons of the last requested tvs
+	seasonsID     string         // ScraperID of the cached seasons
+	cacheLock     *sync.Mutex
+}
+
+func New() AniList {
+	return AniList{
+		ScraperName:   "anilist",
+		APIURL:        "https://graphql.anilist.co",
+		TitleLanguage: TitleRomaji,
+		SearchResults: 20,
+		Logger:        nil,
+		ScraperID:     "",
+		ScraperData:   "",
+		cacheLock:     &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (a *AniList) Setup(config map[string]string, logger *log.Logger) error {
+	a.Logger = logger
+	if val, ok := config["api_url"]; ok && val != "" {
+		a.APIURL = val
+	}
+	if val, ok := config["title_language"]; ok {
+		switch val {
+		case TitleRomaji, TitleEnglish, TitleNative:
+			a.TitleLanguage = val
+		default:
+			return errors.New("invalid title language: " + val)
+		}
+	}
+	if val, ok := config["include_adult"]; ok {
+		a.IncludeAdult = val == "true"
+	}
+	if val, ok := config["search_max_results"]; ok {
+		if n, err := strconv.Atoi(val); err == nil && n > 0 {
+			a.SearchResults = n
+		}
+	}
+	return nil
+}
+
+func (a *AniList) Configure(ScraperID string, ScraperData string) {
+	a.ScraperID = ScraperID
+	a.ScraperData = ScraperData
+}
+
+// helper to make a graphql request to the api, data is decoded in v
+func (a *AniList) request(query string, variables map[string]interface{}, v interface{}) error {
+	errFields := log.Fields{
+		"file":     "anilist",
+		"function": "request",
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", a.APIURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		a.Logger.WithFields(errFields).Errorf("request error: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		a.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+		return err
+	}
+
+	decode := AniListResponse{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		a.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+		return err
+	}
+	if len(decode.Errors) > 0 {
+		a.Logger.WithFields(errFields).Errorf("request error: status code: %d: %s", resp.StatusCode, decode.Errors[0].Message)
+		return errors.New(decode.Errors[0].Message)
+	}
+	if resp.StatusCode != 200 {
+		a.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+		return errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+
+	return json.Unmarshal(decode.Data, v)
+}
+
+// search media with the given formats, year is ignored if 0
+func (a *AniList) search(name string, formats []string, year int) ([]AniListMedia, error) {
+	query := `query ($search: String, $formats: [MediaFormat], $year: Int, $perPage: Int) {
+		Page(perPage: $perPage) {
+			media(search: $search, type: ANIME, format_in: $formats, seasonYear: $year, sort: SEARCH_MATCH) {` + mediaFields + `}
+		}
+	}`
+	variables := map[string]interface{}{"search": name, "formats": formats, "year": nil, "perPage": a.SearchResults}
+	if year > 0 {
+		variables["year"] = year
+	}
+	decode := AniListPage{}
+	err := a.request(query, variables, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []AniListMedia{}
+	for _, i := range decode.Page.Media {
+		if !i.IsAdult || a.IncludeAdult {
+			ret = append(ret, i)
+		}
+	}
+	return ret, nil
+}
+
+// returns a media from its id
+func (a *AniList) getMedia(id int) (AniListMedia, error) {
+	query := `query ($id: Int) {
+		Media(id: $id, type: ANIME) {` + mediaFields + `}
+	}`
+	decode := AniListMediaData{}
+	err := a.request(query, map[string]interface{}{"id": id}, &decode)
+	return decode.Media, err
+}
+
+// returns the staff and characters of a media, with the japanese voice actors of the characters
+func (a *AniList) getCredits(id int) (AniListCredits, error) {
+	query := `query ($id: Int) {
+		Media(id: $id, type: ANIME) {
+			staff(perPage: 50, sort: RELEVANCE) { edges { role node { id name { full native } } } }
+			characters(perPage: 50, sort: ROLE) { edges { role node { id name { full native } } voiceActors(language: JAPANESE) { id name { full native } } } }
+		}
+	}`
+	decode := AniListCredits{}
+	err := a.request(query, map[string]interface{}{"id": id}, &decode)
+	return decode, err
+}
+
+// returns the id of the first related media with the given relation type and one of the formats
+func related(m AniListMedia, relationType string, formats []string) int {
+	for _, i := range m.Relations.Edges {
+		if i.RelationType != relationType || i.Node.Type != "ANIME" {
+			continue
+		}
+		for _, f := range formats {
+			if i.Node.Format == f {
+				return i.Node.ID
+			}
+		}
+	}
+	return 0
+}
+
+// returns the title in the configured language, or the first available one
+func (a *AniList) title(m AniListMedia) string {
+	titles := map[string]string{
+		TitleRomaji:  m.Title.Romaji,
+		TitleEnglish: m.Title.English,
+		TitleNative:  m.Title.Native,
+	}
+	if val := titles[a.TitleLanguage]; val != "" {
+		return val
+	}
+	for _, i := range []string{TitleRomaji, TitleEnglish, TitleNative} {
+		if titles[i] != "" {
+			return titles[i]
+		}
+	}
+	return ""
+}
+
+func (a *AniList) searchData(m AniListMedia) common.SearchData {
+	return common.SearchData{
+		Title:     a.title(m),
+		Overview:  description(m.Description),
+		Icon:      cover(m),
+		Premiered: m.StartDate.Date(),
+		Adult:     m.IsAdult,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: a.ScraperName,
+			ScraperID:   strconv.Itoa(m.ID),
+			ScraperData: "",
+			ScraperLink: m.SiteURL,
+		},
+	}
+}
+
+var (
+	lineBreakReg = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlTagReg   = regexp.MustCompile(`<[^>]+>`)
+)
+
+// descriptions can contain html tags (ex: <br>, <i>)
+func description(val string) string {
+	val = lineBreakReg.ReplaceAllString(val, "\n")
+	val = htmlTagReg.ReplaceAllString(val, "")
+	return strings.TrimSpace(val)
+}
+
+func cover(m AniListMedia) string {
+	if m.CoverImage.ExtraLarge != "" {
+		return m.CoverImage.ExtraLarge
+	}
+	return m.CoverImage.Large
+}
+
+func trailers(m AniListMedia) []common.TrailerData {
+	var link string
+	switch m.Trailer.Site {
+	case "youtube":
+		link = "https://www.youtube.com/watch?v=" + m.Trailer.ID
+	case "dailymotion":
+		link = "https://www.dailymotion.com/video/" + m.Trailer.ID
+	default:
+		return []common.TrailerData{}
+	}
+	return []common.TrailerData{{Link: link, Site: m.Trailer.Site, Type: "trailer"}}
+}
+
+// scores are on a 0-100 scale
+func ratings(m AniListMedia) []common.RatingData {
+	if m.AverageScore == 0 {
+		return []common.RatingData{}
+	}
+	return []common.RatingData{{Source: "anilist", Value: float64(m.AverageScore), Max: 100}}
+}
+
+func tags(m AniListMedia) []common.TagData {
+	ret := []common.TagData{}
+	for _, i := range m.Genres {
+		ret = append(ret, common.TagData{Name: "genre", Value: i})
+	}
+	for _, i := range m.Tags {
+		// skip the spoilers and the less relevant tags
+		if !i.IsMediaSpoiler && i.Rank >= 50 {
+			ret = append(ret, common.TagData{Name: "tag", Value: i.Name})
+		}
+	}
+	if m.CountryOfOrigin != "" {
+		ret = append(ret, common.TagData{Name: "country", Value: m.CountryOfOrigin})
+	}
+	return ret
+}
+
+func (a *AniList) companies(m AniListMedia) []common.CompanyData {
+	ret := []common.CompanyData{}
+	for _, i := range m.Studios.Edges {
+		if !i.Node.IsAnimationStudio && !i.IsMain {
+			continue
+		}
+		ret = append(ret, common.CompanyData{
+			Name: i.Node.Name,
+			Type: common.CompanyStudio,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: a.ScraperName,
+				ScraperID:   strconv.Itoa(i.Node.ID),
+				ScraperLink: i.Node.SiteURL,
+			},
+		})
+	}
+	return ret
+}
+
+// the staff roles are job titles (ex: Animation Director (eps 1-3)), they are converted to departments
+// the characters are not persons, they are the character played by their voice actors
+func persons(c AniListCredits) []common.PersonData {
+	pers := []common.PersonData{}
+	for _, i := range c.Media.Staff.Edges {
+		pers = append(pers, common.PersonData{Name: i.Node.Name.Full, Role: common.JobRole(i.Role), IsCharacter: false})
+	}
+	for _, i := range c.Media.Characters.Edges {
+		for _, va := range i.VoiceActors {
+			pers = append(pers, common.PersonData{Name: va.Name.Full, Role: common.RoleActing, Character: i.Node.Name.Full, IsCharacter: false})
+		}
+	}
+	return pers
+}
+
+type AniListResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+		Status  int    `json:"status"`
+	} `json:"errors"`
+}
+
+type AniListFuzzyDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// returns the date with the precision of the known fields
+func (d AniListFuzzyDate) Date() common.Date {
+	if d.Year == 0 {
+		return common.Date{}
+	} else if d.Month == 0 {
+		return common.NewDate(time.Date(d.Year, 1, 1, 0, 0, 0, 0, time.UTC), common.DateYear)
+	} else if d.Day == 0 {
+		return common.NewDate(time.Date(d.Year, time.Month(d.Month), 1, 0, 0, 0, 0, time.UTC), common.DateMonth)
+	}
+	return common.NewDate(time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC), common.DateDay)
+}
+
+type AniListMedia struct {
+	ID              int    `json:"id"`
+	IDMal           int    `json:"idMal"`
+	Type            string `json:"type"`
+	Format          string `json:"format"`
+	Status          string `json:"status"`
+	Episodes        int    `json:"episodes"`
+	IsAdult         bool   `json:"isAdult"`
+	CountryOfOrigin string `json:"countryOfOrigin"`
+	SiteURL         string `json:"siteUrl"`
+	Title           struct {
+		Romaji  string `json:"romaji"`
+		English string `json:"english"`
+		Native  string `json:"native"`
+	} `json:"title"`
+	Description string           `json:"description"`
+	StartDate   AniListFuzzyDate `json:"startDate"`
+	CoverImage  struct {
+		ExtraLarge string `json:"extraLarge"`
+		Large      string `json:"large"`
+	} `json:"coverImage"`
+	BannerImage  string   `json:"bannerImage"`
+	AverageScore int      `json:"averageScore"`
+	Popularity   int      `json:"popularity"`
+	Genres       []string `json:"genres"`
+	Tags         []struct {
+		Name           string `json:"name"`
+		Rank           int    `json:"rank"`
+		IsMediaSpoiler bool   `json:"isMediaSpoiler"`
+		IsAdult        bool   `json:"isAdult"`
+	} `json:"tags"`
+	Trailer struct {
+		ID   string `json:"id"`
+		Site string `json:"site"`
+	} `json:"trailer"`
+	Studios struct {
+		Edges []struct {
+			IsMain bool `json:"isMain"`
+			Node   struct {
+				ID                int    `json:"id"`
+				Name              string `json:"name"`
+				IsAnimationStudio bool   `json:"isAnimationStudio"`
+				SiteURL           string `json:"siteUrl"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"studios"`
+	NextAiringEpisode *AniListAiring `json:"nextAiringEpisode"`
+	AiringSchedule    struct {
+		Nodes []AniListAiring `json:"nodes"`
+	} `json:"airingSchedule"`
+	StreamingEpisodes []struct {
+		Title     string `json:"title"`
+		Thumbnail string `json:"thumbnail"`
+	} `json:"streamingEpisodes"`
+	Relations struct {
+		Edges []struct {
+			RelationType string `json:"relationType"`
+			Node         struct {
+				ID     int    `json:"id"`
+				Type   string `json:"type"`
+				Format string `json:"format"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"relations"`
+}
+
+type AniListAiring struct {
+	AiringAt int64 `json:"airingAt"`
+	Episode  int   `json:"episode"`
+}
+
+type AniListPage struct {
+	Page struct {
+		Media []AniListMedia `json:"media"`
+	} `json:"Page"`
+}
+
+type AniListMediaData struct {
+	Media AniListMedia `json:"Media"`
+}
+
+type AniListCredit struct {
+	Role string `json:"role"`
+	Node struct {
+		ID   int `json:"id"`
+		Name struct {
+			Full   string `json:"full"`
+			Native string `json:"native"`
+		} `json:"name"`
+	} `json:"node"`
+	VoiceActors []struct {
+		ID   int `json:"id"`
+		Name struct {
+			Full   string `json:"full"`
+			Native string `json:"native"`
+		} `json:"name"`
+	} `json:"voiceActors"` // only for the characters
+}
+
+type AniListCredits struct {
+	Media struct {
+		Staff struct {
+			Edges []AniListCredit `json:"edges"`
+		} `json:"staff"`
+		Characters struct {
+			Edges []AniListCredit `json:"edges"`
+		} `json:"characters"`
+	} `json:"Media"`
+}
@@ -0,0 +1,147 @@
+package anilist
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// formats of the media used as movies
+var movieFormats = []string{"MOVIE"}
+
+// formats of the media followed to find the first entry of a franchise
+var allFormats = []string{"TV", "TV_SHORT", "MOVIE", "SPECIAL", "OVA", "ONA"}
+
+func NewMovieProvider() common.MovieProvider {
+	p := New()
+	return &p
+}
+
+// movie search
+func (a *AniList) SearchMovie(name string, year int) ([]common.SearchData, error) {
+	data, err := a.search(name, movieFormats, year)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, i := range data {
+		ret = append(ret, a.searchData(i))
+	}
+	return ret, nil
+}
+
+// the api does not support external ids from the other providers
+func (a *AniList) FindMovieByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return nil, errors.New("unsupported external source: " + string(source))
+}
+
+func (a *AniList) getMovie() (AniListMedia, error) {
+	id, err := strconv.Atoi(a.ScraperID)
+	if err != nil {
+		return AniListMedia{}, err
+	}
+	return a.getMedia(id)
+}
+
+func (a *AniList) GetMovie() (common.MovieData, error) {
+	m, err := a.getMovie()
+	if err != nil {
+		return common.MovieData{}, err
+	}
+
+	tr := trailers(m)
+	return common.MovieData{
+		Title:      a.title(m),
+		Overview:   description(m.Description),
+		Icon:       cover(m),
+		Fanart:     m.BannerImage,
+		Website:    "",
+		Trailer:    common.SelectTrailer(tr, common.NewTrailerPolicy()).Link,
+		Trailers:   tr,
+		Premiered:  m.StartDate.Date(),
+		Rating:     int64(m.AverageScore / 10),
+		Ratings:    ratings(m),
+		Collection: 0,
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   a.ScraperID,
+			ScraperName: a.ScraperName,
+			ScraperData: a.ScraperData,
+			ScraperLink: m.SiteURL,
+		},
+	}, nil
+}
+
+// the collection is the first entry of the franchise, found from the prequels of the movie
+func (a *AniList) GetMovieCollection() (common.MovieCollectionData, error) {
+	m, err := a.getMovie()
+	if err != nil {
+		return common.MovieCollectionData{}, err
+	}
+
+	root := m
+	known := map[int]bool{m.ID: true}
+	for n := 0; n < maxSeasons; n++ {
+		prequel := related(root, "PREQUEL", allFormats)
+		if prequel == 0 || known[prequel] {
+			break
+		}
+		known[prequel] = true
+		root, err = a.getMedia(prequel)
+		if err != nil {
+			return common.MovieCollectionData{}, err
+		}
+	}
+	if root.ID == m.ID && related(m, "SEQUEL", allFormats) == 0 {
+		return common.MovieCollectionData{}, errors.New("no data")
+	}
+
+	return common.MovieCollectionData{
+		Title:     a.title(root),
+		Overview:  description(root.Description),
+		Icon:      cover(root),
+		Fanart:    root.BannerImage,
+		Premiered: root.StartDate.Date(),
+		Rating:    int64(root.AverageScore / 10),
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   strconv.Itoa(root.ID),
+			ScraperName: a.ScraperName,
+			ScraperData: "",
+			ScraperLink: root.SiteURL,
+		},
+	}, nil
+}
+
+func (a *AniList) ListMoviePerson() ([]common.PersonData, error) {
+	m, err := a.getMovie()
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	credits, err := a.getCredits(m.ID)
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return persons(credits), nil
+}
+
+func (a *AniList) ListMovieTag() ([]common.TagData, error) {
+	m, err := a.getMovie()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return tags(m), nil
+}
+
+func (a *AniList) ListMovieCompany() ([]common.CompanyData, error) {
+	m, err := a.getMovie()
+	if err != nil {
+		return []common.CompanyData{}, err
+	}
+	return a.companies(m), nil
+}
+
+// upcoming movies are not tracked
+func (a *AniList) GetMovieUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
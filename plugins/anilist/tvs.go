@@ -0,0 +1,353 @@
+package anilist
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// formats of the media used as seasons of a tvs
+var tvsFormats = []string{"TV", "TV_SHORT", "ONA"}
+
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+// tvs search
+// later seasons are not returned if their first season is also a result, as they are part of the same tvs
+func (a *AniList) SearchTVS(name string) ([]common.SearchData, error) {
+	data, err := a.search(name, tvsFormats, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[int]bool{}
+	for _, i := range data {
+		ids[i.ID] = true
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, i := range data {
+		if prequel := related(i, "PREQUEL", tvsFormats); prequel != 0 && ids[prequel] {
+			continue
+		}
+		ret = append(ret, a.searchData(i))
+	}
+	return ret, nil
+}
+
+// the api does not support external ids from the other providers
+func (a *AniList) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return nil, errors.New("unsupported external source: " + string(source))
+}
+
+// returns the seasons of the tvs, each season is a media linked to the previous one by a sequel relation
+// the first season is found from the prequels of the selected media
+func (a *AniList) getSeasons() ([]AniListMedia, error) {
+	a.cacheLock.Lock()
+	defer a.cacheLock.Unlock()
+
+	if a.seasons != nil && a.seasonsID == a.ScraperID {
+		return a.seasons, nil
+	}
+
+	id, err := strconv.Atoi(a.ScraperID)
+	if err != nil {
+		return nil, err
+	}
+	media, err := a.getMedia(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// find the first season
+	known := map[int]bool{media.ID: true}
+	for n := 0; n < maxSeasons; n++ {
+		prequel := related(media, "PREQUEL", tvsFormats)
+		if prequel == 0 || known[prequel] {
+			break
+		}
+		known[prequel] = true
+		media, err = a.getMedia(prequel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// follow the sequels
+	seasons := []AniListMedia{media}
+	known = map[int]bool{media.ID: true}
+	for len(seasons) < maxSeasons {
+		sequel := related(media, "SEQUEL", tvsFormats)
+		if sequel == 0 || known[sequel] {
+			break
+		}
+		known[sequel] = true
+		media, err = a.getMedia(sequel)
+		if err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, media)
+	}
+
+	a.seasons = seasons
+	a.seasonsID = a.ScraperID
+	return a.seasons, nil
+}
+
+// returns the number of episodes of a media, for airing media only the aired episodes are counted
+func episodeCount(m AniListMedia) int {
+	if m.Episodes > 0 {
+		return m.Episodes
+	}
+	if m.NextAiringEpisode != nil {
+		return m.NextAiringEpisode.Episode - 1
+	}
+	return len(m.StreamingEpisodes)
+}
+
+// returns the media containing an episode and the number of the episode in this media
+// with the absolute ordering, all the episodes are in the first season
+func (a *AniList) position(season int, episode int) (AniListMedia, int, error) {
+	seasons, err := a.getSeasons()
+	if err != nil {
+		return AniListMedia{}, 0, err
+	}
+
+	if a.ScraperData == OrderAbsolute {
+		if season != 1 {
+			return AniListMedia{}, 0, errors.New("no data")
+		}
+		for i, m := range seasons {
+			count := episodeCount(m)
+			// the number of episodes of the last season can be unknown
+			if episode <= count || i == len(seasons)-1 {
+				return m, episode, nil
+			}
+			episode -= count
+		}
+		return AniListMedia{}, 0, errors.New("no data")
+	}
+
+	if season < 1 || season > len(seasons) {
+		return AniListMedia{}, 0, errors.New("no data")
+	}
+	return seasons[season-1], episode, nil
+}
+
+// tvs get show, the data of the first season is used
+func (a *AniList) GetTVS() (common.TVSData, error) {
+	seasons, err := a.getSeasons()
+	if err != nil {
+		return common.TVSData{}, err
+	}
+	m := seasons[0]
+
+	tr := trailers(m)
+	return common.TVSData{
+		Title:     a.title(m),
+		Overview:  description(m.Description),
+		Icon:      cover(m),
+		Fanart:    m.BannerImage,
+		Website:   "",
+		Trailer:   common.SelectTrailer(tr, common.NewTrailerPolicy()).Link,
+		Trailers:  tr,
+		Premiered: m.StartDate.Date(),
+		Rating:    int64(m.AverageScore / 10),
+		Ratings:   ratings(m),
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   a.ScraperID,
+			ScraperName: a.ScraperName,
+			ScraperData: a.ScraperData,
+			ScraperLink: m.SiteURL,
+		},
+	}, nil
+}
+
+func (a *AniList) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	m, _, err := a.position(season, 1)
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+
+	return common.TVSSeasonData{
+		Title:        a.title(m),
+		Overview:     description(m.Description),
+		Icon:         cover(m),
+		Fanart:       m.BannerImage,
+		Trailer:      common.SelectTrailer(trailers(m), common.NewTrailerPolicy()).Link,
+		Premiered:    m.StartDate.Date(),
+		Rating:       int64(m.AverageScore / 10),
+		EpisodeCount: int64(episodeCount(m)),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: a.ScraperName,
+			ScraperID:   a.ScraperID,
+			ScraperData: a.ScraperData,
+			ScraperLink: m.SiteURL,
+		},
+	}, nil
+}
+
+// title of a streaming episode, ex: Episode 3 - The Title
+var streamingTitleReg = regexp.MustCompile(`^Episode (\d+)\s*-\s*(.+)$`)
+
+// get tvs episode
+// the api only provides the titles and thumbnails of the episodes available on streaming sites
+func (a *AniList) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	m, ep, err := a.position(season, episode)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+	if count := episodeCount(m); count > 0 && ep > count {
+		return common.TVSEpisodeData{}, errors.New("no data")
+	}
+
+	title := "Episode " + strconv.Itoa(episode)
+	icon := ""
+	for _, i := range m.StreamingEpisodes {
+		match := streamingTitleReg.FindStringSubmatch(i.Title)
+		if len(match) > 2 && match[1] == strconv.Itoa(ep) {
+			title = match[2]
+			icon = i.Thumbnail
+			break
+		}
+	}
+
+	prem := common.Date{}
+	for _, i := range m.AiringSchedule.Nodes {
+		if i.Episode == ep {
+			prem = common.NewDate(time.Unix(i.AiringAt, 0), common.DateTime)
+			break
+		}
+	}
+
+	return common.TVSEpisodeData{
+		Title:     title,
+		Overview:  "",
+		Icon:      icon,
+		Premiered: prem,
+		Rating:    0,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: a.ScraperName,
+			ScraperID:   a.ScraperID,
+			ScraperData: a.ScraperData,
+			ScraperLink: m.SiteURL,
+		},
+	}, nil
+}
+
+// the api does not provide the people of the episodes
+func (a *AniList) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (a *AniList) ListTVSTag() ([]common.TagData, error) {
+	seasons, err := a.getSeasons()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return tags(seasons[0]), nil
+}
+
+// list the studios of all the seasons
+func (a *AniList) ListTVSCompany() ([]common.CompanyData, error) {
+	companies := []common.CompanyData{}
+
+	seasons, err := a.getSeasons()
+	if err != nil {
+		return companies, err
+	}
+
+	known := map[string]bool{}
+	for _, s := range seasons {
+		for _, i := range a.companies(s) {
+			if !known[i.ScraperID] {
+				known[i.ScraperID] = true
+				companies = append(companies, i)
+			}
+		}
+	}
+	return companies, nil
+}
+
+func (a *AniList) ListTVSPerson() ([]common.PersonData, error) {
+	seasons, err := a.getSeasons()
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	credits, err := a.getCredits(seasons[0].ID)
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return persons(credits), nil
+}
+
+func (a *AniList) GetTVSUpcoming() (common.UpcomingData, error) {
+	seasons, err := a.getSeasons()
+	if err != nil {
+		return common.UpcomingData{}, err
+	}
+
+	offset := 0
+	for i, m := range seasons {
+		if m.NextAiringEpisode == nil {
+			offset += episodeCount(m)
+			continue
+		}
+
+		season, episode := i+1, m.NextAiringEpisode.Episode
+		if a.ScraperData == OrderAbsolute {
+			season, episode = 1, offset+episode
+		}
+		return common.UpcomingData{
+			Title:     "Episode " + strconv.Itoa(episode),
+			Overview:  "",
+			Icon:      cover(m),
+			Premiered: common.NewDate(time.Unix(m.NextAiringEpisode.AiringAt, 0), common.DateTime),
+			ID1:       int64(season),
+			ID2:       int64(episode),
+			ScraperInfo: common.ScraperInfo{
+				ScraperID:   strconv.Itoa(m.ID),
+				ScraperName: a.ScraperName,
+				ScraperData: "",
+				ScraperLink: m.SiteURL,
+			},
+		}, nil
+	}
+
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+// by default each sequel is a season, the absolute ordering numbers all the episodes in a single season
+func (a *AniList) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	groups := []common.EpisodeGroupData{}
+
+	seasons, err := a.getSeasons()
+	if err != nil {
+		return groups, err
+	}
+
+	count := 0
+	for _, i := range seasons {
+		count += episodeCount(i)
+	}
+
+	groups = append(groups, common.EpisodeGroupData{
+		Title:        "Absolute",
+		Overview:     "",
+		Type:         "absolute",
+		EpisodeCount: int64(count),
+		GroupCount:   1,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: a.ScraperName,
+			ScraperID:   a.ScraperID,
+			ScraperData: OrderAbsolute,
+			ScraperLink: seasons[0].SiteURL,
+		},
+	})
+	return groups, nil
+}
@@ -0,0 +1,101 @@
+package embedded
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// maximum size of the metadata elements read in memory (mkv info and tags elements, mp4 moov box)
+const maxElementSize = 64 * 1024 * 1024
+
+// provider reading the tags embedded in the video files (ex: written by a dvr software)
+// ScraperID is the full path of the tvs folder or of the movie file, it is stored relative to the library by the scraper
+type Embedded struct {
+	ScraperName string
+	ScraperID   string
+	ScraperData string
+	Logger      *log.Logger
+	index       *FileIndex // index of the files of the last requested tvs
+	cacheLock   *sync.Mutex
+}
+
+func New() Embedded {
+	return Embedded{ScraperName: "embedded", Logger: nil, ScraperID: "", ScraperData: "", cacheLock: &sync.Mutex{}}
+}
+
+// configure the provider's settings
+func (e *Embedded) Setup(config map[string]string, logger *log.Logger) error {
+	e.Logger = logger
+	return nil
+}
+
+func (e *Embedded) Configure(ScraperID string, ScraperData string) {
+	e.ScraperID = ScraperID
+	e.ScraperData = ScraperData
+}
+
+// read the tags of a video file, the container is selected from the extension of the file
+func readTags(path string) (FileTags, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mkv", ".mk3d", ".webm":
+		return readMKV(path)
+	case ".mp4", ".m4v", ".mov":
+		return readMP4(path)
+	}
+	return FileTags{}, errors.New("unsupported container: " + path)
+}
+
+// helpers
+
+func newTags() FileTags {
+	return FileTags{Season: -1, Episode: -1}
+}
+
+var leadingNumberReg = regexp.MustCompile(`^\s*(\d+)`)
+
+// returns the number at the start of a value (ex: 3 for "3/10"), -1 if there is no number
+func number(val string) int {
+	match := leadingNumberReg.FindStringSubmatch(val)
+	if len(match) < 2 {
+		return -1
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// set a value only if it is not already set, the tags are read by order of preference
+func setString(dst *string, val string) {
+	if *dst == "" {
+		*dst = strings.TrimSpace(val)
+	}
+}
+
+func setNumber(dst *int, val int) {
+	if *dst < 0 {
+		*dst = val
+	}
+}
+
+func setDate(dst *common.Date, val string) {
+	if !dst.IsKnown() {
+		*dst = common.ParseDate(val)
+	}
+}
+
+func isVideo(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mkv", ".mk3d", ".webm", ".mp4", ".m4v", ".mov":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package embedded
+
+import "github.com/zogwine/metadata/internal/providers/common"
+
+// tags read from a video file, the tags of the mkv and mp4 containers are converted to this format
+type FileTags struct {
+	Title     string // title of the episode or of the movie
+	Show      string // title of the tvs
+	Overview  string
+	Genre     string
+	Season    int // -1 if unknown
+	Episode   int // -1 if unknown
+	Premiered common.Date
+}
+
+// index of the tagged video files found in a tvs folder
+type FileIndex struct {
+	Path     string
+	Show     string // most common tvs title of the files
+	Episodes map[string]TaggedFile
+}
+
+type TaggedFile struct {
+	Path string
+	Tags FileTags
+}
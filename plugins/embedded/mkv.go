@@ -0,0 +1,322 @@
+package embedded
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// ids of the ebml elements used to read the tags of a mkv file
+const (
+	ebmlHeader            = 0x1A45DFA3
+	mkvSegment            = 0x18538067
+	mkvSeekHead           = 0x114D9B74
+	mkvSeek               = 0x4DBB
+	mkvSeekID             = 0x53AB
+	mkvSeekPosition       = 0x53AC
+	mkvInfo               = 0x1549A966
+	mkvTitle              = 0x7BA9
+	mkvTags               = 0x1254C367
+	mkvTag                = 0x7373
+	mkvTargets            = 0x63C0
+	mkvTargetTypeValue    = 0x68CA
+	mkvTagTrackUID        = 0x63C5
+	mkvTagEditionUID      = 0x63C9
+	mkvTagChapterUID      = 0x63C4
+	mkvTagAttachmentUID   = 0x63C6
+	mkvSimpleTag          = 0x67C8
+	mkvTagName            = 0x45A3
+	mkvTagString          = 0x4487
+	mkvTargetCollection   = 70 // tvs
+	mkvTargetSeason       = 60
+	mkvTargetEpisode      = 50 // episode or movie, default target of the tags
+	mkvUnknownSize        = -1
+	mkvMaxElementIDLength = 4
+)
+
+type ebmlElement struct {
+	ID     uint64
+	Offset int64 // position of the data of the element
+	Size   int64 // mkvUnknownSize if the size is unknown (ex: live recordings)
+	Data   []byte
+}
+
+// read a variable size integer, the marker bit is kept for the ids
+// returns the value, its length and true if all the bits of the value are set (unknown size)
+func readVint(data []byte, keepMarker bool) (uint64, int, bool, error) {
+	if len(data) == 0 || data[0] == 0 {
+		return 0, 0, false, errors.New("invalid ebml integer")
+	}
+	length := 1
+	for mask := byte(0x80); data[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if len(data) < length {
+		return 0, 0, false, errors.New("truncated ebml integer")
+	}
+
+	val := uint64(data[0])
+	if !keepMarker {
+		val &= uint64(0xFF >> length)
+	}
+	allSet := val == uint64(0xFF>>length)
+	for i := 1; i < length; i++ {
+		val = val<<8 | uint64(data[i])
+		allSet = allSet && data[i] == 0xFF
+	}
+	return val, length, allSet, nil
+}
+
+// read the header of the element stored at offset in the file
+func readElementHeader(f io.ReaderAt, offset int64) (ebmlElement, error) {
+	buf := make([]byte, mkvMaxElementIDLength+8)
+	n, err := f.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return ebmlElement{}, err
+	}
+	buf = buf[:n]
+
+	id, idLength, _, err := readVint(buf, true)
+	if err != nil || idLength > mkvMaxElementIDLength {
+		return ebmlElement{}, errors.New("invalid ebml element")
+	}
+	size, sizeLength, unknown, err := readVint(buf[idLength:], false)
+	if err != nil {
+		return ebmlElement{}, err
+	}
+	el := ebmlElement{ID: id, Offset: offset + int64(idLength+sizeLength), Size: int64(size)}
+	if unknown {
+		el.Size = mkvUnknownSize
+	}
+	return el, nil
+}
+
+// read the data of an element from the file
+func readElementData(f io.ReaderAt, el ebmlElement) ([]byte, error) {
+	if el.Size == mkvUnknownSize || el.Size > maxElementSize {
+		return nil, errors.New("ebml element too large")
+	}
+	data := make([]byte, el.Size)
+	_, err := f.ReadAt(data, el.Offset)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return data, nil
+}
+
+// parse the children of an element read in memory
+func parseChildren(data []byte) []ebmlElement {
+	ret := []ebmlElement{}
+	for pos := 0; pos < len(data); {
+		id, idLength, _, err := readVint(data[pos:], true)
+		if err != nil {
+			break
+		}
+		size, sizeLength, unknown, err := readVint(data[pos+idLength:], false)
+		if err != nil || unknown {
+			break
+		}
+		start := pos + idLength + sizeLength
+		end := start + int(size)
+		if size > uint64(len(data)) || end > len(data) {
+			break
+		}
+		ret = append(ret, ebmlElement{ID: id, Offset: int64(start), Size: int64(size), Data: data[start:end]})
+		pos = end
+	}
+	return ret
+}
+
+func readUint(data []byte) uint64 {
+	val := uint64(0)
+	for _, i := range data {
+		val = val<<8 | uint64(i)
+	}
+	return val
+}
+
+func readString(data []byte) string {
+	return strings.TrimRight(string(data), "\x00")
+}
+
+// read the segment title and the tags of a mkv file
+// the top level elements of the segment are read in order, the position of the tags is read from the seek head
+// if they are stored after a cluster of unknown size
+func readMKV(path string) (FileTags, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FileTags{}, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return FileTags{}, err
+	}
+
+	header, err := readElementHeader(f, 0)
+	if err != nil || header.ID != ebmlHeader || header.Size == mkvUnknownSize {
+		return FileTags{}, errors.New("not a mkv file: " + path)
+	}
+	segment, err := readElementHeader(f, header.Offset+header.Size)
+	if err != nil || segment.ID != mkvSegment {
+		return FileTags{}, errors.New("no segment in: " + path)
+	}
+	end := info.Size()
+	if segment.Size != mkvUnknownSize && segment.Offset+segment.Size < end {
+		end = segment.Offset + segment.Size
+	}
+
+	tags := newTags()
+	title := ""
+	tagsFound := false
+	tagsPosition := int64(-1)
+	for pos := segment.Offset; pos < end; {
+		el, err := readElementHeader(f, pos)
+		if err != nil {
+			break
+		}
+		switch el.ID {
+		case mkvSeekHead:
+			data, err := readElementData(f, el)
+			if err == nil && tagsPosition < 0 {
+				tagsPosition = seekPosition(data, mkvTags)
+			}
+		case mkvInfo:
+			data, err := readElementData(f, el)
+			if err == nil {
+				for _, i := range parseChildren(data) {
+					if i.ID == mkvTitle {
+						title = readString(i.Data)
+					}
+				}
+			}
+		case mkvTags:
+			data, err := readElementData(f, el)
+			if err == nil {
+				readMKVTags(data, &tags)
+				tagsFound = true
+			}
+		}
+		if el.Size == mkvUnknownSize {
+			break
+		}
+		pos = el.Offset + el.Size
+	}
+
+	if !tagsFound && tagsPosition >= 0 {
+		el, err := readElementHeader(f, segment.Offset+tagsPosition)
+		if err == nil && el.ID == mkvTags {
+			data, err := readElementData(f, el)
+			if err == nil {
+				readMKVTags(data, &tags)
+			}
+		}
+	}
+
+	// the segment title is used if there is no title tag
+	setString(&tags.Title, title)
+	return tags, nil
+}
+
+// returns the position of an element relative to the start of the segment, -1 if it is not listed in the seek head
+func seekPosition(data []byte, id uint64) int64 {
+	for _, seek := range parseChildren(data) {
+		if seek.ID != mkvSeek {
+			continue
+		}
+		seekID := uint64(0)
+		position := int64(-1)
+		for _, i := range parseChildren(seek.Data) {
+			switch i.ID {
+			case mkvSeekID:
+				seekID = readUint(i.Data)
+			case mkvSeekPosition:
+				position = int64(readUint(i.Data))
+			}
+		}
+		if seekID == id {
+			return position
+		}
+	}
+	return -1
+}
+
+// read the tags of the tvs, of the season and of the episode or movie
+// the tags of the tracks, editions, chapters and attachments are ignored
+func readMKVTags(data []byte, tags *FileTags) {
+	for _, tag := range parseChildren(data) {
+		if tag.ID != mkvTag {
+			continue
+		}
+		target := uint64(mkvTargetEpisode)
+		skip := false
+		values := map[string]string{}
+		for _, i := range parseChildren(tag.Data) {
+			switch i.ID {
+			case mkvTargets:
+				for _, t := range parseChildren(i.Data) {
+					switch t.ID {
+					case mkvTargetTypeValue:
+						target = readUint(t.Data)
+					case mkvTagTrackUID, mkvTagEditionUID, mkvTagChapterUID, mkvTagAttachmentUID:
+						if readUint(t.Data) != 0 {
+							skip = true
+						}
+					}
+				}
+			case mkvSimpleTag:
+				name, value := "", ""
+				for _, t := range parseChildren(i.Data) {
+					switch t.ID {
+					case mkvTagName:
+						name = strings.ToUpper(readString(t.Data))
+					case mkvTagString:
+						value = readString(t.Data)
+					}
+				}
+				if _, ok := values[name]; !ok && name != "" && value != "" {
+					values[name] = value
+				}
+			}
+		}
+		if !skip {
+			applyMKVTags(target, values, tags)
+		}
+	}
+}
+
+// convert the matroska tags, some common non standard names are also supported (ex: SEASON, EPISODE)
+func applyMKVTags(target uint64, values map[string]string, tags *FileTags) {
+	switch target {
+	case mkvTargetCollection:
+		setString(&tags.Show, values["TITLE"])
+		setString(&tags.Genre, values["GENRE"])
+	case mkvTargetSeason:
+		if n := number(values["PART_NUMBER"]); n >= 0 {
+			setNumber(&tags.Season, n)
+		}
+	case mkvTargetEpisode:
+		setString(&tags.Title, values["TITLE"])
+		for _, i := range []string{"SHOW", "SERIES", "TVSHOW"} {
+			setString(&tags.Show, values[i])
+		}
+		for _, i := range []string{"DESCRIPTION", "SYNOPSIS", "SUMMARY", "COMMENT"} {
+			setString(&tags.Overview, values[i])
+		}
+		setString(&tags.Genre, values["GENRE"])
+		for _, i := range []string{"DATE_RELEASED", "DATE_RECORDED", "DATE_BROADCASTED"} {
+			setDate(&tags.Premiered, values[i])
+		}
+		for _, i := range []string{"PART_NUMBER", "EPISODE", "EPISODE_NUMBER"} {
+			if n := number(values[i]); n >= 0 {
+				setNumber(&tags.Episode, n)
+			}
+		}
+		for _, i := range []string{"SEASON", "SEASON_NUMBER"} {
+			if n := number(values[i]); n >= 0 {
+				setNumber(&tags.Season, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package embedded
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// encode an ebml element, the size is written on 8 bytes
+func ebml(id uint64, data ...[]byte) []byte {
+	ret := []byte{}
+	for shift := 24; shift >= 0; shift -= 8 {
+		if b := byte(id >> shift); b != 0 || len(ret) > 0 {
+			ret = append(ret, b)
+		}
+	}
+	content := []byte{}
+	for _, i := range data {
+		content = append(content, i...)
+	}
+	size := make([]byte, 8)
+	binary.BigEndian.PutUint64(size, uint64(len(content)))
+	size[0] = 0x01
+	return append(append(ret, size...), content...)
+}
+
+func ebmlString(id uint64, val string) []byte {
+	return ebml(id, []byte(val))
+}
+
+func ebmlUint(id uint64, val uint64) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, val)
+	return ebml(id, data)
+}
+
+func mkvSimple(name string, value string) []byte {
+	return ebml(mkvSimpleTag, ebmlString(mkvTagName, name), ebmlString(mkvTagString, value))
+}
+
+func mkvTagElement(target uint64, data ...[]byte) []byte {
+	return ebml(mkvTag, append([][]byte{ebml(mkvTargets, ebmlUint(mkvTargetTypeValue, target))}, data...)...)
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestReadVint(t *testing.T) {
+	tests := []struct {
+		data       []byte
+		keepMarker bool
+		val        uint64
+		length     int
+		allSet     bool
+	}{
+		{[]byte{0x81}, false, 1, 1, false},
+		{[]byte{0x81}, true, 0x81, 1, false},
+		{[]byte{0x40, 0x02, 0xFF}, false, 2, 2, false},
+		{[]byte{0x1A, 0x45, 0xDF, 0xA3}, true, ebmlHeader, 4, false},
+		{[]byte{0x01, 0, 0, 0, 0, 0, 0x01, 0x00}, false, 256, 8, false},
+		{[]byte{0xFF}, false, 0x7F, 1, true},
+		{[]byte{0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, false, 0xFFFFFFFFFFFFFF, 8, true},
+	}
+	for _, i := range tests {
+		val, length, allSet, err := readVint(i.data, i.keepMarker)
+		if err != nil || val != i.val || length != i.length || allSet != i.allSet {
+			t.Errorf("readVint(%x, %t) = %x, %d, %t, %v, want %x, %d, %t", i.data, i.keepMarker, val, length, allSet, err, i.val, i.length, i.allSet)
+		}
+	}
+	for _, i := range [][]byte{{}, {0x00}, {0x40}, {0x01, 0xFF}} {
+		if _, _, _, err := readVint(i, false); err == nil {
+			t.Errorf("readVint(%x) returned no error", i)
+		}
+	}
+}
+
+func TestReadMKV(t *testing.T) {
+	header := ebml(ebmlHeader, ebmlString(0x4282, "matroska"))
+	tags := ebml(mkvTags,
+		// the tags of a track are ignored
+		ebml(mkvTag,
+			ebml(mkvTargets, ebmlUint(mkvTargetTypeValue, mkvTargetEpisode), ebmlUint(mkvTagTrackUID, 1)),
+			mkvSimple("TITLE", "Track title"),
+		),
+		mkvTagElement(mkvTargetCollection, mkvSimple("TITLE", "Show"), mkvSimple("GENRE", "Drama")),
+		mkvTagElement(mkvTargetSeason, mkvSimple("PART_NUMBER", "2")),
+		mkvTagElement(mkvTargetEpisode,
+			mkvSimple("title", "Episode\x00"),
+			mkvSimple("TITLE", "Other title"),
+			mkvSimple("PART_NUMBER", "3/10"),
+			mkvSimple("SYNOPSIS", "Overview"),
+			mkvSimple("DATE_RELEASED", "2010-05-12"),
+		),
+	)
+	want := FileTags{Title: "Episode", Show: "Show", Overview: "Overview", Genre: "Drama", Season: 2, Episode: 3, Premiered: common.ParseDate("2010-05-12")}
+
+	p := writeFile(t, "episode.mkv", append(header, ebml(mkvSegment, ebml(mkvInfo, ebmlString(mkvTitle, "Segment title")), tags)...))
+	res, err := readTags(p)
+	if err != nil {
+		t.Fatalf("readTags(tags) returned %v", err)
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("readTags(tags) = %+v, want %+v", res, want)
+	}
+
+	// tags stored after a cluster of unknown size, found from the seek head
+	// the segment title is used as there is no title tag for the episode
+	info := ebml(mkvInfo, ebmlString(mkvTitle, "Segment title"))
+	// cluster of unknown size containing a block
+	cluster := []byte{0x1F, 0x43, 0xB6, 0x75, 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xA3, 0x81, 0x00}
+	tags = ebml(mkvTags, mkvTagElement(mkvTargetEpisode, mkvSimple("EPISODE", "4"), mkvSimple("SEASON", "1")))
+	seekHead := func(position uint64) []byte {
+		return ebml(mkvSeekHead,
+			ebml(mkvSeek, ebml(mkvSeekID, []byte{0x15, 0x49, 0xA9, 0x66}), ebmlUint(mkvSeekPosition, 0)),
+			ebml(mkvSeek, ebml(mkvSeekID, []byte{0x12, 0x54, 0xC3, 0x67}), ebmlUint(mkvSeekPosition, position)),
+		)
+	}
+	position := uint64(len(seekHead(0)) + len(info) + len(cluster))
+	segment := append(append(append(seekHead(position), info...), cluster...), tags...)
+	// the size of the segment is unknown
+	segment = append([]byte{0x18, 0x53, 0x80, 0x67, 0xFF}, segment...)
+	p = writeFile(t, "episode.webm", append(header, segment...))
+	res, err = readTags(p)
+	if err != nil {
+		t.Fatalf("readTags(seek head) returned %v", err)
+	}
+	want = FileTags{Title: "Segment title", Season: 1, Episode: 4}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("readTags(seek head) = %+v, want %+v", res, want)
+	}
+
+	// files without tags
+	p = writeFile(t, "empty.mkv", append(header, ebml(mkvSegment)...))
+	if res, err = readTags(p); err != nil || !reflect.DeepEqual(res, newTags()) {
+		t.Errorf("readTags(no tags) = %+v, %v, want no tags", res, err)
+	}
+
+	for name, data := range map[string][]byte{
+		"not an ebml file": []byte("RIFF\x00\x00\x00\x00AVI "),
+		"no segment":       header,
+		"empty file":       {},
+	} {
+		p = writeFile(t, "invalid.mkv", data)
+		if _, err := readTags(p); err == nil {
+			t.Errorf("readTags(%s) returned no error", name)
+		}
+	}
+}
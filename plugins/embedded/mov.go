@@ -0,0 +1,115 @@
+package embedded
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewMovieProvider() common.MovieProvider {
+	p := New()
+	return &p
+}
+
+// returns the video file of a movie, the path can be the file or the folder of the movie
+func movieFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", err
+	}
+	for _, i := range entries {
+		if !i.IsDir() && isVideo(i.Name()) {
+			return filepath.Join(path, i.Name()), nil
+		}
+	}
+	return "", errors.New("no video file in: " + path)
+}
+
+// movie search, the tags can only be read from the path of the movie
+func (e *Embedded) SearchMovie(name string, year int) ([]common.SearchData, error) {
+	return nil, errors.New("the embedded provider requires the path of the movie")
+}
+
+// movie search from the title embedded in the video file
+func (e *Embedded) SearchMovieFromPath(path string) ([]common.SearchData, error) {
+	file, err := movieFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := readTags(file)
+	if err != nil {
+		return nil, err
+	}
+	if tags.Title == "" {
+		return []common.SearchData{}, nil
+	}
+	return []common.SearchData{{
+		Title:     tags.Title,
+		Overview:  tags.Overview,
+		Premiered: tags.Premiered,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: e.ScraperName,
+			ScraperID:   file,
+			ScraperData: "",
+			ScraperLink: "",
+		},
+	}}, nil
+}
+
+// the tags do not contain external ids
+func (e *Embedded) FindMovieByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return nil, errors.New("unsupported external source: " + string(source))
+}
+
+func (e *Embedded) GetMovie() (common.MovieData, error) {
+	tags, err := readTags(e.ScraperID)
+	if err != nil {
+		return common.MovieData{}, err
+	}
+	if tags.Title == "" {
+		return common.MovieData{}, errors.New("no data")
+	}
+	return common.MovieData{
+		Title:       tags.Title,
+		Overview:    tags.Overview,
+		Trailers:    []common.TrailerData{},
+		Premiered:   tags.Premiered,
+		ScraperInfo: e.scraperInfo(),
+	}, nil
+}
+
+func (e *Embedded) ListMovieTag() ([]common.TagData, error) {
+	tags, err := readTags(e.ScraperID)
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	if tags.Genre == "" {
+		return []common.TagData{}, nil
+	}
+	return []common.TagData{{Name: "genre", Value: tags.Genre}}, nil
+}
+
+func (e *Embedded) ListMovieCompany() ([]common.CompanyData, error) {
+	return []common.CompanyData{}, nil
+}
+
+func (e *Embedded) ListMoviePerson() ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (e *Embedded) GetMovieUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+func (e *Embedded) GetMovieCollection() (common.MovieCollectionData, error) {
+	return common.MovieCollectionData{}, errors.New("no data")
+}
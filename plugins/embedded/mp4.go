@@ -0,0 +1,186 @@
+package embedded
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// mp4 box read in memory
+type mp4Box struct {
+	Type string
+	Data []byte
+}
+
+// well-known types of the data boxes of the metadata items
+const (
+	mp4Implicit = 0
+	mp4UTF8     = 1
+	mp4Integer  = 21
+)
+
+// read the header of the box stored at offset in the file
+// returns the type of the box, the position of its data and its size (until the end of the file if the size is 0)
+func readBoxHeader(f io.ReaderAt, offset int64, fileSize int64) (string, int64, int64, error) {
+	buf := make([]byte, 16)
+	n, err := f.ReadAt(buf, offset)
+	if n < 8 {
+		if err == nil {
+			err = io.EOF
+		}
+		return "", 0, 0, err
+	}
+
+	size := int64(binary.BigEndian.Uint32(buf[0:4]))
+	tp := string(buf[4:8])
+	start := offset + 8
+	switch size {
+	case 0:
+		size = fileSize - offset
+	case 1:
+		if n < 16 {
+			return "", 0, 0, errors.New("truncated mp4 box")
+		}
+		size = int64(binary.BigEndian.Uint64(buf[8:16]))
+		start += 8
+	}
+	if size < start-offset {
+		return "", 0, 0, errors.New("invalid mp4 box size")
+	}
+	return tp, start, size - (start - offset), nil
+}
+
+// parse the boxes stored in a box read in memory
+func parseBoxes(data []byte) []mp4Box {
+	ret := []mp4Box{}
+	for pos := 0; pos+8 <= len(data); {
+		size := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		tp := string(data[pos+4 : pos+8])
+		start := pos + 8
+		if size == 0 {
+			size = len(data) - pos
+		}
+		if size < 8 || pos+size > len(data) {
+			break
+		}
+		ret = append(ret, mp4Box{Type: tp, Data: data[start : pos+size]})
+		pos += size
+	}
+	return ret
+}
+
+func findBox(boxes []mp4Box, tp string) (mp4Box, bool) {
+	for _, i := range boxes {
+		if i.Type == tp {
+			return i, true
+		}
+	}
+	return mp4Box{}, false
+}
+
+// returns the metadata items of a meta box
+// the meta box of the mp4 files is a full box (version and flags before its children), it is not the case for quicktime files
+func metaItems(meta mp4Box) []mp4Box {
+	data := meta.Data
+	if len(data) >= 8 && string(data[4:8]) != "hdlr" {
+		data = data[4:]
+	}
+	ilst, ok := findBox(parseBoxes(data), "ilst")
+	if !ok {
+		return []mp4Box{}
+	}
+	return parseBoxes(ilst.Data)
+}
+
+// returns the value of a metadata item as a string, integers are converted to strings
+func itemValue(item mp4Box) string {
+	data, ok := findBox(parseBoxes(item.Data), "data")
+	if !ok || len(data.Data) < 8 {
+		return ""
+	}
+	tp := binary.BigEndian.Uint32(data.Data[0:4]) & 0xFFFFFF
+	value := data.Data[8:]
+	switch tp {
+	case mp4UTF8:
+		return string(value)
+	case mp4Integer, mp4Implicit:
+		if len(value) == 0 || len(value) > 8 {
+			return ""
+		}
+		n := uint64(0)
+		for _, i := range value {
+			n = n<<8 | uint64(i)
+		}
+		return strconv.FormatUint(n, 10)
+	}
+	return ""
+}
+
+// read the metadata items of a mp4 file (moov/udta/meta/ilst, or moov/meta/ilst for quicktime files)
+// only the moov box is read in memory, the other top level boxes are skipped
+func readMP4(path string) (FileTags, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FileTags{}, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return FileTags{}, err
+	}
+
+	var moov []byte
+	for pos := int64(0); pos < info.Size(); {
+		tp, start, size, err := readBoxHeader(f, pos, info.Size())
+		if err != nil {
+			break
+		}
+		if tp == "moov" {
+			if size > maxElementSize {
+				return FileTags{}, errors.New("mp4 moov box too large: " + path)
+			}
+			moov = make([]byte, size)
+			_, err = f.ReadAt(moov, start)
+			if err != nil && err != io.EOF {
+				return FileTags{}, err
+			}
+			break
+		}
+		pos = start + size
+	}
+	if moov == nil {
+		return FileTags{}, errors.New("not a mp4 file: " + path)
+	}
+
+	items := []mp4Box{}
+	boxes := parseBoxes(moov)
+	if udta, ok := findBox(boxes, "udta"); ok {
+		if meta, ok := findBox(parseBoxes(udta.Data), "meta"); ok {
+			items = metaItems(meta)
+		}
+	}
+	if meta, ok := findBox(boxes, "meta"); ok && len(items) == 0 {
+		items = metaItems(meta)
+	}
+
+	values := map[string]string{}
+	for _, i := range items {
+		if _, ok := values[i.Type]; !ok {
+			values[i.Type] = itemValue(i)
+		}
+	}
+
+	tags := newTags()
+	setString(&tags.Title, values["\xa9nam"])
+	setString(&tags.Show, values["tvsh"])
+	for _, i := range []string{"ldes", "desc", "\xa9cmt"} {
+		setString(&tags.Overview, values[i])
+	}
+	setString(&tags.Genre, values["\xa9gen"])
+	setDate(&tags.Premiered, values["\xa9day"])
+	tags.Season = number(values["tvsn"])
+	tags.Episode = number(values["tves"])
+	return tags, nil
+}
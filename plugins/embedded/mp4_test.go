@@ -0,0 +1,113 @@
+package embedded
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// encode a mp4 box with a 32 bit size
+func box(tp string, data ...[]byte) []byte {
+	content := []byte{}
+	for _, i := range data {
+		content = append(content, i...)
+	}
+	ret := make([]byte, 8)
+	binary.BigEndian.PutUint32(ret, uint32(8+len(content)))
+	copy(ret[4:], tp)
+	return append(ret, content...)
+}
+
+// encode a metadata item, with its well-known type
+func mp4Item(tp string, dataType uint32, value []byte) []byte {
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header, dataType)
+	return box(tp, box("data", header, value))
+}
+
+func TestReadMP4(t *testing.T) {
+	ftyp := box("ftyp", []byte("isom\x00\x00\x02\x00isomiso2mp41"))
+	hdlr := box("hdlr", make([]byte, 8), []byte("mdirappl"), make([]byte, 9))
+	ilst := box("ilst",
+		mp4Item("\xa9nam", mp4UTF8, []byte("Episode")),
+		mp4Item("\xa9nam", mp4UTF8, []byte("Other title")),
+		mp4Item("tvsh", mp4UTF8, []byte("Show")),
+		mp4Item("desc", mp4UTF8, []byte("Short overview")),
+		mp4Item("ldes", mp4UTF8, []byte("Overview")),
+		mp4Item("\xa9gen", mp4UTF8, []byte("Drama")),
+		mp4Item("\xa9day", mp4UTF8, []byte("2010-05-12")),
+		mp4Item("tvsn", mp4Integer, []byte{0, 0, 0, 2}),
+		mp4Item("tves", mp4Implicit, []byte{3}),
+		// unknown data types are ignored
+		mp4Item("\xa9cmt", 13, []byte{0xFF, 0xD8}),
+	)
+	want := FileTags{Title: "Episode", Show: "Show", Overview: "Overview", Genre: "Drama", Season: 2, Episode: 3, Premiered: common.ParseDate("2010-05-12")}
+
+	// the meta box of the mp4 files is a full box, the mdat box has a 64 bit size
+	meta := box("meta", make([]byte, 4), hdlr, ilst)
+	mdat := make([]byte, 16)
+	binary.BigEndian.PutUint32(mdat, 1)
+	copy(mdat[4:], "mdat")
+	binary.BigEndian.PutUint64(mdat[8:], 16+4)
+	mdat = append(mdat, 0, 0, 0, 0)
+	data := append(append(ftyp, mdat...), box("moov", box("mvhd", make([]byte, 100)), box("udta", meta))...)
+	res, err := readTags(writeFile(t, "episode.mp4", data))
+	if err != nil {
+		t.Fatalf("readTags(mp4) returned %v", err)
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("readTags(mp4) = %+v, want %+v", res, want)
+	}
+
+	// the meta box of the quicktime files is stored in the moov box, and is not a full box
+	data = append(append([]byte{}, ftyp...), box("moov", box("meta", hdlr, ilst))...)
+	res, err = readTags(writeFile(t, "episode.mov", data))
+	if err != nil {
+		t.Fatalf("readTags(quicktime) returned %v", err)
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("readTags(quicktime) = %+v, want %+v", res, want)
+	}
+
+	// files without metadata
+	data = append(append([]byte{}, ftyp...), box("moov", box("mvhd", make([]byte, 100)))...)
+	if res, err = readTags(writeFile(t, "empty.m4v", data)); err != nil || !reflect.DeepEqual(res, newTags()) {
+		t.Errorf("readTags(no metadata) = %+v, %v, want no tags", res, err)
+	}
+
+	for name, data := range map[string][]byte{
+		"no moov box":    append(append([]byte{}, ftyp...), box("mdat", make([]byte, 32))...),
+		"invalid size":   {0, 0, 0, 4, 'f', 't', 'y', 'p'},
+		"truncated file": {0, 0, 0},
+	} {
+		if _, err := readTags(writeFile(t, "invalid.mp4", data)); err == nil {
+			t.Errorf("readTags(%s) returned no error", name)
+		}
+	}
+}
+
+func TestItemValue(t *testing.T) {
+	tests := []struct {
+		dataType uint32
+		value    []byte
+		str      string
+	}{
+		{mp4UTF8, []byte("text"), "text"},
+		{mp4Integer, []byte{0x01, 0x00}, "256"},
+		{mp4Implicit, []byte{0, 0, 0, 0, 0, 0, 0, 7}, "7"},
+		{mp4Integer, make([]byte, 9), ""},
+		{mp4Integer, []byte{}, ""},
+		{13, []byte{0xFF}, ""},
+	}
+	for _, i := range tests {
+		item := parseBoxes(mp4Item("test", i.dataType, i.value))[0]
+		if str := itemValue(item); str != i.str {
+			t.Errorf("itemValue(%d, %x) = %q, want %q", i.dataType, i.value, str, i.str)
+		}
+	}
+	if str := itemValue(mp4Box{Type: "test", Data: box("data", []byte{0, 0, 0})}); str != "" {
+		t.Errorf("itemValue(truncated) = %q, want an empty value", str)
+	}
+}
@@ -0,0 +1,193 @@
+package embedded
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+func episodeKey(season int, episode int) string {
+	return strconv.Itoa(season) + "-" + strconv.Itoa(episode)
+}
+
+// index the tagged video files of a tvs folder
+// the title of the tvs is the most common tvs title of the files
+func indexTVS(path string) FileIndex {
+	index := FileIndex{Path: path, Episodes: map[string]TaggedFile{}}
+	shows := map[string]int{}
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isVideo(p) {
+			return nil
+		}
+		tags, err := readTags(p)
+		if err != nil {
+			return nil
+		}
+		if tags.Show != "" {
+			shows[tags.Show]++
+			if shows[tags.Show] > shows[index.Show] {
+				index.Show = tags.Show
+			}
+		}
+		if tags.Season >= 0 && tags.Episode >= 0 {
+			key := episodeKey(tags.Season, tags.Episode)
+			if _, ok := index.Episodes[key]; !ok {
+				index.Episodes[key] = TaggedFile{Path: p, Tags: tags}
+			}
+		}
+		return nil
+	})
+	return index
+}
+
+// returns the index of the files of the configured tvs
+// the index is cached as it is used for every season and episode of the tvs
+func (e *Embedded) getIndex() *FileIndex {
+	e.cacheLock.Lock()
+	defer e.cacheLock.Unlock()
+
+	if e.index != nil && e.index.Path == e.ScraperID {
+		return e.index
+	}
+	index := indexTVS(e.ScraperID)
+	e.index = &index
+	return e.index
+}
+
+func (e *Embedded) scraperInfo() common.ScraperInfo {
+	return common.ScraperInfo{
+		ScraperName: e.ScraperName,
+		ScraperID:   e.ScraperID,
+		ScraperData: e.ScraperData,
+		ScraperLink: "",
+	}
+}
+
+func (e *Embedded) episodeData(tags FileTags) common.TVSEpisodeData {
+	return common.TVSEpisodeData{
+		Title:       tags.Title,
+		Overview:    tags.Overview,
+		Premiered:   tags.Premiered,
+		Season:      int64(tags.Season),
+		Episode:     int64(tags.Episode),
+		ScraperInfo: e.scraperInfo(),
+	}
+}
+
+// tvs search, the tags can only be read from the path of the tvs
+func (e *Embedded) SearchTVS(name string) ([]common.SearchData, error) {
+	return nil, errors.New("the embedded provider requires the path of the tvs")
+}
+
+// tvs search from the tvs title embedded in the video files of the tvs folder
+func (e *Embedded) SearchTVSFromPath(path string) ([]common.SearchData, error) {
+	index := indexTVS(path)
+	if index.Show == "" {
+		return []common.SearchData{}, nil
+	}
+	return []common.SearchData{{
+		Title: index.Show,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: e.ScraperName,
+			ScraperID:   path,
+			ScraperData: "",
+			ScraperLink: "",
+		},
+	}}, nil
+}
+
+// the tags do not contain external ids
+func (e *Embedded) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return nil, errors.New("unsupported external source: " + string(source))
+}
+
+// returns the season and episode numbers and the episode data embedded in a video file
+func (e *Embedded) GetTVSEpisodeFromFile(path string) (common.TVSEpisodeData, error) {
+	tags, err := readTags(path)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+	if tags.Season < 0 || tags.Episode < 0 {
+		return common.TVSEpisodeData{}, errors.New("no episode number in the tags of: " + path)
+	}
+	return e.episodeData(tags), nil
+}
+
+func (e *Embedded) GetTVS() (common.TVSData, error) {
+	index := e.getIndex()
+	if index.Show == "" {
+		return common.TVSData{}, errors.New("no data")
+	}
+
+	// the tvs premiered with its first episode
+	premiered := common.Date{}
+	for _, i := range index.Episodes {
+		if i.Tags.Premiered.IsKnown() && i.Tags.Season > 0 && (!premiered.IsKnown() || i.Tags.Premiered.Before(premiered)) {
+			premiered = i.Tags.Premiered
+		}
+	}
+	return common.TVSData{
+		Title:       index.Show,
+		Trailers:    []common.TrailerData{},
+		Premiered:   premiered,
+		ScraperInfo: e.scraperInfo(),
+	}, nil
+}
+
+// seasons are named from their number, the tags do not describe the seasons
+func (e *Embedded) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	title := "Season " + strconv.Itoa(season)
+	if season == 0 {
+		title = "Specials"
+	}
+	return common.TVSSeasonData{Title: title, ScraperInfo: e.scraperInfo()}, nil
+}
+
+func (e *Embedded) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	file, ok := e.getIndex().Episodes[episodeKey(season, episode)]
+	if !ok {
+		return common.TVSEpisodeData{}, errors.New("no data")
+	}
+	return e.episodeData(file.Tags), nil
+}
+
+// the genres of the episodes are used as the tags of the tvs
+func (e *Embedded) ListTVSTag() ([]common.TagData, error) {
+	ret := []common.TagData{}
+	genres := map[string]bool{}
+	for _, i := range e.getIndex().Episodes {
+		if i.Tags.Genre != "" && !genres[i.Tags.Genre] {
+			genres[i.Tags.Genre] = true
+			ret = append(ret, common.TagData{Name: "genre", Value: i.Tags.Genre})
+		}
+	}
+	return ret, nil
+}
+
+func (e *Embedded) ListTVSCompany() ([]common.CompanyData, error) {
+	return []common.CompanyData{}, nil
+}
+
+func (e *Embedded) ListTVSPerson() ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (e *Embedded) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (e *Embedded) GetTVSUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+func (e *Embedded) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	return []common.EpisodeGroupData{}, nil
+}
@@ -0,0 +1,159 @@
+package fanarttv
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewArtworkProvider() common.ArtworkProvider {
+	p := New()
+	return &p
+}
+
+// artwork types of the api, the hd images are listed before the low resolution ones
+type fanartType struct {
+	Key  string
+	Type common.ArtworkType
+	HD   bool
+}
+
+var tvsTypes = []fanartType{
+	{"hdtvlogo", common.ArtworkClearLogo, true},
+	{"clearlogo", common.ArtworkClearLogo, false},
+	{"hdclearart", common.ArtworkClearArt, true},
+	{"clearart", common.ArtworkClearArt, false},
+	{"tvposter", common.ArtworkPoster, true},
+	{"showbackground", common.ArtworkFanart, true},
+	{"tvbanner", common.ArtworkBanner, true},
+	{"tvthumb", common.ArtworkThumb, true},
+	{"characterart", common.ArtworkCharacter, true},
+	{"seasonposter", common.ArtworkSeasonPoster, true},
+	{"seasonbanner", common.ArtworkSeasonBanner, true},
+	{"seasonthumb", common.ArtworkSeasonThumb, true},
+}
+
+var movieTypes = []fanartType{
+	{"hdmovielogo", common.ArtworkClearLogo, true},
+	{"movielogo", common.ArtworkClearLogo, false},
+	{"hdmovieclearart", common.ArtworkClearArt, true},
+	{"movieart", common.ArtworkClearArt, false},
+	{"movieposter", common.ArtworkPoster, true},
+	{"moviebackground", common.ArtworkFanart, true},
+	{"moviebanner", common.ArtworkBanner, true},
+	{"moviethumb", common.ArtworkThumb, true},
+	{"moviedisc", common.ArtworkDisc, true},
+}
+
+// tvs artworks, only the tvdb ids are supported by the api
+func (f *FanartTV) ListTVSArtwork(ids []common.ExternalID) ([]common.ArtworkData, error) {
+	return f.listArtwork("tv", "series", tvsTypes, supportedIDs(ids, common.ExternalTVDB))
+}
+
+// movie artworks, the api supports the tmdb and imdb ids
+func (f *FanartTV) ListMovieArtwork(ids []common.ExternalID) ([]common.ArtworkData, error) {
+	return f.listArtwork("movies", "movie", movieTypes, supportedIDs(ids, common.ExternalTMDB, common.ExternalIMDB))
+}
+
+// request the artworks of a media, the ids are tried in order until the api knows the media
+func (f *FanartTV) listArtwork(endpoint string, linkType string, types []fanartType, ids []common.ExternalID) ([]common.ArtworkData, error) {
+	if len(ids) == 0 {
+		return []common.ArtworkData{}, errors.New("no supported external id")
+	}
+
+	for _, id := range ids {
+		decode := FanartMedia{}
+		err := f.request(endpoint+"/"+id.ID, &decode)
+		if err == errNotFound {
+			continue
+		}
+		if err != nil {
+			return []common.ArtworkData{}, err
+		}
+		return f.convertArtwork(decode, types, id.ID, f.mediaLink(linkType, id.ID))
+	}
+	return []common.ArtworkData{}, errors.New("no data")
+}
+
+// convert the images of a media, the artworks are grouped by type and season
+// and sorted by language, resolution and number of likes
+func (f *FanartTV) convertArtwork(media FanartMedia, types []fanartType, id string, link string) ([]common.ArtworkData, error) {
+	type item struct {
+		data  common.ArtworkData
+		order int // position of the type of the artwork in the list of types
+		hd    bool
+	}
+
+	// the order of the types is the order of their first appearance in the list
+	typeOrder := map[common.ArtworkType]int{}
+	for _, t := range types {
+		if _, ok := typeOrder[t.Type]; !ok {
+			typeOrder[t.Type] = len(typeOrder)
+		}
+	}
+
+	items := []item{}
+	for _, t := range types {
+		raw, ok := media[t.Key]
+		if !ok {
+			continue
+		}
+		images := []FanartImage{}
+		err := json.Unmarshal(raw, &images)
+		if err != nil {
+			return []common.ArtworkData{}, err
+		}
+		for _, i := range images {
+			if i.URL == "" {
+				continue
+			}
+			likes, _ := strconv.ParseInt(i.Likes, 10, 64)
+			data := common.ArtworkData{
+				Type:     t.Type,
+				URL:      i.URL,
+				Preview:  preview(i.URL),
+				Language: language(i.Lang),
+				Season:   -1,
+				Disc:     i.DiscType,
+				Likes:    likes,
+				ScraperInfo: common.ScraperInfo{
+					ScraperName: f.ScraperName,
+					ScraperID:   id,
+					ScraperData: i.ID,
+					ScraperLink: link,
+				},
+			}
+			switch t.Type {
+			case common.ArtworkSeasonPoster, common.ArtworkSeasonBanner, common.ArtworkSeasonThumb:
+				data.Season = season(i.Season)
+			}
+			items = append(items, item{data: data, order: typeOrder[t.Type], hd: t.HD})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.order != b.order {
+			return a.order < b.order
+		}
+		if a.data.Season != b.data.Season {
+			return a.data.Season < b.data.Season
+		}
+		if la, lb := f.languageScore(a.data.Language), f.languageScore(b.data.Language); la != lb {
+			return la < lb
+		}
+		if a.hd != b.hd {
+			return a.hd
+		}
+		return a.data.Likes > b.data.Likes
+	})
+
+	ret := []common.ArtworkData{}
+	for _, i := range items {
+		ret = append(ret, i.data)
+	}
+	return ret, nil
+}
@@ -0,0 +1,24 @@
+package fanarttv
+
+import "encoding/json"
+
+// image returned by the api, all the values are strings
+type FanartImage struct {
+	ID       string `json:"id"`
+	URL      string `json:"url"`
+	Lang     string `json:"lang"` // iso 639-1 code, 00 if the image contains no text
+	Likes    string `json:"likes"`
+	Season   string `json:"season"`    // season artworks only, all if the artwork is used for all the seasons
+	Disc     string `json:"disc"`      // disc artworks only, number of the disc
+	DiscType string `json:"disc_type"` // disc artworks only, ex: bluray, dvd, 3d
+}
+
+// the api returns an object with a list of images for each artwork type (ex: hdtvlogo, clearart)
+// the other keys contain the name and the ids of the media
+type FanartMedia map[string]json.RawMessage
+
+// error returned by the api (ex: unknown media)
+type FanartError struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error message"`
+}
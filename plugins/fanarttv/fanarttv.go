@@ -0,0 +1,164 @@
+package fanarttv
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// error returned when the api has no artwork for a media
+var errNotFound = errors.New("no data")
+
+// provider for the fanart.tv api, tvs are identified by their tvdb id and movies by their tmdb or imdb id
+type FanartTV struct {
+	APIKey      string // key of the project
+	ClientKey   string // optional personal key of the user, gives access to the most recent images
+	APIURL      string // base url of the api, can be changed to use a local server
+	Language    string // preferred language of the images containing text
+	ScraperName string
+	ScraperID   string
+	ScraperData string
+	Logger      *log.Logger
+}
+
+func New() FanartTV {
+	return FanartTV{
+		ScraperName: "fanarttv",
+		APIURL:      "https://webservice.fanart.tv/v3",
+		Language:    "en",
+		Logger:      nil,
+		ScraperID:   "",
+		ScraperData: "",
+	}
+}
+
+// configure the provider's settings
+func (f *FanartTV) Setup(config map[string]string, logger *log.Logger) error {
+	f.Logger = logger
+	if val, ok := config["api_key"]; ok && val != "" {
+		f.APIKey = val
+	} else {
+		return errors.New("empty api key")
+	}
+	if val, ok := config["client_key"]; ok {
+		f.ClientKey = val
+	}
+	if val, ok := config["api_url"]; ok && val != "" {
+		f.APIURL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["language"]; ok && val != "" {
+		f.Language = val
+	}
+	return nil
+}
+
+// the media is identified by the external ids given to each call
+func (f *FanartTV) Configure(ScraperID string, ScraperData string) {
+	f.ScraperID = ScraperID
+	f.ScraperData = ScraperData
+}
+
+// helper to make a request to the api (ex: tv/<tvdb id>)
+// the api returns a 404 status code if it has no artwork for the media
+func (f *FanartTV) request(link string, v interface{}) error {
+	errFields := log.Fields{
+		"file":     "fanarttv",
+		"function": "request",
+	}
+
+	params := url.Values{"api_key": {f.APIKey}}
+	if f.ClientKey != "" {
+		params.Set("client_key", f.ClientKey)
+	}
+	u := f.APIURL + "/" + link + "?" + params.Encode()
+	resp, err := http.Get(u)
+	if err != nil {
+		f.Logger.WithFields(errFields).Errorf("request error: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		f.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return errNotFound
+	}
+	if resp.StatusCode != 200 {
+		f.Logger.WithFields(errFields).Infof("requested url: %s", link)
+		decode := FanartError{}
+		if json.Unmarshal(data, &decode) == nil && decode.ErrorMessage != "" {
+			f.Logger.WithFields(errFields).Errorf("request error: %s", decode.ErrorMessage)
+			return errors.New(decode.ErrorMessage)
+		}
+		f.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+		return errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+	return json.Unmarshal(data, v)
+}
+
+// helpers
+
+// returns the url of the preview of an image (ex: https://assets.fanart.tv/preview/tv/...)
+func preview(link string) string {
+	if !strings.Contains(link, "/fanart/") {
+		return ""
+	}
+	return strings.Replace(link, "/fanart/", "/preview/", 1)
+}
+
+// returns the language of an image, 00 is used for the images without text
+func language(lang string) string {
+	if lang == "00" {
+		return ""
+	}
+	return lang
+}
+
+// the seasons are numbers or all for the artworks used for all the seasons
+func season(val string) int {
+	n, err := strconv.Atoi(val)
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// score of the language of an image, lower is better: preferred language, no text, english, other languages
+func (f *FanartTV) languageScore(lang string) int {
+	switch {
+	case lang == f.Language:
+		return 0
+	case lang == "":
+		return 1
+	case lang == "en":
+		return 2
+	}
+	return 3
+}
+
+func (f *FanartTV) mediaLink(mediaType string, id string) string {
+	return "https://fanart.tv/" + mediaType + "/" + id + "/"
+}
+
+// returns the ids of the supported sources, in the order of the given list
+func supportedIDs(ids []common.ExternalID, sources ...common.ExternalSource) []common.ExternalID {
+	ret := []common.ExternalID{}
+	for _, i := range ids {
+		for _, s := range sources {
+			if i.Source == s && i.ID != "" {
+				ret = append(ret, i)
+			}
+		}
+	}
+	return ret
+}
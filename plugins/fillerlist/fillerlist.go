@@ -0,0 +1,225 @@
+package fillerlist
+
+import (
+	"errors"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// provider parsing the pages of an anime filler list (animefillerlist.com)
+// the pages can be requested from the website, from a stand-in server, or read from a local copy:
+// a local copy contains the list of shows in shows.html and the page of each show in shows/<show>.html
+// ScraperID is the identifier of the show in the urls (ex: naruto-shippuden), the shows are found with SearchFiller
+type FillerList struct {
+	URL         string // base url of the website, used if Path is empty
+	Path        string // folder of a local copy of the website
+	ScraperName string
+	ScraperID   string
+	ScraperData string
+	Logger      *log.Logger
+	shows       []common.SearchData // cached list of shows
+	cacheLock   *sync.Mutex
+}
+
+func NewFillerProvider() common.FillerProvider {
+	p := New()
+	return &p
+}
+
+func New() FillerList {
+	return FillerList{
+		ScraperName: "fillerlist",
+		URL:         "https://www.animefillerlist.com",
+		Logger:      nil,
+		ScraperID:   "",
+		ScraperData: "",
+		cacheLock:   &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (f *FillerList) Setup(config map[string]string, logger *log.Logger) error {
+	f.Logger = logger
+	if val, ok := config["url"]; ok && val != "" {
+		f.URL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["path"]; ok {
+		f.Path = val
+	}
+	return nil
+}
+
+func (f *FillerList) Configure(ScraperID string, ScraperData string) {
+	f.ScraperID = ScraperID
+	f.ScraperData = ScraperData
+}
+
+// helper to get a page (ex: shows/naruto) from the local copy or the website
+func (f *FillerList) request(page string) (string, error) {
+	errFields := log.Fields{
+		"file":     "fillerlist",
+		"function": "request",
+	}
+
+	if f.Path != "" {
+		data, err := os.ReadFile(filepath.Join(f.Path, filepath.FromSlash(page)+".html"))
+		if err != nil {
+			f.Logger.WithFields(errFields).Errorf("read error: %v", err)
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	u := f.URL + "/" + page
+	resp, err := http.Get(u)
+	if err != nil {
+		f.Logger.WithFields(errFields).Errorf("request error: %v", err)
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		f.Logger.WithFields(errFields).Infof("requested url: %s", u)
+		f.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+		return "", errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		f.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+		return "", err
+	}
+	return string(data), nil
+}
+
+var (
+	showLinkReg = regexp.MustCompile(`<a href="(?:https?://[^"]*?)?/shows/([^"/?#]+)"[^>]*>([^<]+)</a>`) // links of the list of shows, relative or absolute
+	rowReg      = regexp.MustCompile(`(?s)<tr[^>]*class="([^"]*)"[^>]*>(.*?)</tr>`)
+	numberReg   = regexp.MustCompile(`<td[^>]*class="Number"[^>]*>\s*(\d+)\s*</td>`)
+)
+
+// returns the list of the shows, it is requested only once and then cached
+func (f *FillerList) listShows() ([]common.SearchData, error) {
+	f.cacheLock.Lock()
+	defer f.cacheLock.Unlock()
+
+	if f.shows != nil {
+		return f.shows, nil
+	}
+
+	page, err := f.request("shows")
+	if err != nil {
+		return nil, err
+	}
+
+	shows := []common.SearchData{}
+	known := map[string]bool{}
+	for _, i := range showLinkReg.FindAllStringSubmatch(page, -1) {
+		if known[i[1]] {
+			continue
+		}
+		known[i[1]] = true
+		shows = append(shows, common.SearchData{
+			Title: strings.TrimSpace(html.UnescapeString(i[2])),
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: f.ScraperName,
+				ScraperID:   i[1],
+				ScraperData: "",
+				ScraperLink: f.URL + "/shows/" + i[1],
+			},
+		})
+	}
+	if len(shows) == 0 {
+		return nil, errors.New("no show found in the list of shows")
+	}
+
+	f.shows = shows
+	return f.shows, nil
+}
+
+// search a show by name, all the shows with a title containing the name are returned,
+// the shows whose title is the name are listed first
+func (f *FillerList) SearchFiller(name string) ([]common.SearchData, error) {
+	search := normalize(name)
+	if search == "" {
+		return nil, errors.New("empty search name")
+	}
+	shows, err := f.listShows()
+	if err != nil {
+		return nil, err
+	}
+
+	exact := make([]common.SearchData, 0)
+	ret := make([]common.SearchData, 0)
+	for _, i := range shows {
+		title := normalize(i.Title)
+		if title == search {
+			exact = append(exact, i)
+		} else if strings.Contains(title, search) {
+			ret = append(ret, i)
+		}
+	}
+	return append(exact, ret...), nil
+}
+
+// list the type of each episode of the show, from the rows of the episode list
+// ex: <tr class="filler odd"><td class="Number">26</td>...<td class="Type"><span>Filler</span></td>...</tr>
+func (f *FillerList) ListFiller() ([]common.FillerData, error) {
+	page, err := f.request("shows/" + f.ScraperID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []common.FillerData{}
+	for _, row := range rowReg.FindAllStringSubmatch(page, -1) {
+		number := numberReg.FindStringSubmatch(row[2])
+		if len(number) < 2 {
+			continue
+		}
+		index, err := strconv.Atoi(number[1])
+		if err != nil {
+			continue
+		}
+		filler, ok := fillerType(row[1])
+		if !ok {
+			continue
+		}
+		ret = append(ret, common.FillerData{Filler: filler, Index: index})
+	}
+	if len(ret) == 0 {
+		return nil, errors.New("no episode found for show: " + f.ScraperID)
+	}
+	return ret, nil
+}
+
+// convert the class of a row of the episode list
+func fillerType(class string) (common.FillerType, bool) {
+	for _, i := range strings.Fields(class) {
+		switch i {
+		case "manga_canon":
+			return common.Canon, true
+		case "anime_canon":
+			return common.Adaptation, true
+		case "mixed_canon/filler", "mixed_canon", "mixed":
+			return common.Mixed, true
+		case "filler":
+			return common.Filler, true
+		}
+	}
+	return common.Canon, false
+}
+
+var nonAlnumReg = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// lowercase a name and keep only its letters and digits
+func normalize(name string) string {
+	return nonAlnumReg.ReplaceAllString(strings.ToLower(name), "")
+}
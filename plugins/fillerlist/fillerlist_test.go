@@ -0,0 +1,125 @@
+package fillerlist
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// pages of the website, by path without the .html extension
+var pages = map[string]string{
+	"shows": `<ul>
+		<li><a href="/shows/naruto">Naruto</a></li>
+		<li><a href="https://www.animefillerlist.com/shows/naruto-shippuden">Naruto Shippuden</a></li>
+		<li><a href="/shows/naruto">Naruto</a></li>
+		<li><a href="/shows/boruto">Boruto: Naruto Next Generations</a></li>
+		<li><a href="/shows/one-piece">One Piece</a></li>
+	</ul>`,
+	"shows/naruto": `<table class="EpisodeList"><tbody>
+		<tr class="manga_canon odd"><td class="Number">1</td><td class="Title">Enter: Naruto Uzumaki!</td></tr>
+		<tr class="mixed_canon/filler even"><td class="Number">2</td><td class="Title">My Name is Konohamaru!</td></tr>
+		<tr class="anime_canon odd"><td class="Number">3</td><td class="Title">Sasuke and Sakura</td></tr>
+		<tr class="filler even"><td class="Number"> 4 </td><td class="Title">Pass or Fail</td></tr>
+		<tr class="unknown odd"><td class="Number">5</td></tr>
+		<tr class="filler even"><td class="Title">no number</td></tr>
+	</tbody></table>`,
+}
+
+func newLogger() *log.Logger {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// provider reading a local copy of the website
+func newLocalFillerList(t *testing.T) *FillerList {
+	dir := t.TempDir()
+	for k, v := range pages {
+		p := filepath.Join(dir, filepath.FromSlash(k)+".html")
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(v), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f := New()
+	f.Setup(map[string]string{"path": dir}, newLogger())
+	return &f
+}
+
+// provider requesting a stand-in server
+func newRemoteFillerList(t *testing.T) *FillerList {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if val, ok := pages[r.URL.Path[1:]]; ok {
+			io.WriteString(w, val)
+			return
+		}
+		w.WriteHeader(404)
+	}))
+	t.Cleanup(server.Close)
+	f := New()
+	f.Setup(map[string]string{"url": server.URL + "/"}, newLogger())
+	return &f
+}
+
+func TestSearchFiller(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []string
+	}{
+		{"Naruto", []string{"naruto", "naruto-shippuden", "boruto"}},
+		{"naruto: shippuden", []string{"naruto-shippuden"}},
+		{"ONE PIECE", []string{"one-piece"}},
+		{"Bleach", []string{}},
+	}
+	for name, f := range map[string]*FillerList{"local": newLocalFillerList(t), "remote": newRemoteFillerList(t)} {
+		for _, i := range tests {
+			res, err := f.SearchFiller(i.name)
+			if err != nil {
+				t.Errorf("%s: SearchFiller(%q) returned %v", name, i.name, err)
+				continue
+			}
+			ids := []string{}
+			for _, r := range res {
+				ids = append(ids, r.ScraperID)
+			}
+			if !reflect.DeepEqual(ids, i.ids) {
+				t.Errorf("%s: SearchFiller(%q) = %v, want %v", name, i.name, ids, i.ids)
+			}
+		}
+		if _, err := f.SearchFiller(" - "); err == nil {
+			t.Errorf("%s: SearchFiller(empty name) returned no error", name)
+		}
+	}
+}
+
+func TestListFiller(t *testing.T) {
+	want := []common.FillerData{
+		{Filler: common.Canon, Index: 1},
+		{Filler: common.Mixed, Index: 2},
+		{Filler: common.Adaptation, Index: 3},
+		{Filler: common.Filler, Index: 4},
+	}
+	for name, f := range map[string]*FillerList{"local": newLocalFillerList(t), "remote": newRemoteFillerList(t)} {
+		f.Configure("naruto", "")
+		list, err := f.ListFiller()
+		if err != nil {
+			t.Errorf("%s: ListFiller() returned %v", name, err)
+		} else if !reflect.DeepEqual(list, want) {
+			t.Errorf("%s: ListFiller() = %v, want %v", name, list, want)
+		}
+
+		f.Configure("one-piece", "")
+		if _, err := f.ListFiller(); err == nil {
+			t.Errorf("%s: ListFiller(unknown show) returned no error", name)
+		}
+	}
+}
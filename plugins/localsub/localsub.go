@@ -0,0 +1,251 @@
+package localsub
+
+import (
+	"archive/zip"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// extensions of the indexed subtitle files
+var subtitleFormats = []string{"srt", "ass", "ssa"}
+
+var (
+	hashReg    = regexp.MustCompile(`(?i)(?:^|[^0-9a-f])([0-9a-f]{16})(?:$|[^0-9a-f])`) // opensubtitles hash of the video file
+	imdbReg    = regexp.MustCompile(`\b(tt\d{7,})\b`)
+	episodeReg = regexp.MustCompile(`(?i)\bs(\d{1,3})[ ._-]?e(\d{1,4})\b|\b(\d{1,2})x(\d{2,3})\b`) // s01e02 or 1x02
+	wordReg    = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+	yearReg    = regexp.MustCompile(`\(\d{4}\)`)
+)
+
+// provider searching the subtitles stored in local folders, the folders can contain zip archives of subtitles
+// the language, the flags (forced, sdh) and the episode numbers are read from the names of the files and of their folders
+// ex: Show/Season 1/Show.S01E02.720p.en.forced.srt or subs.zip/English/tt1234567.srt
+type LocalSub struct {
+	Paths           []string // folders containing the subtitles
+	DefaultLanguage string   // language of the files without language in their name, empty to ignore these files
+	ScraperName     string
+	ScraperID       string
+	ScraperData     string
+	Logger          *log.Logger
+	index           []SubtitleFile // index of the subtitles, built on the first search
+	cacheLock       *sync.Mutex
+}
+
+func New() LocalSub {
+	return LocalSub{ScraperName: "localsub", Logger: nil, ScraperID: "", ScraperData: "", cacheLock: &sync.Mutex{}}
+}
+
+// configure the provider's settings
+// paths is a list of folders separated by the os path list separator (: on linux)
+func (l *LocalSub) Setup(config map[string]string, logger *log.Logger) error {
+	l.Logger = logger
+	if val, ok := config["paths"]; ok && val != "" {
+		l.Paths = filepath.SplitList(val)
+	} else {
+		return errors.New("no subtitle folder")
+	}
+	if val, ok := config["default_language"]; ok {
+		l.DefaultLanguage = language(val)
+	}
+	return nil
+}
+
+// subtitles are searched from the query given to SearchSubtitle
+func (l *LocalSub) Configure(ScraperID string, ScraperData string) {
+	l.ScraperID = ScraperID
+	l.ScraperData = ScraperData
+}
+
+// returns the index of the subtitles, the folders are only scanned once
+func (l *LocalSub) getIndex() []SubtitleFile {
+	l.cacheLock.Lock()
+	defer l.cacheLock.Unlock()
+
+	if l.index != nil {
+		return l.index
+	}
+
+	errFields := log.Fields{
+		"file":     "localsub",
+		"function": "getIndex",
+	}
+
+	l.index = []SubtitleFile{}
+	for _, root := range l.Paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				l.Logger.WithFields(errFields).Warn(err)
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(root, path)
+			if strings.EqualFold(filepath.Ext(path), ".zip") {
+				l.indexArchive(path, rel)
+				return nil
+			}
+			if sub, ok := l.parseName(filepath.ToSlash(rel)); ok {
+				sub.Path = path
+				l.index = append(l.index, sub)
+			}
+			return nil
+		})
+		if err != nil {
+			l.Logger.WithFields(errFields).Warn(err)
+		}
+	}
+	l.Logger.WithFields(errFields).Debugf("indexed subtitles: %d", len(l.index))
+	return l.index
+}
+
+// add the subtitles of a zip archive to the index, the name of the archive is used as a folder
+func (l *LocalSub) indexArchive(path string, rel string) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		l.Logger.WithFields(log.Fields{"file": "localsub", "function": "indexArchive"}).Warnf("%s: %v", path, err)
+		return
+	}
+	defer r.Close()
+
+	folder := strings.TrimSuffix(filepath.ToSlash(rel), filepath.Ext(rel))
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if sub, ok := l.parseName(folder + "/" + f.Name); ok {
+			sub.Path = path
+			sub.Entry = f.Name
+			l.index = append(l.index, sub)
+		}
+	}
+}
+
+// parse the path of a subtitle relative to its root folder
+// the language and the flags are read from the last parts of the file name (ex: name.en.forced.srt),
+// or from the name of a parent folder (ex: English/name.srt)
+func (l *LocalSub) parseName(rel string) (SubtitleFile, bool) {
+	name := rel[strings.LastIndex(rel, "/")+1:]
+	dir := ""
+	if i := strings.LastIndex(rel, "/"); i >= 0 {
+		dir = rel[:i]
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+	if !contains(subtitleFormats, ext) {
+		return SubtitleFile{}, false
+	}
+	sub := SubtitleFile{Format: ext, Season: -1, Episode: -1}
+
+	parts := strings.Split(strings.TrimSuffix(name, filepath.Ext(name)), ".")
+	for len(parts) > 1 {
+		last := strings.ToLower(parts[len(parts)-1])
+		if last == "forced" {
+			sub.Forced = true
+		} else if last == "sdh" || last == "hi" || last == "cc" {
+			sub.HearingImpaired = true
+		} else if lang := language(last); lang != "" && sub.Language == "" {
+			sub.Language = lang
+		} else {
+			break
+		}
+		parts = parts[:len(parts)-1]
+	}
+	sub.Name = normalize(strings.Join(parts, "."))
+
+	if sub.Language == "" {
+		folders := strings.Split(dir, "/")
+		for i := len(folders) - 1; i >= 0 && sub.Language == ""; i-- {
+			sub.Language = language(folders[i])
+		}
+	}
+	if sub.Language == "" {
+		sub.Language = l.DefaultLanguage
+	}
+	if sub.Language == "" {
+		return SubtitleFile{}, false
+	}
+
+	sub.Folder = normalize(dir)
+	if match := hashReg.FindStringSubmatch(name); len(match) > 1 {
+		sub.Hash = strings.ToLower(match[1])
+	}
+	if match := imdbReg.FindStringSubmatch(rel); len(match) > 1 {
+		sub.IMDB = match[1]
+	}
+	if match := episodeReg.FindStringSubmatch(name); len(match) > 4 {
+		if match[1] != "" {
+			sub.Season, _ = strconv.Atoi(match[1])
+			sub.Episode, _ = strconv.Atoi(match[2])
+		} else {
+			sub.Season, _ = strconv.Atoi(match[3])
+			sub.Episode, _ = strconv.Atoi(match[4])
+		}
+	}
+	return sub, true
+}
+
+// helpers
+
+// iso 639-1 code, iso 639-2 codes and english name of the supported languages
+var languages = [][]string{
+	{"en", "eng", "english"},
+	{"fr", "fre", "fra", "french"},
+	{"de", "ger", "deu", "german"},
+	{"es", "spa", "spanish"},
+	{"it", "ita", "italian"},
+	{"pt", "por", "portuguese"},
+	{"nl", "dut", "nld", "dutch"},
+	{"sv", "swe", "swedish"},
+	{"no", "nor", "norwegian"},
+	{"da", "dan", "danish"},
+	{"fi", "fin", "finnish"},
+	{"pl", "pol", "polish"},
+	{"cs", "cze", "ces", "czech"},
+	{"hu", "hun", "hungarian"},
+	{"ro", "rum", "ron", "romanian"},
+	{"el", "gre", "ell", "greek"},
+	{"tr", "tur", "turkish"},
+	{"ru", "rus", "russian"},
+	{"uk", "ukr", "ukrainian"},
+	{"ar", "ara", "arabic"},
+	{"he", "heb", "hebrew"},
+	{"hi", "hin", "hindi"},
+	{"ja", "jpn", "japanese"},
+	{"zh", "chi", "zho", "chinese"},
+	{"ko", "kor", "korean"},
+}
+
+// returns the iso 639-1 code of a language code or name, empty if the language is unknown
+// hi is read as the hearing impaired flag in the file names, hindi files must use hin
+func language(val string) string {
+	val = strings.ToLower(strings.TrimSpace(val))
+	for _, i := range languages {
+		if contains(i, val) {
+			return i[0]
+		}
+	}
+	return ""
+}
+
+// lowercase words separated by a single space
+func normalize(val string) string {
+	words := wordReg.Split(strings.ToLower(val), -1)
+	return strings.TrimSpace(strings.Join(words, " "))
+}
+
+func contains(list []string, val string) bool {
+	for _, i := range list {
+		if i == val {
+			return true
+		}
+	}
+	return false
+}
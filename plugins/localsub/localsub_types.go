@@ -0,0 +1,17 @@
+package localsub
+
+// subtitle found in the subtitle folders
+type SubtitleFile struct {
+	Path            string // path of the subtitle file, or of the zip archive containing it
+	Entry           string // name of the file in the zip archive, empty for the regular files
+	Name            string // normalized name of the file, without the language, the flags and the extension
+	Folder          string // normalized path of the parent folders, relative to the subtitle folder
+	Language        string
+	Format          string
+	Forced          bool
+	HearingImpaired bool
+	Hash            string // video hash contained in the name of the file
+	IMDB            string // imdb id contained in the name of the file or of a parent folder
+	Season          int    // -1 if the name does not contain an episode number
+	Episode         int
+}
@@ -0,0 +1,133 @@
+package localsub
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewSubtitleProvider() common.SubtitleProvider {
+	p := New()
+	return &p
+}
+
+// search the indexed subtitles matching the video file, the imdb id or the title and episode number of the query
+func (l *LocalSub) SearchSubtitle(query common.SubtitleQuery) ([]common.SubtitleData, error) {
+	fileName := normalize(strings.TrimSuffix(query.FileName, filepath.Ext(query.FileName)))
+	title := normalize(yearReg.ReplaceAllString(query.Title, ""))
+	hash := strings.ToLower(query.Hash)
+
+	type result struct {
+		data     common.SubtitleData
+		language int // position of the language in the preferred languages
+	}
+	results := []result{}
+	for _, i := range l.getIndex() {
+		lang := len(query.Languages)
+		for n, j := range query.Languages {
+			if language(j) == i.Language {
+				lang = n
+				break
+			}
+		}
+		if len(query.Languages) > 0 && lang == len(query.Languages) {
+			continue
+		}
+
+		sameEpisode := query.Season < 0 || (i.Season == query.Season && i.Episode == query.Episode)
+		var match common.SubtitleMatch
+		switch {
+		case hash != "" && i.Hash == hash:
+			match = common.SubtitleMatchHash
+		case fileName != "" && i.Name == fileName:
+			match = common.SubtitleMatchFileName
+		case query.IMDB != "" && i.IMDB == query.IMDB && sameEpisode:
+			match = common.SubtitleMatchIMDB
+		case query.Season >= 0 && title != "" && sameEpisode && strings.Contains(" "+i.Folder+" "+i.Name+" ", " "+title+" "):
+			match = common.SubtitleMatchEpisode
+		default:
+			continue
+		}
+
+		results = append(results, result{
+			data: common.SubtitleData{
+				Language:        i.Language,
+				Format:          i.Format,
+				Match:           match,
+				HearingImpaired: i.HearingImpaired,
+				Forced:          i.Forced,
+				ScraperInfo: common.ScraperInfo{
+					ScraperName: l.ScraperName,
+					ScraperID:   i.Path,
+					ScraperData: i.Entry,
+					ScraperLink: "",
+				},
+			},
+			language: lang,
+		})
+	}
+
+	// best match first, then by language preference, the full subtitles are preferred to the forced ones
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.data.Match != b.data.Match {
+			return a.data.Match < b.data.Match
+		}
+		if a.language != b.language {
+			return a.language < b.language
+		}
+		if a.data.Forced != b.data.Forced {
+			return !a.data.Forced
+		}
+		return !a.data.HearingImpaired && b.data.HearingImpaired
+	})
+
+	ret := []common.SubtitleData{}
+	for _, i := range results {
+		ret = append(ret, i.data)
+	}
+	return ret, nil
+}
+
+// returns the content of an indexed subtitle, read from its file or from its zip archive
+func (l *LocalSub) DownloadSubtitle(sub common.SubtitleData) ([]byte, error) {
+	// only the indexed files can be read
+	found := false
+	for _, i := range l.getIndex() {
+		if i.Path == sub.ScraperID && i.Entry == sub.ScraperData {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.New("unknown subtitle: " + sub.ScraperID)
+	}
+
+	if sub.ScraperData == "" {
+		return os.ReadFile(sub.ScraperID)
+	}
+
+	r, err := zip.OpenReader(sub.ScraperID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if f.Name != sub.ScraperData {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, errors.New("subtitle not found in archive: " + sub.ScraperData)
+}
@@ -0,0 +1,180 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// names of the metadata files, by order of preference
+var metadataFiles = []string{"metadata.yaml", "metadata.yml", "metadata.json"}
+
+// provider reading the metadata files written by the user in the tvs, season and movie folders
+// the files override the fields of the remote providers, or fully describe a media if standalone is set
+// ScraperID is the path of the tvs or movie folder
+type Metadata struct {
+	ScraperName string
+	ScraperID   string
+	ScraperData string
+	Logger      *log.Logger
+	tvs         *common.TVSOverrideData // data of the last requested tvs
+	tvsPath     string                  // path of the cached tvs
+	cacheLock   *sync.Mutex
+}
+
+func New() Metadata {
+	return Metadata{ScraperName: "metadata", Logger: nil, ScraperID: "", ScraperData: "", cacheLock: &sync.Mutex{}}
+}
+
+// configure the provider's settings
+func (m *Metadata) Setup(config map[string]string, logger *log.Logger) error {
+	m.Logger = logger
+	return nil
+}
+
+func (m *Metadata) Configure(ScraperID string, ScraperData string) {
+	m.ScraperID = ScraperID
+	m.ScraperData = ScraperData
+}
+
+// read the metadata file stored in a folder
+func readMetadata(dir string) (MetadataFile, error) {
+	for _, name := range metadataFiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+
+		var tree interface{}
+		if strings.HasSuffix(name, ".json") {
+			dec := json.NewDecoder(strings.NewReader(string(data)))
+			dec.UseNumber()
+			err = dec.Decode(&tree)
+		} else {
+			tree, err = parseYAML(string(data))
+		}
+		if err != nil {
+			return MetadataFile{}, errors.New(filepath.Join(dir, name) + ": " + err.Error())
+		}
+
+		// the values are converted to strings, so that yaml and json files are decoded the same way
+		raw, err := json.Marshal(stringify(tree))
+		if err != nil {
+			return MetadataFile{}, err
+		}
+		file := MetadataFile{}
+		err = json.Unmarshal(raw, &file)
+		if err != nil {
+			return MetadataFile{}, errors.New(filepath.Join(dir, name) + ": " + err.Error())
+		}
+		return file, nil
+	}
+	return MetadataFile{}, errors.New("no metadata file in: " + dir)
+}
+
+// convert the scalars of a decoded document to strings
+func stringify(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, i := range val {
+			val[k] = stringify(i)
+		}
+		return val
+	case []interface{}:
+		for k, i := range val {
+			val[k] = stringify(i)
+		}
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	case nil:
+		return ""
+	}
+	return v
+}
+
+// helpers
+
+var seasonDirReg = regexp.MustCompile(`(?i)^(?:season|saison|staffel|s)[ ._-]*(\d+)$`)
+
+// returns the season number from the name of a season folder (ex: Season 01, Specials)
+func seasonFromDir(name string) (int, bool) {
+	if strings.EqualFold(name, "specials") {
+		return 0, true
+	}
+	match := seasonDirReg.FindStringSubmatch(name)
+	if len(match) > 1 {
+		num, err := strconv.Atoi(match[1])
+		return num, err == nil
+	}
+	return 0, false
+}
+
+// returns the url of an artwork, relative paths are resolved from dir
+func imagePath(dir string, link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+	if u, err := url.Parse(link); err == nil && u.Scheme != "" && len(u.Scheme) > 1 {
+		return link
+	}
+	if filepath.IsAbs(link) {
+		return link
+	}
+	return filepath.Join(dir, link)
+}
+
+func rating(val string) int64 {
+	r, _ := strconv.ParseFloat(strings.TrimSpace(val), 64)
+	return int64(r)
+}
+
+func trailers(link string) []common.TrailerData {
+	if link == "" {
+		return []common.TrailerData{}
+	}
+	return []common.TrailerData{{Link: link, Type: "trailer"}}
+}
+
+// convert the tags of a file, each tag name has a value or a list of values (ex: genre: [Documentary, Family])
+func tags(values map[string]interface{}) []common.TagData {
+	names := []string{}
+	for k := range values {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	ret := []common.TagData{}
+	for _, name := range names {
+		switch val := values[name].(type) {
+		case string:
+			if val != "" {
+				ret = append(ret, common.TagData{Name: name, Value: val})
+			}
+		case []interface{}:
+			for _, i := range val {
+				if s, ok := i.(string); ok && s != "" {
+					ret = append(ret, common.TagData{Name: name, Value: s})
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func isStandalone(file MetadataFile) bool {
+	return strings.EqualFold(strings.TrimSpace(file.Standalone), "true")
+}
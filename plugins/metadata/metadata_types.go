@@ -0,0 +1,40 @@
+package metadata
+
+// content of a metadata file, all the values are read as strings
+// a file describes a tvs, a season (in a season folder) or a movie
+type MetadataFile struct {
+	Standalone string                    `json:"standalone"` // true if the media has no entry in the remote providers
+	Title      string                    `json:"title"`
+	Overview   string                    `json:"overview"`
+	Icon       string                    `json:"icon"` // path relative to the folder of the file, or url
+	Fanart     string                    `json:"fanart"`
+	Website    string                    `json:"website"`
+	Trailer    string                    `json:"trailer"`
+	Premiered  string                    `json:"premiered"`
+	Rating     string                    `json:"rating"`
+	Tags       map[string]interface{}    `json:"tags"`   // tag name -> value or list of values
+	Season     string                    `json:"season"` // number of the season described by a file stored in a season folder
+	Seasons    map[string]MetadataSeason `json:"seasons"`
+	Episodes   []MetadataEpisode         `json:"episodes"`
+}
+
+type MetadataSeason struct {
+	Title     string            `json:"title"`
+	Overview  string            `json:"overview"`
+	Icon      string            `json:"icon"`
+	Fanart    string            `json:"fanart"`
+	Trailer   string            `json:"trailer"`
+	Premiered string            `json:"premiered"`
+	Rating    string            `json:"rating"`
+	Episodes  []MetadataEpisode `json:"episodes"`
+}
+
+type MetadataEpisode struct {
+	Season    string `json:"season"` // can be omitted in the episodes of a season
+	Episode   string `json:"episode"`
+	Title     string `json:"title"`
+	Overview  string `json:"overview"`
+	Icon      string `json:"icon"`
+	Premiered string `json:"premiered"`
+	Rating    string `json:"rating"`
+}
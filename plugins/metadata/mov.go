@@ -0,0 +1,129 @@
+package metadata
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewMovieProvider() common.MovieProvider {
+	p := New()
+	return &p
+}
+
+// returns the folder containing the metadata file of a movie from the path of the movie file or folder
+func movieDir(path string) string {
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return filepath.Dir(path)
+	}
+	return path
+}
+
+func (m *Metadata) readMovie(path string) (common.MovieOverrideData, bool, error) {
+	dir := movieDir(path)
+	file, err := readMetadata(dir)
+	if err != nil {
+		return common.MovieOverrideData{}, false, err
+	}
+	return common.MovieOverrideData{
+		Movie: common.MovieData{
+			Title:     file.Title,
+			Overview:  strings.TrimSpace(file.Overview),
+			Icon:      imagePath(dir, file.Icon),
+			Fanart:    imagePath(dir, file.Fanart),
+			Website:   file.Website,
+			Trailer:   file.Trailer,
+			Trailers:  trailers(file.Trailer),
+			Premiered: common.ParseDate(file.Premiered),
+			Rating:    rating(file.Rating),
+		},
+		Tags: tags(file.Tags),
+	}, isStandalone(file), nil
+}
+
+// returns the overrides written by the user for the movie stored in path
+func (m *Metadata) GetMovieOverride(path string) (common.MovieOverrideData, error) {
+	data, _, err := m.readMovie(path)
+	if err != nil && strings.HasPrefix(err.Error(), "no metadata file") {
+		return data, common.ErrNoOverride
+	}
+	return data, err
+}
+
+// movie search, metadata files can only be found from the path of the movie
+func (m *Metadata) SearchMovie(name string, year int) ([]common.SearchData, error) {
+	return nil, errors.New("the metadata provider requires the path of the movie")
+}
+
+// movie search from the metadata file stored in the movie folder
+// only the movies marked as standalone are returned, the other files are only used as overrides
+func (m *Metadata) SearchMovieFromPath(path string) ([]common.SearchData, error) {
+	data, standalone, err := m.readMovie(path)
+	if err != nil {
+		return nil, err
+	}
+	if !standalone {
+		return []common.SearchData{}, nil
+	}
+	title := data.Movie.Title
+	if title == "" {
+		title = filepath.Base(path)
+	}
+	return []common.SearchData{{
+		Title:     title,
+		Overview:  data.Movie.Overview,
+		Icon:      data.Movie.Icon,
+		Premiered: data.Movie.Premiered,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: m.ScraperName,
+			ScraperID:   path,
+			ScraperData: "",
+			ScraperLink: "",
+		},
+	}}, nil
+}
+
+// metadata files do not contain external ids
+func (m *Metadata) FindMovieByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return nil, errors.New("unsupported external source: " + string(source))
+}
+
+func (m *Metadata) GetMovie() (common.MovieData, error) {
+	data, _, err := m.readMovie(m.ScraperID)
+	if err != nil {
+		return common.MovieData{}, err
+	}
+	ret := data.Movie
+	if ret.Title == "" {
+		ret.Title = strings.TrimSuffix(filepath.Base(m.ScraperID), filepath.Ext(m.ScraperID))
+	}
+	ret.ScraperInfo = m.scraperInfo()
+	return ret, nil
+}
+
+func (m *Metadata) ListMovieTag() ([]common.TagData, error) {
+	data, _, err := m.readMovie(m.ScraperID)
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return data.Tags, nil
+}
+
+func (m *Metadata) ListMovieCompany() ([]common.CompanyData, error) {
+	return []common.CompanyData{}, nil
+}
+
+func (m *Metadata) ListMoviePerson() ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (m *Metadata) GetMovieUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+func (m *Metadata) GetMovieCollection() (common.MovieCollectionData, error) {
+	return common.MovieCollectionData{}, errors.New("no data")
+}
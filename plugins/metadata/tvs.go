@@ -0,0 +1,307 @@
+package metadata
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+func (m *Metadata) episodeData(dir string, season int, e MetadataEpisode) (common.TVSEpisodeData, error) {
+	if e.Season != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(e.Season))
+		if err != nil {
+			return common.TVSEpisodeData{}, errors.New("invalid season number: " + e.Season)
+		}
+		season = n
+	}
+	episode, err := strconv.Atoi(strings.TrimSpace(e.Episode))
+	if err != nil || season < 0 {
+		return common.TVSEpisodeData{}, errors.New("invalid episode number: " + e.Episode)
+	}
+	return common.TVSEpisodeData{
+		Title:     e.Title,
+		Overview:  strings.TrimSpace(e.Overview),
+		Icon:      imagePath(dir, e.Icon),
+		Premiered: common.ParseDate(e.Premiered),
+		Rating:    rating(e.Rating),
+		Season:    int64(season),
+		Episode:   int64(episode),
+	}, nil
+}
+
+func (m *Metadata) seasonData(dir string, s MetadataSeason) common.TVSSeasonData {
+	return common.TVSSeasonData{
+		Title:     s.Title,
+		Overview:  strings.TrimSpace(s.Overview),
+		Icon:      imagePath(dir, s.Icon),
+		Fanart:    imagePath(dir, s.Fanart),
+		Trailer:   s.Trailer,
+		Premiered: common.ParseDate(s.Premiered),
+		Rating:    rating(s.Rating),
+	}
+}
+
+// read the metadata files of a tvs: the file of the tvs folder and the files of its season folders
+// the seasons and episodes of a season folder override the ones described in the file of the tvs folder
+func (m *Metadata) readTVS(path string) (common.TVSOverrideData, bool, error) {
+	data := common.TVSOverrideData{Seasons: map[int]common.TVSSeasonData{}, Episodes: []common.TVSEpisodeData{}, Tags: []common.TagData{}}
+	episodes := map[string]int{} // key: season-episode, value: index in data.Episodes
+	addEpisode := func(e common.TVSEpisodeData) {
+		key := strconv.FormatInt(e.Season, 10) + "-" + strconv.FormatInt(e.Episode, 10)
+		if i, ok := episodes[key]; ok {
+			data.Episodes[i] = e
+			return
+		}
+		episodes[key] = len(data.Episodes)
+		data.Episodes = append(data.Episodes, e)
+	}
+
+	found := false
+	standalone := false
+	show, err := readMetadata(path)
+	if err == nil {
+		found = true
+		standalone = isStandalone(show)
+		data.TVS = common.TVSData{
+			Title:     show.Title,
+			Overview:  strings.TrimSpace(show.Overview),
+			Icon:      imagePath(path, show.Icon),
+			Fanart:    imagePath(path, show.Fanart),
+			Website:   show.Website,
+			Trailer:   show.Trailer,
+			Trailers:  trailers(show.Trailer),
+			Premiered: common.ParseDate(show.Premiered),
+			Rating:    rating(show.Rating),
+		}
+		data.Tags = tags(show.Tags)
+		for k, s := range show.Seasons {
+			season, err := strconv.Atoi(strings.TrimSpace(k))
+			if err != nil {
+				return data, false, errors.New("invalid season number: " + k)
+			}
+			data.Seasons[season] = m.seasonData(path, s)
+			for _, i := range s.Episodes {
+				e, err := m.episodeData(path, season, i)
+				if err != nil {
+					return data, false, err
+				}
+				addEpisode(e)
+			}
+		}
+		for _, i := range show.Episodes {
+			if i.Season == "" {
+				return data, false, errors.New("missing season number for episode: " + i.Episode)
+			}
+			e, err := m.episodeData(path, 0, i)
+			if err != nil {
+				return data, false, err
+			}
+			addEpisode(e)
+		}
+	} else if !strings.HasPrefix(err.Error(), "no metadata file") {
+		return data, false, err
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return data, false, err
+	}
+	for _, d := range entries {
+		if !d.IsDir() {
+			continue
+		}
+		dir := filepath.Join(path, d.Name())
+		file, err := readMetadata(dir)
+		if err != nil {
+			if !strings.HasPrefix(err.Error(), "no metadata file") {
+				return data, false, err
+			}
+			continue
+		}
+		season, ok := seasonFromDir(d.Name())
+		if file.Season != "" {
+			season, err = strconv.Atoi(strings.TrimSpace(file.Season))
+			ok = err == nil
+		}
+		if !ok {
+			continue
+		}
+		found = true
+		data.Seasons[season] = m.seasonData(dir, MetadataSeason{
+			Title:     file.Title,
+			Overview:  file.Overview,
+			Icon:      file.Icon,
+			Fanart:    file.Fanart,
+			Trailer:   file.Trailer,
+			Premiered: file.Premiered,
+			Rating:    file.Rating,
+		})
+		for _, i := range file.Episodes {
+			e, err := m.episodeData(dir, season, i)
+			if err != nil {
+				return data, false, err
+			}
+			addEpisode(e)
+		}
+	}
+
+	if !found {
+		return data, false, errors.New("no metadata file in: " + path)
+	}
+	return data, standalone, nil
+}
+
+// returns the overrides written by the user for the tvs stored in path
+func (m *Metadata) GetTVSOverride(path string) (common.TVSOverrideData, error) {
+	data, _, err := m.readTVS(path)
+	if err != nil && strings.HasPrefix(err.Error(), "no metadata file") {
+		return data, common.ErrNoOverride
+	}
+	return data, err
+}
+
+// returns the data of the configured tvs
+// the data is cached as it is used for every season and episode of the tvs
+func (m *Metadata) getTVS() (*common.TVSOverrideData, error) {
+	m.cacheLock.Lock()
+	defer m.cacheLock.Unlock()
+
+	if m.tvs != nil && m.tvsPath == m.ScraperID {
+		return m.tvs, nil
+	}
+
+	data, _, err := m.readTVS(m.ScraperID)
+	if err != nil {
+		return nil, err
+	}
+	m.tvs = &data
+	m.tvsPath = m.ScraperID
+	return m.tvs, nil
+}
+
+// tvs search, metadata files can only be found from the path of the tvs
+func (m *Metadata) SearchTVS(name string) ([]common.SearchData, error) {
+	return nil, errors.New("the metadata provider requires the path of the tvs")
+}
+
+// tvs search from the metadata files stored in the tvs folder
+// only the tvs marked as standalone are returned, the other files are only used as overrides
+func (m *Metadata) SearchTVSFromPath(path string) ([]common.SearchData, error) {
+	data, standalone, err := m.readTVS(path)
+	if err != nil {
+		return nil, err
+	}
+	if !standalone {
+		return []common.SearchData{}, nil
+	}
+	title := data.TVS.Title
+	if title == "" {
+		title = filepath.Base(path)
+	}
+	return []common.SearchData{{
+		Title:     title,
+		Overview:  data.TVS.Overview,
+		Icon:      data.TVS.Icon,
+		Premiered: data.TVS.Premiered,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: m.ScraperName,
+			ScraperID:   path,
+			ScraperData: "",
+			ScraperLink: "",
+		},
+	}}, nil
+}
+
+// metadata files do not contain external ids
+func (m *Metadata) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return nil, errors.New("unsupported external source: " + string(source))
+}
+
+func (m *Metadata) scraperInfo() common.ScraperInfo {
+	return common.ScraperInfo{
+		ScraperName: m.ScraperName,
+		ScraperID:   m.ScraperID,
+		ScraperData: m.ScraperData,
+		ScraperLink: "",
+	}
+}
+
+func (m *Metadata) GetTVS() (common.TVSData, error) {
+	data, err := m.getTVS()
+	if err != nil {
+		return common.TVSData{}, err
+	}
+	ret := data.TVS
+	if ret.Title == "" {
+		ret.Title = filepath.Base(m.ScraperID)
+	}
+	ret.ScraperInfo = m.scraperInfo()
+	return ret, nil
+}
+
+// seasons without metadata are named from their number, as the files describe all the content of a standalone tvs
+func (m *Metadata) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	data, err := m.getTVS()
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+	ret, ok := data.Seasons[season]
+	if !ok || ret.Title == "" {
+		ret.Title = "Season " + strconv.Itoa(season)
+		if season == 0 {
+			ret.Title = "Specials"
+		}
+	}
+	ret.ScraperInfo = m.scraperInfo()
+	return ret, nil
+}
+
+func (m *Metadata) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	data, err := m.getTVS()
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+	ret, ok := data.Episode(season, episode)
+	if !ok {
+		return common.TVSEpisodeData{}, errors.New("no data")
+	}
+	ret.ScraperInfo = m.scraperInfo()
+	return ret, nil
+}
+
+func (m *Metadata) ListTVSTag() ([]common.TagData, error) {
+	data, err := m.getTVS()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return data.Tags, nil
+}
+
+func (m *Metadata) ListTVSCompany() ([]common.CompanyData, error) {
+	return []common.CompanyData{}, nil
+}
+
+func (m *Metadata) ListTVSPerson() ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (m *Metadata) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (m *Metadata) GetTVSUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+func (m *Metadata) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	return []common.EpisodeGroupData{}, nil
+}
@@ -0,0 +1,468 @@
+package metadata
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// minimal yaml parser, supporting the subset of yaml used by the metadata files:
+// block mappings and sequences, plain and quoted scalars (on one or several lines),
+// literal (|) and folded (>) block scalars, flow sequences ([a, b]) and comments
+// scalars are always returned as strings, mappings as map[string]interface{} and sequences as []interface{}
+
+type yamlLine struct {
+	num    int    // line number, used in the errors
+	indent int    // number of leading spaces
+	text   string // line without its indentation
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func parseYAML(data string) (interface{}, error) {
+	p := yamlParser{}
+	data = strings.TrimSuffix(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	for n, i := range strings.Split(data, "\n") {
+		text := strings.TrimLeft(i, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, yamlError(n+1, "tabs are not allowed for indentation")
+		}
+		p.lines = append(p.lines, yamlLine{num: n + 1, indent: len(i) - len(text), text: strings.TrimRight(text, " \t")})
+	}
+
+	p.skipEmpty()
+	if p.pos >= len(p.lines) {
+		return map[string]interface{}{}, nil
+	}
+	if strings.HasPrefix(p.lines[p.pos].text, "---") {
+		p.pos++
+		p.skipEmpty()
+	}
+	if p.pos >= len(p.lines) {
+		return map[string]interface{}{}, nil
+	}
+
+	v, err := p.parseBlock(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+	p.skipEmpty()
+	if p.pos < len(p.lines) {
+		return nil, yamlError(p.lines[p.pos].num, "unexpected content")
+	}
+	return v, nil
+}
+
+func yamlError(line int, msg string) error {
+	return errors.New("yaml: line " + strconv.Itoa(line) + ": " + msg)
+}
+
+// skip the empty lines and the comments
+func (p *yamlParser) skipEmpty() {
+	for p.pos < len(p.lines) && stripComment(p.lines[p.pos].text) == "" {
+		p.pos++
+	}
+}
+
+// removes a comment from a line, # starts a comment at the beginning of a line or after a space, outside of quotes
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if n := quoteEnd(text, quote, i); n >= 0 {
+				i = n
+				quote = 0
+			} else {
+				i = len(text)
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.ContainsRune(" [,:-", rune(text[i-1])) {
+				quote = c
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return strings.TrimRight(text[:i], " ")
+		}
+	}
+	return text
+}
+
+// returns true if the line is an item of a sequence
+func isItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// parse a mapping or a sequence starting at the current line
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	if isItem(stripComment(p.lines[p.pos].text)) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+func (p *yamlParser) parseMapping(indent int) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	for {
+		p.skipEmpty()
+		if p.pos >= len(p.lines) || p.lines[p.pos].indent < indent {
+			return m, nil
+		}
+		line := p.lines[p.pos]
+		if line.indent > indent {
+			return nil, yamlError(line.num, "bad indentation")
+		}
+		text := stripComment(line.text)
+		if isItem(text) {
+			return nil, yamlError(line.num, "unexpected sequence item")
+		}
+		key, rest, ok := splitKey(text)
+		if !ok {
+			return nil, yamlError(line.num, "expected a key")
+		}
+		if _, exists := m[key]; exists {
+			return nil, yamlError(line.num, "duplicate key: "+key)
+		}
+		p.pos++
+		val, err := p.parseValue(rest, indent, line.num, true)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = val
+	}
+}
+
+func (p *yamlParser) parseSequence(indent int) ([]interface{}, error) {
+	list := []interface{}{}
+	for {
+		p.skipEmpty()
+		if p.pos >= len(p.lines) || p.lines[p.pos].indent < indent {
+			return list, nil
+		}
+		line := p.lines[p.pos]
+		text := stripComment(line.text)
+		if line.indent > indent || !isItem(text) {
+			if line.indent == indent {
+				// end of a sequence used as the value of a mapping key at the same indentation
+				return list, nil
+			}
+			return nil, yamlError(line.num, "bad indentation")
+		}
+
+		item := strings.TrimLeft(text[1:], " ")
+		if item == "" {
+			p.pos++
+			val, err := p.parseValue("", indent, line.num, false)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+			continue
+		}
+
+		// the item is a mapping or a sequence (ex: - season: 1), it is parsed as a block starting after the dash
+		if _, _, ok := splitKey(item); ok || isItem(item) {
+			offset := len(line.text) - len(strings.TrimLeft(line.text[1:], " "))
+			p.lines[p.pos] = yamlLine{num: line.num, indent: line.indent + offset, text: strings.TrimLeft(line.text[1:], " ")}
+			val, err := p.parseBlock(line.indent + offset)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+			continue
+		}
+
+		p.pos++
+		val, err := p.parseValue(item, indent, line.num, false)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, val)
+	}
+}
+
+// parse the value of a mapping key or a sequence item, rest is the text following the key or the dash
+// a sequence can be the value of a mapping key at the same indentation
+func (p *yamlParser) parseValue(rest string, indent int, num int, sameIndentSequence bool) (interface{}, error) {
+	switch {
+	case rest == "":
+		p.skipEmpty()
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > indent {
+				// the value is a scalar starting on the next line, or a block
+				text := stripComment(next.text)
+				if _, _, ok := splitKey(text); !ok && !isItem(text) {
+					p.pos++
+					return p.parseValue(text, indent, next.num, false)
+				}
+				return p.parseBlock(next.indent)
+			}
+			if sameIndentSequence && next.indent == indent && isItem(stripComment(next.text)) {
+				return p.parseSequence(indent)
+			}
+		}
+		return "", nil
+	case strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">"):
+		return p.parseBlockScalar(rest, indent, num)
+	case strings.HasPrefix(rest, "["):
+		return parseFlowSequence(rest, num)
+	}
+	return p.parseMultiLineScalar(rest, indent, num)
+}
+
+// parse a plain or quoted scalar, it continues on the following lines which are more indented than the key or the dash
+// the lines are folded: line breaks are replaced by spaces, and empty lines by line breaks
+// a plain scalar ends at a comment, a quoted scalar at its closing quote
+func (p *yamlParser) parseMultiLineScalar(first string, indent int, num int) (string, error) {
+	quote := byte(0)
+	if strings.HasPrefix(first, "\"") || strings.HasPrefix(first, "'") {
+		quote = first[0]
+		if quoteEnd(first, quote, 1) > 0 {
+			return parseScalar(first, num)
+		}
+	}
+	double := quote == '"'
+
+	lines := []string{strings.TrimSpace(first)}
+	breaks := []int{} // number of empty lines before each following line
+	empty := 0
+	for i := p.pos; i < len(p.lines); i++ {
+		line := p.lines[i]
+		if line.text == "" {
+			empty++
+			continue
+		}
+		if line.indent <= indent {
+			break
+		}
+		text := line.text
+		end := false
+		if quote != 0 {
+			if n := quoteEnd(text, quote, 0); n >= 0 {
+				if stripComment(text[n+1:]) != "" {
+					return "", yamlError(line.num, "unexpected content after a quoted string")
+				}
+				text = text[:n+1]
+				quote = 0
+				end = true
+			}
+		} else {
+			text = stripComment(line.text)
+			if text == "" {
+				break
+			}
+			if _, _, ok := splitKey(text); ok {
+				return "", yamlError(line.num, "unexpected key in a multi-line scalar")
+			}
+			// a comment ends the scalar
+			end = text != line.text
+		}
+		lines = append(lines, text)
+		breaks = append(breaks, empty)
+		empty = 0
+		p.pos = i + 1
+		if end {
+			break
+		}
+	}
+	if quote != 0 {
+		return "", yamlError(num, "unterminated quoted string")
+	}
+
+	// the double quoted strings are unquoted as go strings, their line breaks are escaped
+	newline := "\n"
+	if double {
+		newline = "\\n"
+	}
+	b := strings.Builder{}
+	b.WriteString(lines[0])
+	for n, l := range lines[1:] {
+		if breaks[n] > 0 {
+			b.WriteString(strings.Repeat(newline, breaks[n]))
+		} else {
+			b.WriteString(" ")
+		}
+		b.WriteString(l)
+	}
+	return parseScalar(b.String(), num)
+}
+
+// returns the index of the quote closing a quoted text, -1 if the quote is not closed
+// start is the index following the opening quote, or 0 on the next lines of the text
+// in a double quoted text, quotes are escaped with a backslash, in a single quoted text by doubling them
+func quoteEnd(text string, quote byte, start int) int {
+	for i := start; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case quote == '\'' && text[i] == quote && i+1 < len(text) && text[i+1] == quote:
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// parse a literal (|) or folded (>) block scalar, the chomping indicator (- or +) is supported
+func (p *yamlParser) parseBlockScalar(header string, indent int, num int) (string, error) {
+	folded := header[0] == '>'
+	chomp := strings.TrimSpace(header[1:])
+	if chomp != "" && chomp != "-" && chomp != "+" {
+		return "", yamlError(num, "unsupported block scalar header: "+header)
+	}
+
+	lines := []string{}
+	blockIndent := -1
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.text == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+		if line.indent <= indent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = line.indent
+		}
+		if line.indent < blockIndent {
+			break
+		}
+		lines = append(lines, strings.Repeat(" ", line.indent-blockIndent)+line.text)
+		p.pos++
+	}
+
+	// trailing empty lines are part of the following content
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var text string
+	if folded {
+		// lines are joined with spaces, empty lines are line breaks and more indented lines keep their line breaks
+		b := strings.Builder{}
+		for i, l := range lines {
+			if i > 0 {
+				prev := lines[i-1]
+				if l == "" || (prev != "" && (strings.HasPrefix(l, " ") || strings.HasPrefix(prev, " "))) {
+					b.WriteString("\n")
+				} else if prev != "" {
+					b.WriteString(" ")
+				}
+			}
+			b.WriteString(l)
+		}
+		text = b.String()
+	} else {
+		text = strings.Join(lines, "\n")
+	}
+
+	switch chomp {
+	case "-":
+		return text, nil
+	case "+":
+		return text + strings.Repeat("\n", trailing+1), nil
+	}
+	if text == "" {
+		return "", nil
+	}
+	return text + "\n", nil
+}
+
+// split a "key: value" line, the key can be quoted
+func splitKey(text string) (string, string, bool) {
+	if strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'") {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return "", "", false
+		}
+		key, rest := text[1:end+1], strings.TrimLeft(text[end+2:], " ")
+		if !strings.HasPrefix(rest, ":") || (len(rest) > 1 && rest[1] != ' ') {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest[1:]), true
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i == len(text)-1 || text[i+1] == ' ') {
+			key := strings.TrimSpace(text[:i])
+			if key == "" {
+				return "", "", false
+			}
+			return key, strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// parse a plain or quoted scalar, null values are returned as empty strings
+func parseScalar(text string, num int) (string, error) {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(text, "\""):
+		val, err := strconv.Unquote(text)
+		if err != nil {
+			return "", yamlError(num, "invalid double quoted string")
+		}
+		return val, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return "", yamlError(num, "invalid single quoted string")
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case text == "~" || text == "null" || text == "Null" || text == "NULL":
+		return "", nil
+	}
+	return text, nil
+}
+
+// parse a flow sequence of scalars (ex: [Drama, "Sci-Fi"])
+func parseFlowSequence(text string, num int) ([]interface{}, error) {
+	if !strings.HasSuffix(text, "]") {
+		return nil, yamlError(num, "unterminated flow sequence")
+	}
+	list := []interface{}{}
+	content := strings.TrimSpace(text[1 : len(text)-1])
+	if content == "" {
+		return list, nil
+	}
+
+	var quote byte
+	start := 0
+	for i := 0; i <= len(content); i++ {
+		if i < len(content) {
+			c := content[i]
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			if c == '"' || c == '\'' {
+				quote = c
+				continue
+			}
+			if c == '[' || c == '{' {
+				return nil, yamlError(num, "nested flow collections are not supported")
+			}
+			if c != ',' {
+				continue
+			}
+		}
+		val, err := parseScalar(content[start:i], num)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, val)
+		start = i + 1
+	}
+	return list, nil
+}
@@ -0,0 +1,157 @@
+package metadata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAMLScalars(t *testing.T) {
+	tests := []struct {
+		yaml  string
+		value interface{}
+	}{
+		{"title: Show", "Show"},
+		{"title: Show # comment", "Show"},
+		{"title: Show#1", "Show#1"},
+		{"title: \"Show: \\\"The\\\" #1\"", "Show: \"The\" #1"},
+		{"title: 'It''s # not a comment'", "It's # not a comment"},
+		{"title: ~", ""},
+		{"title: null", ""},
+		{"title:", ""},
+		{"title: 2010", "2010"},
+		{"title: https://example.com/a#b", "https://example.com/a#b"},
+		{"\"title\": Show", "Show"},
+		// plain scalars on several lines
+		{"title: A long\n  title", "A long title"},
+		{"title: A long\n  title\n\n  on two lines\nyear: 2010", "A long title\non two lines"},
+		{"title:\n  A long\n    title", "A long title"},
+		{"title: A long\n  title # comment\nyear: 2010", "A long title"},
+		{"title: A long\n\n# comment\nyear: 2010", "A long"},
+		// quoted scalars on several lines
+		{"title: \"A long\n  title\"", "A long title"},
+		{"title: \"A long\n\n  title\" # comment", "A long\ntitle"},
+		{"title: 'It''s a\n  long title'", "It's a long title"},
+		{"title: \"A long\n  \"", "A long "},
+	}
+	for _, i := range tests {
+		v, err := parseYAML(i.yaml)
+		if err != nil {
+			t.Errorf("parseYAML(%q) returned %v", i.yaml, err)
+			continue
+		}
+		if val := v.(map[string]interface{})["title"]; !reflect.DeepEqual(val, i.value) {
+			t.Errorf("parseYAML(%q) title = %q, want %q", i.yaml, val, i.value)
+		}
+	}
+}
+
+func TestParseYAMLBlockScalars(t *testing.T) {
+	tests := []struct {
+		yaml  string
+		value string
+	}{
+		{"overview: |\n  line 1\n  line 2\n", "line 1\nline 2\n"},
+		{"overview: |-\n  line 1\n\n    indented\nyear: 2010", "line 1\n\n  indented"},
+		{"overview: |+\n  line 1\n\n", "line 1\n\n"},
+		{"overview: >\n  line 1\n  line 2\n\n  line 3\n", "line 1 line 2\nline 3\n"},
+		{"overview: >-\n  line 1\n    indented\n  line 2", "line 1\n  indented\nline 2"},
+		{"overview: |\nyear: 2010", ""},
+	}
+	for _, i := range tests {
+		v, err := parseYAML(i.yaml)
+		if err != nil {
+			t.Errorf("parseYAML(%q) returned %v", i.yaml, err)
+			continue
+		}
+		if val := v.(map[string]interface{})["overview"]; val != i.value {
+			t.Errorf("parseYAML(%q) overview = %q, want %q", i.yaml, val, i.value)
+		}
+	}
+}
+
+func TestParseYAMLCollections(t *testing.T) {
+	data := `---
+# show
+title: Show
+tags:
+  genre: [Drama, "Sci-Fi", 'Thriller']
+  country:
+  - US
+  - GB
+  studio: []
+seasons:
+  "1":
+    title: Season 1
+episodes:
+  - season: 1
+    episode: 2
+    title: A long
+      title
+  -
+    season: 1
+    episode: 3
+  - - nested
+    - list
+  - plain item
+    on two lines
+`
+	want := map[string]interface{}{
+		"title": "Show",
+		"tags": map[string]interface{}{
+			"genre":   []interface{}{"Drama", "Sci-Fi", "Thriller"},
+			"country": []interface{}{"US", "GB"},
+			"studio":  []interface{}{},
+		},
+		"seasons": map[string]interface{}{
+			"1": map[string]interface{}{"title": "Season 1"},
+		},
+		"episodes": []interface{}{
+			map[string]interface{}{"season": "1", "episode": "2", "title": "A long title"},
+			map[string]interface{}{"season": "1", "episode": "3"},
+			[]interface{}{"nested", "list"},
+			"plain item on two lines",
+		},
+	}
+	v, err := parseYAML(data)
+	if err != nil {
+		t.Fatalf("parseYAML() returned %v", err)
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("parseYAML() = %v, want %v", v, want)
+	}
+
+	for _, i := range []string{"", "\n# comment\n", "---\n"} {
+		v, err := parseYAML(i)
+		if err != nil || !reflect.DeepEqual(v, map[string]interface{}{}) {
+			t.Errorf("parseYAML(%q) = %v, %v, want an empty mapping", i, v, err)
+		}
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		yaml string
+		err  string
+	}{
+		{"title: Show\n\ttitle: Other", "yaml: line 2: tabs are not allowed for indentation"},
+		{"title: Show\ntitle: Other", "yaml: line 2: duplicate key: title"},
+		{"title: Show\nnot a key", "yaml: line 2: expected a key"},
+		{"title: A long\n  title: Other", "yaml: line 2: unexpected key in a multi-line scalar"},
+		{"title: Show\n# comment\n  more", "yaml: line 3: bad indentation"},
+		{"tags:\n  - a\n  b: c", "yaml: line 3: bad indentation"},
+		{"title: \"Show", "yaml: line 1: unterminated quoted string"},
+		{"year: 2010\ntitle: \"A long\n  title\" text", "yaml: line 3: unexpected content after a quoted string"},
+		{"title: \"\\q\"", "yaml: line 1: invalid double quoted string"},
+		{"genre: [Drama, Sci-Fi", "yaml: line 1: unterminated flow sequence"},
+		{"genre: [Drama, [Sci-Fi]]", "yaml: line 1: nested flow collections are not supported"},
+		{"overview: |x\n  text", "yaml: line 1: unsupported block scalar header: |x"},
+		{"title: Show\n- item", "yaml: line 2: unexpected sequence item"},
+	}
+	for _, i := range tests {
+		_, err := parseYAML(i.yaml)
+		if err == nil || !strings.HasPrefix(err.Error(), i.err) {
+			t.Errorf("parseYAML(%q) returned %v, want %q", i.yaml, err, i.err)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+package musicbrainz
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// maximum number of albums returned by ListArtistAlbum
+const maxAlbums = 500
+
+func NewMusicProvider() common.MusicProvider {
+	p := New()
+	return &p
+}
+
+// artists
+
+func (m *MusicBrainz) artistSearchData(a MBArtist) common.SearchData {
+	return common.SearchData{
+		Title:     a.Name,
+		Overview:  a.Disambiguation,
+		Icon:      "",
+		Premiered: common.ParseDate(a.LifeSpan.Begin),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: m.ScraperName,
+			ScraperID:   a.ID,
+			ScraperData: "",
+			ScraperLink: m.MediaLink("artist", a.ID),
+		},
+	}
+}
+
+func (m *MusicBrainz) SearchArtist(name string) ([]common.SearchData, error) {
+	decode := MBArtistSearch{}
+	err := m.search("artist", escape(name), &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, i := range decode.Artists {
+		ret = append(ret, m.artistSearchData(i))
+	}
+	return ret, nil
+}
+
+func (m *MusicBrainz) getArtist() (MBArtist, error) {
+	decode := MBArtist{}
+	err := m.request("artist/"+url.PathEscape(m.ScraperID), url.Values{"inc": {"url-rels genres tags"}}, &decode)
+	return decode, err
+}
+
+// the api has no biography, the disambiguation comment is used as overview
+func (m *MusicBrainz) GetArtist() (common.ArtistData, error) {
+	decode, err := m.getArtist()
+	if err != nil {
+		return common.ArtistData{}, err
+	}
+
+	return common.ArtistData{
+		Name:     decode.Name,
+		SortName: decode.SortName,
+		Overview: decode.Disambiguation,
+		Icon:     imageURL(relation(decode.Relations, "image")),
+		Type:     artistType(decode.Type),
+		Country:  decode.Country,
+		Begin:    common.ParseDate(decode.LifeSpan.Begin),
+		End:      common.ParseDate(decode.LifeSpan.End),
+		Website:  relation(decode.Relations, "official homepage"),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: m.ScraperName,
+			ScraperID:   m.ScraperID,
+			ScraperData: m.ScraperData,
+			ScraperLink: m.MediaLink("artist", m.ScraperID),
+		},
+	}, nil
+}
+
+// list the albums, singles and eps of the artist
+func (m *MusicBrainz) ListArtistAlbum() ([]common.SearchData, error) {
+	ret := []common.SearchData{}
+
+	for offset := 0; offset < maxAlbums; {
+		decode := MBReleaseGroupSearch{}
+		err := m.request("release-group", url.Values{
+			"artist": {m.ScraperID},
+			"limit":  {"100"},
+			"offset": {strconv.Itoa(offset)},
+		}, &decode)
+		if err != nil {
+			return ret, err
+		}
+		for _, i := range decode.ReleaseGroups {
+			ret = append(ret, m.albumSearchData(i))
+		}
+		offset += len(decode.ReleaseGroups)
+		if len(decode.ReleaseGroups) == 0 || offset >= decode.ReleaseGroupCount {
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+func (m *MusicBrainz) ListArtistTag() ([]common.TagData, error) {
+	decode, err := m.getArtist()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return tags(decode.Genres, decode.Tags), nil
+}
+
+// albums
+
+// the covers are not requested for the search results, as it would require a request per result
+func (m *MusicBrainz) albumSearchData(rg MBReleaseGroup) common.SearchData {
+	return common.SearchData{
+		Title:     rg.Title,
+		Overview:  artistCredit(rg.ArtistCredit),
+		Icon:      "",
+		Premiered: common.ParseDate(rg.FirstReleaseDate),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: m.ScraperName,
+			ScraperID:   rg.ID,
+			ScraperData: "",
+			ScraperLink: m.MediaLink("release-group", rg.ID),
+		},
+	}
+}
+
+func (m *MusicBrainz) SearchAlbum(name string, artist string) ([]common.SearchData, error) {
+	query := "releasegroup:\"" + escape(name) + "\""
+	if artist != "" {
+		query += " AND artist:\"" + escape(artist) + "\""
+	}
+	decode := MBReleaseGroupSearch{}
+	err := m.search("release-group", query, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, i := range decode.ReleaseGroups {
+		ret = append(ret, m.albumSearchData(i))
+	}
+	return ret, nil
+}
+
+// returns the album and the release used for its tracks
+// the data is cached as it is used for the album and its tracks
+func (m *MusicBrainz) getAlbum() (*MBReleaseGroup, *MBRelease, error) {
+	m.cacheLock.Lock()
+	defer m.cacheLock.Unlock()
+
+	if m.album != nil && m.album.ID == m.ScraperID {
+		return m.album, m.release, nil
+	}
+
+	album := MBReleaseGroup{}
+	err := m.request("release-group/"+url.PathEscape(m.ScraperID), url.Values{"inc": {"artist-credits releases genres tags ratings"}}, &album)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release := MBRelease{}
+	if id := selectRelease(album.Releases); id != "" {
+		err = m.request("release/"+url.PathEscape(id), url.Values{"inc": {"recordings artist-credits"}}, &release)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	m.album = &album
+	m.release = &release
+	return m.album, m.release, nil
+}
+
+func (m *MusicBrainz) GetAlbum() (common.AlbumData, error) {
+	album, release, err := m.getAlbum()
+	if err != nil {
+		return common.AlbumData{}, err
+	}
+
+	count := 0
+	for _, i := range release.Media {
+		count += len(i.Tracks)
+	}
+
+	// the cover of the release group is selected by the cover art archive, the one of the release is used as a fallback
+	icon := m.cover("release-group", album.ID)
+	if icon == "" {
+		icon = m.cover("release", release.ID)
+	}
+
+	return common.AlbumData{
+		Title:      album.Title,
+		Artist:     artistCredit(album.ArtistCredit),
+		Overview:   album.Disambiguation,
+		Icon:       icon,
+		Fanart:     "",
+		Type:       albumType(album),
+		Premiered:  common.ParseDate(album.FirstReleaseDate),
+		TrackCount: int64(count),
+		Rating:     rating(album.Rating),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: m.ScraperName,
+			ScraperID:   m.ScraperID,
+			ScraperData: m.ScraperData,
+			ScraperLink: m.MediaLink("release-group", m.ScraperID),
+		},
+	}, nil
+}
+
+// list the tracks of the earliest official release of the album
+// the ScraperID of the tracks is the id of their recording
+func (m *MusicBrainz) ListAlbumTrack() ([]common.TrackData, error) {
+	ret := []common.TrackData{}
+
+	album, release, err := m.getAlbum()
+	if err != nil {
+		return ret, err
+	}
+
+	for _, media := range release.Media {
+		for _, i := range media.Tracks {
+			credits := i.ArtistCredit
+			if len(credits) == 0 {
+				credits = i.Recording.ArtistCredit
+			}
+			length := i.Length
+			if length == 0 {
+				length = i.Recording.Length
+			}
+			ret = append(ret, common.TrackData{
+				Title:     i.Title,
+				Artist:    artistCredit(credits),
+				Album:     album.Title,
+				Disc:      int64(media.Position),
+				Track:     int64(i.Position),
+				Duration:  length / 1000,
+				Premiered: common.ParseDate(release.Date),
+				Rating:    0,
+				ScraperInfo: common.ScraperInfo{
+					ScraperName: m.ScraperName,
+					ScraperID:   i.Recording.ID,
+					ScraperData: "",
+					ScraperLink: m.MediaLink("recording", i.Recording.ID),
+				},
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+func (m *MusicBrainz) ListAlbumTag() ([]common.TagData, error) {
+	album, _, err := m.getAlbum()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return tags(album.Genres, album.Tags), nil
+}
+
+// tracks
+
+func (m *MusicBrainz) trackSearchData(r MBRecording) common.SearchData {
+	album := ""
+	if len(r.Releases) > 0 {
+		album = r.Releases[0].Title
+	}
+	overview := artistCredit(r.ArtistCredit)
+	if album != "" {
+		overview += " - " + album
+	}
+	return common.SearchData{
+		Title:     r.Title,
+		Overview:  overview,
+		Icon:      "",
+		Premiered: common.ParseDate(r.FirstReleaseDate),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: m.ScraperName,
+			ScraperID:   r.ID,
+			ScraperData: "",
+			ScraperLink: m.MediaLink("recording", r.ID),
+		},
+	}
+}
+
+func (m *MusicBrainz) SearchTrack(name string, artist string) ([]common.SearchData, error) {
+	query := "recording:\"" + escape(name) + "\""
+	if artist != "" {
+		query += " AND artist:\"" + escape(artist) + "\""
+	}
+	decode := MBRecordingSearch{}
+	err := m.search("recording", query, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, i := range decode.Recordings {
+		ret = append(ret, m.trackSearchData(i))
+	}
+	return ret, nil
+}
+
+// the album of the track is its earliest official release
+func (m *MusicBrainz) GetTrack() (common.TrackData, error) {
+	decode := MBRecording{}
+	err := m.request("recording/"+url.PathEscape(m.ScraperID), url.Values{"inc": {"artist-credits releases ratings"}}, &decode)
+	if err != nil {
+		return common.TrackData{}, err
+	}
+
+	album := ""
+	if id := selectRelease(decode.Releases); id != "" {
+		for _, i := range decode.Releases {
+			if i.ID == id {
+				album = i.Title
+				break
+			}
+		}
+	}
+
+	return common.TrackData{
+		Title:     decode.Title,
+		Artist:    artistCredit(decode.ArtistCredit),
+		Album:     album,
+		Duration:  decode.Length / 1000,
+		Premiered: common.ParseDate(decode.FirstReleaseDate),
+		Rating:    rating(decode.Rating),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: m.ScraperName,
+			ScraperID:   m.ScraperID,
+			ScraperData: m.ScraperData,
+			ScraperLink: m.MediaLink("recording", m.ScraperID),
+		},
+	}, nil
+}
+
+// external ids
+
+// search an artist, album or track from an external id
+// a musicbrainz id can identify an artist, an album or a track, the first existing entity is returned
+// wikidata ids are resolved with the url relations of the api
+func (m *MusicBrainz) FindMusicByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	switch source {
+	case common.ExternalMusicBrainz:
+		artist := MBArtist{}
+		if err := m.request("artist/"+url.PathEscape(id), url.Values{}, &artist); err == nil {
+			return []common.SearchData{m.artistSearchData(artist)}, nil
+		}
+		album := MBReleaseGroup{}
+		if err := m.request("release-group/"+url.PathEscape(id), url.Values{"inc": {"artist-credits"}}, &album); err == nil {
+			return []common.SearchData{m.albumSearchData(album)}, nil
+		}
+		track := MBRecording{}
+		err := m.request("recording/"+url.PathEscape(id), url.Values{"inc": {"artist-credits releases"}}, &track)
+		if err != nil {
+			return nil, err
+		}
+		return []common.SearchData{m.trackSearchData(track)}, nil
+	case common.ExternalWikidata:
+		decode := MBURL{}
+		err := m.request("url", url.Values{
+			"resource": {"https://www.wikidata.org/wiki/" + id},
+			"inc":      {"artist-rels release-group-rels"},
+		}, &decode)
+		if err != nil {
+			return nil, err
+		}
+		ret := make([]common.SearchData, 0)
+		for _, i := range decode.Relations {
+			if i.Artist != nil {
+				ret = append(ret, m.artistSearchData(*i.Artist))
+			} else if i.ReleaseGroup != nil {
+				ret = append(ret, m.albumSearchData(*i.ReleaseGroup))
+			}
+		}
+		return ret, nil
+	}
+	return nil, errors.New("unsupported external source: " + string(source))
+}
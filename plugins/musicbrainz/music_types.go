@@ -0,0 +1,124 @@
+package musicbrainz
+
+type MBArtistCredit struct {
+	Name       string `json:"name"`
+	JoinPhrase string `json:"joinphrase"`
+	Artist     struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"artist"`
+}
+
+type MBLifeSpan struct {
+	Begin string `json:"begin"`
+	End   string `json:"end"`
+	Ended bool   `json:"ended"`
+}
+
+type MBTag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type MBRating struct {
+	Value      float64 `json:"value"` // 0-5
+	VotesCount int64   `json:"votes-count"`
+}
+
+type MBRelation struct {
+	Type string `json:"type"` // ex: wikidata, image, official homepage
+	URL  *struct {
+		Resource string `json:"resource"`
+	} `json:"url"`
+	Artist       *MBArtist       `json:"artist"`
+	ReleaseGroup *MBReleaseGroup `json:"release_group"`
+}
+
+type MBArtist struct {
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	SortName       string       `json:"sort-name"`
+	Type           string       `json:"type"` // Person, Group, Orchestra, Choir, Character, Other
+	Country        string       `json:"country"`
+	Disambiguation string       `json:"disambiguation"`
+	LifeSpan       MBLifeSpan   `json:"life-span"`
+	Tags           []MBTag      `json:"tags"`
+	Genres         []MBTag      `json:"genres"`
+	Relations      []MBRelation `json:"relations"`
+}
+
+type MBReleaseGroup struct {
+	ID               string           `json:"id"`
+	Title            string           `json:"title"`
+	PrimaryType      string           `json:"primary-type"`    // Album, Single, EP, Broadcast, Other
+	SecondaryTypes   []string         `json:"secondary-types"` // ex: Soundtrack, Live, Compilation
+	FirstReleaseDate string           `json:"first-release-date"`
+	Disambiguation   string           `json:"disambiguation"`
+	ArtistCredit     []MBArtistCredit `json:"artist-credit"`
+	Releases         []MBRelease      `json:"releases"`
+	Tags             []MBTag          `json:"tags"`
+	Genres           []MBTag          `json:"genres"`
+	Rating           MBRating         `json:"rating"`
+}
+
+type MBRelease struct {
+	ID           string           `json:"id"`
+	Title        string           `json:"title"`
+	Status       string           `json:"status"` // Official, Promotion, Bootleg
+	Date         string           `json:"date"`
+	Country      string           `json:"country"`
+	ArtistCredit []MBArtistCredit `json:"artist-credit"`
+	ReleaseGroup *MBReleaseGroup  `json:"release-group"`
+	Media        []struct {
+		Position int       `json:"position"`
+		Format   string    `json:"format"`
+		Tracks   []MBTrack `json:"tracks"`
+	} `json:"media"`
+}
+
+type MBTrack struct {
+	ID           string           `json:"id"`
+	Number       string           `json:"number"`
+	Position     int              `json:"position"`
+	Title        string           `json:"title"`
+	Length       int64            `json:"length"` // in milliseconds
+	ArtistCredit []MBArtistCredit `json:"artist-credit"`
+	Recording    MBRecording      `json:"recording"`
+}
+
+type MBRecording struct {
+	ID               string           `json:"id"`
+	Title            string           `json:"title"`
+	Length           int64            `json:"length"` // in milliseconds
+	FirstReleaseDate string           `json:"first-release-date"`
+	Disambiguation   string           `json:"disambiguation"`
+	ArtistCredit     []MBArtistCredit `json:"artist-credit"`
+	Releases         []MBRelease      `json:"releases"`
+	Rating           MBRating         `json:"rating"`
+}
+
+type MBArtistSearch struct {
+	Artists []MBArtist `json:"artists"`
+}
+
+type MBReleaseGroupSearch struct {
+	ReleaseGroups     []MBReleaseGroup `json:"release-groups"`
+	ReleaseGroupCount int              `json:"release-group-count"`
+}
+
+type MBRecordingSearch struct {
+	Recordings []MBRecording `json:"recordings"`
+}
+
+type MBURL struct {
+	Relations []MBRelation `json:"relations"`
+}
+
+type CoverArtList struct {
+	Images []struct {
+		Front      bool              `json:"front"`
+		Back       bool              `json:"back"`
+		Image      string            `json:"image"`
+		Thumbnails map[string]string `json:"thumbnails"` // ex: small, large, 500, 1200
+	} `json:"images"`
+}
@@ -0,0 +1,279 @@
+package musicbrainz
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// the api allows one request per second, the requests are spaced by this delay
+const requestDelay = time.Second
+
+// maximum number of retries when the api is unavailable (rate limit exceeded)
+const maxRetries = 3
+
+// provider for the MusicBrainz api, items are identified by their mbid
+// artists are MusicBrainz artists, albums are release groups and tracks are recordings
+// the covers are requested from the Cover Art Archive
+type MusicBrainz struct {
+	APIURL           string // url of the api, can be changed to use a local server
+	CoverArtURL      string // url of the cover art archive, empty to disable the covers
+	UserAgent        string // the api requires a user agent identifying the application
+	SearchMaxResults int
+	ScraperName      string
+	ScraperID        string
+	ScraperData      string
+	Logger           *log.Logger
+	album            *MBReleaseGroup // last requested album
+	release          *MBRelease      // release used for the tracks of the last requested album
+	lastRequest      time.Time
+	requestLock      *sync.Mutex
+	cacheLock        *sync.Mutex
+}
+
+func New() MusicBrainz {
+	return MusicBrainz{
+		ScraperName:      "musicbrainz",
+		APIURL:           "https://musicbrainz.org/ws/2",
+		CoverArtURL:      "https://coverartarchive.org",
+		UserAgent:        "zogwine-metadata ( https://github.com/zogwine/metadata )",
+		SearchMaxResults: 20,
+		Logger:           nil,
+		ScraperID:        "",
+		ScraperData:      "",
+		requestLock:      &sync.Mutex{},
+		cacheLock:        &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (m *MusicBrainz) Setup(config map[string]string, logger *log.Logger) error {
+	m.Logger = logger
+	if val, ok := config["api_url"]; ok && val != "" {
+		m.APIURL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["coverart_url"]; ok {
+		m.CoverArtURL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["user_agent"]; ok && val != "" {
+		m.UserAgent = val
+	}
+	if val, ok := config["search_max_results"]; ok {
+		if n, err := strconv.Atoi(val); err == nil && n > 0 {
+			m.SearchMaxResults = n
+		}
+	}
+	return nil
+}
+
+func (m *MusicBrainz) Configure(ScraperID string, ScraperData string) {
+	m.ScraperID = ScraperID
+	m.ScraperData = ScraperData
+}
+
+// helper to get a json document, the requests are spaced to respect the rate limit of the api
+// the api returns a 503 status code when the rate limit is exceeded, the request is retried after a delay
+func (m *MusicBrainz) get(u string, v interface{}) error {
+	errFields := log.Fields{
+		"file":     "musicbrainz",
+		"function": "get",
+	}
+
+	m.requestLock.Lock()
+	defer m.requestLock.Unlock()
+
+	for retry := 0; ; retry++ {
+		if wait := requestDelay - time.Since(m.lastRequest); wait > 0 {
+			time.Sleep(wait)
+		}
+		m.lastRequest = time.Now()
+
+		req, err := http.NewRequest("GET", u, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("User-Agent", m.UserAgent)
+		req.Header.Set("Accept", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			m.Logger.WithFields(errFields).Errorf("request error: %v", err)
+			return err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode == 503 && retry < maxRetries {
+			time.Sleep(time.Duration(retry+1) * requestDelay)
+			continue
+		}
+		if resp.StatusCode != 200 {
+			m.Logger.WithFields(errFields).Infof("requested url: %s", u)
+			m.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+			return errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+		}
+		if err != nil {
+			m.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+			return err
+		}
+		return json.Unmarshal(data, v)
+	}
+}
+
+// helper to make a request to the api (ex: artist/<mbid>)
+func (m *MusicBrainz) request(link string, params url.Values, v interface{}) error {
+	params.Set("fmt", "json")
+	return m.get(m.APIURL+"/"+link+"?"+params.Encode(), v)
+}
+
+// search the entities of a given type (artist, release-group, recording) with a lucene query
+func (m *MusicBrainz) search(entity string, query string, v interface{}) error {
+	return m.request(entity, url.Values{"query": {query}, "limit": {strconv.Itoa(m.SearchMaxResults)}}, v)
+}
+
+// returns the front cover of a release group or a release, empty if there is no cover
+func (m *MusicBrainz) cover(entity string, id string) string {
+	if m.CoverArtURL == "" || id == "" {
+		return ""
+	}
+	decode := CoverArtList{}
+	err := m.get(m.CoverArtURL+"/"+entity+"/"+id, &decode)
+	if err != nil {
+		return ""
+	}
+	for _, i := range decode.Images {
+		if i.Front {
+			if val := i.Thumbnails["1200"]; val != "" {
+				return val
+			}
+			return i.Image
+		}
+	}
+	return ""
+}
+
+func (m *MusicBrainz) MediaLink(entity string, id string) string {
+	if id == "" {
+		return ""
+	}
+	return "https://musicbrainz.org/" + entity + "/" + id
+}
+
+// helpers
+
+var luceneSpecialReg = regexp.MustCompile(`([+\-&|!(){}\[\]^"~*?:\\/])`)
+
+// escape the special characters of a lucene query
+func escape(val string) string {
+	return luceneSpecialReg.ReplaceAllString(val, `\$1`)
+}
+
+// returns the credited artists (ex: Simon & Garfunkel)
+func artistCredit(credits []MBArtistCredit) string {
+	ret := ""
+	for _, i := range credits {
+		ret += i.Name + i.JoinPhrase
+	}
+	return ret
+}
+
+// convert the types of a release group to the album type
+func albumType(rg *MBReleaseGroup) string {
+	for _, i := range rg.SecondaryTypes {
+		switch i {
+		case "Soundtrack":
+			return common.AlbumSoundtrack
+		case "Live":
+			return common.AlbumLive
+		case "Compilation":
+			return common.AlbumCompilation
+		}
+	}
+	switch rg.PrimaryType {
+	case "Album":
+		return common.AlbumAlbum
+	case "Single":
+		return common.AlbumSingle
+	case "EP":
+		return common.AlbumEP
+	}
+	return common.AlbumOther
+}
+
+// convert the type of an artist, orchestras and choirs are groups
+func artistType(tp string) string {
+	switch tp {
+	case "Person":
+		return common.ArtistPerson
+	case "Group", "Orchestra", "Choir":
+		return common.ArtistGroup
+	}
+	return ""
+}
+
+// ratings are on a 0-5 scale
+func rating(r MBRating) int64 {
+	return int64(r.Value * 2)
+}
+
+// genres are used as tags, the tags added by the users are used if no genre is available
+func tags(genres []MBTag, userTags []MBTag) []common.TagData {
+	ret := []common.TagData{}
+	for _, i := range genres {
+		ret = append(ret, common.TagData{Name: "genre", Value: i.Name})
+	}
+	if len(ret) > 0 {
+		return ret
+	}
+	for _, i := range userTags {
+		if i.Count > 0 {
+			ret = append(ret, common.TagData{Name: "genre", Value: i.Name})
+		}
+	}
+	return ret
+}
+
+// select the release used for the tracks of an album: the earliest official release
+func selectRelease(releases []MBRelease) string {
+	if len(releases) == 0 {
+		return ""
+	}
+	list := append([]MBRelease{}, releases...)
+	sort.SliceStable(list, func(i, j int) bool {
+		if (list[i].Status == "Official") != (list[j].Status == "Official") {
+			return list[i].Status == "Official"
+		}
+		return common.ParseDate(list[i].Date).Before(common.ParseDate(list[j].Date))
+	})
+	return list[0].ID
+}
+
+// returns the url of a relation of the given type (ex: official homepage)
+func relation(relations []MBRelation, tp string) string {
+	for _, i := range relations {
+		if i.Type == tp && i.URL != nil {
+			return i.URL.Resource
+		}
+	}
+	return ""
+}
+
+// the images of the artists are links to wikimedia commons pages (ex: https://commons.wikimedia.org/wiki/File:Example.jpg)
+// they are converted to direct links to the files
+func imageURL(link string) string {
+	const prefix = "https://commons.wikimedia.org/wiki/File:"
+	if strings.HasPrefix(link, prefix) {
+		return "https://commons.wikimedia.org/wiki/Special:FilePath/" + strings.TrimPrefix(link, prefix)
+	}
+	return link
+}
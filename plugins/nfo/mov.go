@@ -0,0 +1,185 @@
+package nfo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewMovieProvider() common.MovieProvider {
+	p := New()
+	return &p
+}
+
+// returns the nfo file of a movie from the path of the movie file or folder
+// kodi stores it as <movie>.nfo next to the movie file or as movie.nfo in the movie folder
+func movieNFOPath(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	candidates := []string{}
+	if info.IsDir() {
+		candidates = append(candidates, filepath.Join(path, "movie.nfo"), filepath.Join(path, filepath.Base(path)+".nfo"))
+	} else {
+		candidates = append(candidates, strings.TrimSuffix(path, filepath.Ext(path))+".nfo", filepath.Join(filepath.Dir(path), "movie.nfo"))
+	}
+	for _, i := range candidates {
+		if _, err := os.Stat(i); err == nil {
+			return i, nil
+		}
+	}
+	return "", errors.New("no nfo file found for: " + path)
+}
+
+func (n *NFO) getMovie() (NFOMovie, error) {
+	movie := NFOMovie{}
+	err := readNFO(n.ScraperID, "movie", &movie)
+	return movie, err
+}
+
+// returns the artwork of a movie, stored either as <movie>-poster.jpg or poster.jpg
+func movieImage(nfoPath string, thumbs []NFOThumb, aspect string, name string) string {
+	dir := filepath.Dir(nfoPath)
+	base := strings.TrimSuffix(filepath.Base(nfoPath), filepath.Ext(nfoPath))
+	files := []string{base + "-" + name + ".jpg", name + ".jpg"}
+	if name == "poster" {
+		files = append(files, "folder.jpg")
+	}
+	return image(dir, thumbs, aspect, files...)
+}
+
+func (n *NFO) movieSearchData(path string, movie NFOMovie) common.SearchData {
+	return common.SearchData{
+		Title:     first(movie.Title, movie.OriginalTitle),
+		Overview:  first(movie.Plot, movie.Outline),
+		Icon:      movieImage(path, movie.Thumbs, "poster", "poster"),
+		Premiered: common.ParseDate(first(movie.Premiered, movie.Year)),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: n.ScraperName,
+			ScraperID:   path,
+			ScraperData: "",
+			ScraperLink: "",
+		},
+	}
+}
+
+// movie search, nfo files can only be found from the path of the movie
+func (n *NFO) SearchMovie(name string, year int) ([]common.SearchData, error) {
+	return nil, errors.New("the nfo provider requires the path of the movie")
+}
+
+// movie search from the nfo file stored next to the movie
+func (n *NFO) SearchMovieFromPath(path string) ([]common.SearchData, error) {
+	nfoPath, err := movieNFOPath(path)
+	if err != nil {
+		return nil, err
+	}
+	movie := NFOMovie{}
+	err = readNFO(nfoPath, "movie", &movie)
+	if err != nil {
+		return nil, err
+	}
+	return []common.SearchData{n.movieSearchData(nfoPath, movie)}, nil
+}
+
+// nfo files are not indexed by external ids, only the configured movie can be checked
+func (n *NFO) FindMovieByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	if n.ScraperID == "" {
+		return nil, errors.New("no data")
+	}
+	movie, err := n.getMovie()
+	if err != nil {
+		return nil, err
+	}
+	if !hasExternalID(movie.UniqueIDs, []string{movie.ID, movie.IMDBID}, source, id) {
+		return []common.SearchData{}, nil
+	}
+	return []common.SearchData{n.movieSearchData(n.ScraperID, movie)}, nil
+}
+
+func (n *NFO) GetMovie() (common.MovieData, error) {
+	movie, err := n.getMovie()
+	if err != nil {
+		return common.MovieData{}, err
+	}
+
+	tr := trailers(movie.Trailer)
+	trailer := ""
+	if len(tr) > 0 {
+		trailer = tr[0].Link
+	}
+
+	return common.MovieData{
+		Title:      first(movie.Title, movie.OriginalTitle),
+		Overview:   first(movie.Plot, movie.Outline),
+		Icon:       movieImage(n.ScraperID, movie.Thumbs, "poster", "poster"),
+		Fanart:     movieImage(n.ScraperID, movie.Fanart, "", "fanart"),
+		Website:    "",
+		Trailer:    trailer,
+		Trailers:   tr,
+		Premiered:  common.ParseDate(first(movie.Premiered, movie.Year)),
+		Rating:     rating(movie.Ratings, movie.Rating),
+		Collection: 0,
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   n.ScraperID,
+			ScraperName: n.ScraperName,
+			ScraperData: n.ScraperData,
+			ScraperLink: "",
+		},
+	}, nil
+}
+
+// the collection is read from the set of the configured movie
+func (n *NFO) GetMovieCollection() (common.MovieCollectionData, error) {
+	movie, err := n.getMovie()
+	if err != nil {
+		return common.MovieCollectionData{}, err
+	}
+	name := first(movie.Set.Name, movie.Set.Text)
+	if name == "" {
+		return common.MovieCollectionData{}, errors.New("no data")
+	}
+
+	return common.MovieCollectionData{
+		Title:    name,
+		Overview: movie.Set.Overview,
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   name,
+			ScraperName: n.ScraperName,
+			ScraperData: "",
+			ScraperLink: "",
+		},
+	}, nil
+}
+
+func (n *NFO) ListMoviePerson() ([]common.PersonData, error) {
+	movie, err := n.getMovie()
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return persons(movie.Actors, movie.Directors, movie.Credits), nil
+}
+
+func (n *NFO) ListMovieTag() ([]common.TagData, error) {
+	movie, err := n.getMovie()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return tags(movie.Genres, movie.Countries, movie.Tags), nil
+}
+
+func (n *NFO) ListMovieCompany() ([]common.CompanyData, error) {
+	movie, err := n.getMovie()
+	if err != nil {
+		return []common.CompanyData{}, err
+	}
+	return n.companies(movie.Studios, common.CompanyStudio), nil
+}
+
+func (n *NFO) GetMovieUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
@@ -0,0 +1,278 @@
+package nfo
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// provider reading the kodi nfo files stored next to the media files
+// ScraperID is the full path of the tvs folder or of the movie nfo file, it is stored relative to the library by the scraper
+// the local artworks are also returned as full paths and stored relative to the library
+type NFO struct {
+	ScraperName string
+	ScraperID   string
+	ScraperData string
+	Logger      *log.Logger
+	index       *NFOIndex // index of the nfo files of the last requested tvs
+	cacheLock   *sync.Mutex
+}
+
+func New() NFO {
+	return NFO{ScraperName: "nfo", Logger: nil, ScraperID: "", ScraperData: "", cacheLock: &sync.Mutex{}}
+}
+
+// configure the provider's settings
+func (n *NFO) Setup(config map[string]string, logger *log.Logger) error {
+	n.Logger = logger
+	return nil
+}
+
+func (n *NFO) Configure(ScraperID string, ScraperData string) {
+	n.ScraperID = ScraperID
+	n.ScraperData = ScraperData
+}
+
+// helper to decode the first element named root in a nfo file
+// nfo files can contain an url after the xml data, it is ignored
+func readNFO(path string, root string, v interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := xml.NewDecoder(f)
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return errors.New("no " + root + " element in: " + path)
+		}
+		if err != nil {
+			return err
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == root {
+			return dec.DecodeElement(v, &se)
+		}
+	}
+}
+
+// helper to decode all the episodes of a nfo file (a file can contain several episodes)
+func readEpisodes(path string) ([]NFOEpisode, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	episodes := []NFOEpisode{}
+	dec := xml.NewDecoder(f)
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "episodedetails" {
+			ep := NFOEpisode{}
+			if dec.DecodeElement(&ep, &se) == nil {
+				episodes = append(episodes, ep)
+			}
+		}
+	}
+	if len(episodes) == 0 {
+		return nil, errors.New("no episodedetails element in: " + path)
+	}
+	return episodes, nil
+}
+
+// returns the default rating, or the rating with the old format
+func rating(ratings []NFORating, legacy string) int64 {
+	for _, i := range ratings {
+		if i.Default {
+			return int64(scaleRating(i))
+		}
+	}
+	if len(ratings) > 0 {
+		return int64(scaleRating(ratings[0]))
+	}
+	val, _ := strconv.ParseFloat(strings.Replace(strings.TrimSpace(legacy), ",", ".", 1), 64)
+	return int64(val)
+}
+
+// ratings are stored on a 0-10 scale
+func scaleRating(r NFORating) float64 {
+	if r.Max > 0 && r.Max != 10 {
+		return r.Value * 10 / r.Max
+	}
+	return r.Value
+}
+
+// returns the first non empty value
+func first(values ...string) string {
+	for _, i := range values {
+		if strings.TrimSpace(i) != "" {
+			return strings.TrimSpace(i)
+		}
+	}
+	return ""
+}
+
+// returns the url of an artwork, relative paths are resolved from dir
+func imagePath(dir string, link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+	if u, err := url.Parse(link); err == nil && u.Scheme != "" && len(u.Scheme) > 1 {
+		return link
+	}
+	if filepath.IsAbs(link) {
+		return link
+	}
+	return filepath.Join(dir, link)
+}
+
+// select an artwork with the given aspect from a list of thumbs
+// if none is found, the first of the files found in dir is returned
+func image(dir string, thumbs []NFOThumb, aspect string, files ...string) string {
+	for _, i := range thumbs {
+		if i.Aspect == aspect && i.Season == "" && strings.TrimSpace(i.URL) != "" {
+			return imagePath(dir, i.URL)
+		}
+	}
+	if aspect == "poster" {
+		// thumbs without aspect are posters in the old format
+		for _, i := range thumbs {
+			if i.Aspect == "" && i.Season == "" && strings.TrimSpace(i.URL) != "" {
+				return imagePath(dir, i.URL)
+			}
+		}
+	}
+	return findFile(dir, files...)
+}
+
+// returns the path of the first existing file in dir
+func findFile(dir string, names ...string) string {
+	for _, i := range names {
+		p := filepath.Join(dir, i)
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// convert kodi trailer links (youtube plugin) to web links
+func trailerURL(link string) string {
+	link = strings.TrimSpace(link)
+	if strings.HasPrefix(link, "plugin://plugin.video.youtube") {
+		u, err := url.Parse(link)
+		if err != nil {
+			return ""
+		}
+		id := u.Query().Get("videoid")
+		if id == "" {
+			id = u.Query().Get("video_id")
+		}
+		if id == "" {
+			return ""
+		}
+		return "https://www.youtube.com/watch?v=" + id
+	}
+	return link
+}
+
+func trailers(link string) []common.TrailerData {
+	link = trailerURL(link)
+	if link == "" {
+		return []common.TrailerData{}
+	}
+	site := ""
+	if strings.Contains(link, "youtube.com") {
+		site = "youtube"
+	}
+	return []common.TrailerData{{Link: link, Site: site, Type: "trailer"}}
+}
+
+// returns true if the nfo contains the given external id
+func hasExternalID(ids []NFOUniqueID, legacyIDs []string, source common.ExternalSource, id string) bool {
+	for _, i := range ids {
+		if strings.EqualFold(i.Type, string(source)) && strings.TrimSpace(i.Value) == id {
+			return true
+		}
+	}
+	for _, i := range legacyIDs {
+		if strings.TrimSpace(i) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// the role of an actor in the nfo is the character played
+func persons(actors []NFOActor, directors []string, credits []string) []common.PersonData {
+	pers := []common.PersonData{}
+	for _, i := range actors {
+		if i.Name != "" {
+			pers = append(pers, common.PersonData{Name: i.Name, Role: common.RoleActing, Character: strings.TrimSpace(i.Role), IsCharacter: false})
+		}
+	}
+	for _, i := range directors {
+		if i != "" {
+			pers = append(pers, common.PersonData{Name: i, Role: common.RoleDirecting, IsCharacter: false})
+		}
+	}
+	for _, i := range credits {
+		if i != "" {
+			pers = append(pers, common.PersonData{Name: i, Role: common.RoleWriting, IsCharacter: false})
+		}
+	}
+	return pers
+}
+
+func tags(genres []string, countries []string, tagList []string) []common.TagData {
+	ret := []common.TagData{}
+	for _, i := range genres {
+		ret = append(ret, common.TagData{Name: "genre", Value: i})
+	}
+	for _, i := range countries {
+		ret = append(ret, common.TagData{Name: "country", Value: i})
+	}
+	for _, i := range tagList {
+		ret = append(ret, common.TagData{Name: "tag", Value: i})
+	}
+	return ret
+}
+
+// companies have no id in nfo files, their name is used instead
+func (n *NFO) companies(names []string, tp common.CompanyType) []common.CompanyData {
+	ret := []common.CompanyData{}
+	known := map[string]bool{}
+	for _, i := range names {
+		i = strings.TrimSpace(i)
+		if i == "" || known[strings.ToLower(i)] {
+			continue
+		}
+		known[strings.ToLower(i)] = true
+		ret = append(ret, common.CompanyData{
+			Name: i,
+			Type: tp,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: n.ScraperName,
+				ScraperID:   i,
+			},
+		})
+	}
+	return ret
+}
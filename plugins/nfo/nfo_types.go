@@ -0,0 +1,122 @@
+package nfo
+
+type NFORating struct {
+	Name    string  `xml:"name,attr"`
+	Max     float64 `xml:"max,attr"`
+	Default bool    `xml:"default,attr"`
+	Value   float64 `xml:"value"`
+	Votes   int64   `xml:"votes"`
+}
+
+type NFOThumb struct {
+	Aspect  string `xml:"aspect,attr"`
+	Type    string `xml:"type,attr"`
+	Season  string `xml:"season,attr"`
+	Preview string `xml:"preview,attr"`
+	URL     string `xml:",chardata"`
+}
+
+type NFOActor struct {
+	Name  string `xml:"name"`
+	Role  string `xml:"role"`
+	Order int    `xml:"order"`
+	Thumb string `xml:"thumb"`
+}
+
+type NFOUniqueID struct {
+	Type    string `xml:"type,attr"`
+	Default bool   `xml:"default,attr"`
+	Value   string `xml:",chardata"`
+}
+
+type NFOTVShow struct {
+	Title         string        `xml:"title"`
+	OriginalTitle string        `xml:"originaltitle"`
+	ShowTitle     string        `xml:"showtitle"`
+	Plot          string        `xml:"plot"`
+	Outline       string        `xml:"outline"`
+	Premiered     string        `xml:"premiered"`
+	Year          string        `xml:"year"`
+	Rating        string        `xml:"rating"`
+	Ratings       []NFORating   `xml:"ratings>rating"`
+	Thumbs        []NFOThumb    `xml:"thumb"`
+	Fanart        []NFOThumb    `xml:"fanart>thumb"`
+	Genres        []string      `xml:"genre"`
+	Tags          []string      `xml:"tag"`
+	Countries     []string      `xml:"country"`
+	Studios       []string      `xml:"studio"`
+	Actors        []NFOActor    `xml:"actor"`
+	Directors     []string      `xml:"director"`
+	Credits       []string      `xml:"credits"`
+	UniqueIDs     []NFOUniqueID `xml:"uniqueid"`
+	ID            string        `xml:"id"`
+	IMDBID        string        `xml:"imdb_id"`
+	Trailer       string        `xml:"trailer"`
+	Status        string        `xml:"status"`
+	MPAA          string        `xml:"mpaa"`
+	NamedSeasons  []struct {
+		Number int    `xml:"number,attr"`
+		Name   string `xml:",chardata"`
+	} `xml:"namedseason"`
+}
+
+type NFOSeason struct {
+	Title        string     `xml:"title"`
+	Plot         string     `xml:"plot"`
+	Outline      string     `xml:"outline"`
+	Premiered    string     `xml:"premiered"`
+	Year         string     `xml:"year"`
+	SeasonNumber *int       `xml:"seasonnumber"`
+	Thumbs       []NFOThumb `xml:"thumb"`
+	Fanart       []NFOThumb `xml:"fanart>thumb"`
+}
+
+type NFOEpisode struct {
+	Title     string        `xml:"title"`
+	ShowTitle string        `xml:"showtitle"`
+	Season    int           `xml:"season"`
+	Episode   int           `xml:"episode"`
+	Plot      string        `xml:"plot"`
+	Outline   string        `xml:"outline"`
+	Aired     string        `xml:"aired"`
+	Premiered string        `xml:"premiered"`
+	Year      string        `xml:"year"`
+	Rating    string        `xml:"rating"`
+	Ratings   []NFORating   `xml:"ratings>rating"`
+	Thumbs    []NFOThumb    `xml:"thumb"`
+	Actors    []NFOActor    `xml:"actor"`
+	Directors []string      `xml:"director"`
+	Credits   []string      `xml:"credits"`
+	UniqueIDs []NFOUniqueID `xml:"uniqueid"`
+}
+
+type NFOMovie struct {
+	Title         string        `xml:"title"`
+	OriginalTitle string        `xml:"originaltitle"`
+	Plot          string        `xml:"plot"`
+	Outline       string        `xml:"outline"`
+	TagLine       string        `xml:"tagline"`
+	Premiered     string        `xml:"premiered"`
+	Year          string        `xml:"year"`
+	Rating        string        `xml:"rating"`
+	Ratings       []NFORating   `xml:"ratings>rating"`
+	Thumbs        []NFOThumb    `xml:"thumb"`
+	Fanart        []NFOThumb    `xml:"fanart>thumb"`
+	Genres        []string      `xml:"genre"`
+	Tags          []string      `xml:"tag"`
+	Countries     []string      `xml:"country"`
+	Studios       []string      `xml:"studio"`
+	Actors        []NFOActor    `xml:"actor"`
+	Directors     []string      `xml:"director"`
+	Credits       []string      `xml:"credits"`
+	UniqueIDs     []NFOUniqueID `xml:"uniqueid"`
+	ID            string        `xml:"id"`
+	IMDBID        string        `xml:"imdb_id"`
+	Trailer       string        `xml:"trailer"`
+	MPAA          string        `xml:"mpaa"`
+	Set           struct {
+		Text     string `xml:",chardata"` // old format: <set>name</set>
+		Name     string `xml:"name"`
+		Overview string `xml:"overview"`
+	} `xml:"set"`
+}
@@ -0,0 +1,389 @@
+package nfo
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+// index of the nfo files found in a tvs folder
+type NFOIndex struct {
+	Path     string
+	Show     NFOTVShow
+	Seasons  map[int]NFOSeasonFile
+	Episodes map[string]NFOEpisodeFile // key: season-episode
+}
+
+type NFOSeasonFile struct {
+	Dir  string // folder containing the season files
+	Data NFOSeason
+}
+
+type NFOEpisodeFile struct {
+	Path string // path of the nfo file
+	Data NFOEpisode
+}
+
+func episodeKey(season int, episode int) string {
+	return strconv.Itoa(season) + "-" + strconv.Itoa(episode)
+}
+
+var seasonDirReg = regexp.MustCompile(`(?i)^(?:season|saison|staffel|s)[ ._-]*(\d+)$`)
+
+// returns the season number from the name of a season folder (ex: Season 01, Specials)
+func seasonFromDir(name string) (int, bool) {
+	if strings.EqualFold(name, "specials") {
+		return 0, true
+	}
+	match := seasonDirReg.FindStringSubmatch(name)
+	if len(match) > 1 {
+		num, err := strconv.Atoi(match[1])
+		return num, err == nil
+	}
+	return 0, false
+}
+
+// returns the index of the nfo files of the configured tvs
+// the index is cached as it is used for every season and episode of the tvs
+func (n *NFO) getIndex() (*NFOIndex, error) {
+	n.cacheLock.Lock()
+	defer n.cacheLock.Unlock()
+
+	if n.index != nil && n.index.Path == n.ScraperID {
+		return n.index, nil
+	}
+
+	index := NFOIndex{Path: n.ScraperID, Seasons: map[int]NFOSeasonFile{}, Episodes: map[string]NFOEpisodeFile{}}
+	err := readNFO(filepath.Join(n.ScraperID, "tvshow.nfo"), "tvshow", &index.Show)
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(n.ScraperID, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".nfo") {
+			return nil
+		}
+		name := strings.ToLower(d.Name())
+		if name == "tvshow.nfo" {
+			return nil
+		}
+		if name == "season.nfo" {
+			season := NFOSeason{}
+			if readNFO(path, "season", &season) != nil {
+				return nil
+			}
+			dir := filepath.Dir(path)
+			num, ok := seasonFromDir(filepath.Base(dir))
+			if season.SeasonNumber != nil {
+				num, ok = *season.SeasonNumber, true
+			}
+			if ok {
+				index.Seasons[num] = NFOSeasonFile{Dir: dir, Data: season}
+			}
+			return nil
+		}
+		episodes, err := readEpisodes(path)
+		if err != nil {
+			return nil
+		}
+		for _, i := range episodes {
+			index.Episodes[episodeKey(i.Season, i.Episode)] = NFOEpisodeFile{Path: path, Data: i}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n.index = &index
+	return n.index, nil
+}
+
+func (n *NFO) tvsSearchData(path string, show NFOTVShow) common.SearchData {
+	return common.SearchData{
+		Title:     first(show.Title, show.ShowTitle, show.OriginalTitle),
+		Overview:  first(show.Plot, show.Outline),
+		Icon:      image(path, show.Thumbs, "poster", "poster.jpg", "folder.jpg"),
+		Premiered: showPremiered(show),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: n.ScraperName,
+			ScraperID:   path,
+			ScraperData: "",
+			ScraperLink: "",
+		},
+	}
+}
+
+func showPremiered(show NFOTVShow) common.Date {
+	prem := common.ParseDate(show.Premiered)
+	if !prem.IsKnown() {
+		prem = common.ParseDate(show.Year)
+	}
+	return prem
+}
+
+// tvs search, nfo files can only be found from the path of the tvs
+func (n *NFO) SearchTVS(name string) ([]common.SearchData, error) {
+	return nil, errors.New("the nfo provider requires the path of the tvs")
+}
+
+// tvs search from the tvshow.nfo file stored in the tvs folder
+func (n *NFO) SearchTVSFromPath(path string) ([]common.SearchData, error) {
+	show := NFOTVShow{}
+	err := readNFO(filepath.Join(path, "tvshow.nfo"), "tvshow", &show)
+	if err != nil {
+		return nil, err
+	}
+	return []common.SearchData{n.tvsSearchData(path, show)}, nil
+}
+
+// nfo files are not indexed by external ids, only the configured tvs can be checked
+func (n *NFO) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	if n.ScraperID == "" {
+		return nil, errors.New("no data")
+	}
+	show := NFOTVShow{}
+	err := readNFO(filepath.Join(n.ScraperID, "tvshow.nfo"), "tvshow", &show)
+	if err != nil {
+		return nil, err
+	}
+	if !hasExternalID(show.UniqueIDs, []string{show.ID, show.IMDBID}, source, id) {
+		return []common.SearchData{}, nil
+	}
+	return []common.SearchData{n.tvsSearchData(n.ScraperID, show)}, nil
+}
+
+func (n *NFO) GetTVS() (common.TVSData, error) {
+	index, err := n.getIndex()
+	if err != nil {
+		return common.TVSData{}, err
+	}
+	show := index.Show
+
+	tr := trailers(show.Trailer)
+	trailer := ""
+	if len(tr) > 0 {
+		trailer = tr[0].Link
+	}
+
+	return common.TVSData{
+		Title:     first(show.Title, show.ShowTitle, show.OriginalTitle),
+		Overview:  first(show.Plot, show.Outline),
+		Icon:      image(n.ScraperID, show.Thumbs, "poster", "poster.jpg", "folder.jpg"),
+		Fanart:    image(n.ScraperID, show.Fanart, "", "fanart.jpg", "backdrop.jpg"),
+		Website:   "",
+		Trailer:   trailer,
+		Trailers:  tr,
+		Premiered: showPremiered(show),
+		Rating:    rating(show.Ratings, show.Rating),
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   n.ScraperID,
+			ScraperName: n.ScraperName,
+			ScraperData: n.ScraperData,
+			ScraperLink: "",
+		},
+	}, nil
+}
+
+// a season is built from its season.nfo file, the named seasons of the tvshow.nfo file and its artworks
+func (n *NFO) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	index, err := n.getIndex()
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+
+	data := common.TVSSeasonData{
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: n.ScraperName,
+			ScraperID:   n.ScraperID,
+			ScraperData: n.ScraperData,
+			ScraperLink: "",
+		},
+	}
+	found := false
+
+	for _, i := range index.Show.NamedSeasons {
+		if i.Number == season && strings.TrimSpace(i.Name) != "" {
+			data.Title = strings.TrimSpace(i.Name)
+			found = true
+		}
+	}
+	for _, i := range index.Show.Thumbs {
+		if i.Season == strconv.Itoa(season) && (i.Aspect == "poster" || i.Aspect == "") && data.Icon == "" {
+			data.Icon = imagePath(n.ScraperID, i.URL)
+		}
+	}
+
+	// season artworks stored in the tvs folder: season01-poster.jpg, season-specials-poster.jpg
+	prefix := fmt.Sprintf("season%02d", season)
+	if season == 0 {
+		prefix = "season-specials"
+	}
+	if data.Icon == "" {
+		data.Icon = findFile(n.ScraperID, prefix+"-poster.jpg", prefix+".jpg")
+	}
+	data.Fanart = findFile(n.ScraperID, prefix+"-fanart.jpg")
+
+	if s, ok := index.Seasons[season]; ok {
+		found = true
+		data.Title = first(s.Data.Title, data.Title)
+		data.Overview = first(s.Data.Plot, s.Data.Outline)
+		data.Premiered = common.ParseDate(first(s.Data.Premiered, s.Data.Year))
+		if icon := image(s.Dir, s.Data.Thumbs, "poster", "poster.jpg", "folder.jpg"); icon != "" && s.Dir != n.ScraperID {
+			data.Icon = icon
+		}
+		if fanart := image(s.Dir, s.Data.Fanart, "", "fanart.jpg"); fanart != "" && s.Dir != n.ScraperID {
+			data.Fanart = fanart
+		}
+	}
+
+	// if the season has no full premiere date, use the one of its first episode
+	firstAired := common.Date{}
+	for _, i := range index.Episodes {
+		if i.Data.Season != season {
+			continue
+		}
+		found = true
+		prem := common.ParseDate(first(i.Data.Aired, i.Data.Premiered))
+		if prem.Before(firstAired) {
+			firstAired = prem
+		}
+	}
+	if firstAired.IsKnown() && data.Premiered.Precision < common.DateDay {
+		data.Premiered = firstAired
+	}
+
+	if !found && data.Icon == "" {
+		return common.TVSSeasonData{}, errors.New("no data")
+	}
+	if data.Title == "" {
+		data.Title = "Season " + strconv.Itoa(season)
+		if season == 0 {
+			data.Title = "Specials"
+		}
+	}
+	return data, nil
+}
+
+func (n *NFO) getEpisode(season int, episode int) (NFOEpisodeFile, error) {
+	index, err := n.getIndex()
+	if err != nil {
+		return NFOEpisodeFile{}, err
+	}
+	ep, ok := index.Episodes[episodeKey(season, episode)]
+	if !ok {
+		return NFOEpisodeFile{}, errors.New("no data")
+	}
+	return ep, nil
+}
+
+func (n *NFO) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	ep, err := n.getEpisode(season, episode)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+
+	// the episode thumbnail is stored next to the episode: <episode>-thumb.jpg
+	dir := filepath.Dir(ep.Path)
+	base := strings.TrimSuffix(filepath.Base(ep.Path), filepath.Ext(ep.Path))
+
+	return common.TVSEpisodeData{
+		Title:     ep.Data.Title,
+		Overview:  first(ep.Data.Plot, ep.Data.Outline),
+		Icon:      image(dir, ep.Data.Thumbs, "poster", base+"-thumb.jpg", base+".jpg"),
+		Premiered: common.ParseDate(first(ep.Data.Aired, ep.Data.Premiered, ep.Data.Year)),
+		Rating:    rating(ep.Data.Ratings, ep.Data.Rating),
+		Season:    int64(season),
+		Episode:   int64(episode),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: n.ScraperName,
+			ScraperID:   n.ScraperID,
+			ScraperData: n.ScraperData,
+			ScraperLink: "",
+		},
+	}, nil
+}
+
+func (n *NFO) ListTVSTag() ([]common.TagData, error) {
+	index, err := n.getIndex()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return tags(index.Show.Genres, index.Show.Countries, index.Show.Tags), nil
+}
+
+// the studio of a tvs is its network in kodi
+func (n *NFO) ListTVSCompany() ([]common.CompanyData, error) {
+	index, err := n.getIndex()
+	if err != nil {
+		return []common.CompanyData{}, err
+	}
+	return n.companies(index.Show.Studios, common.CompanyNetwork), nil
+}
+
+func (n *NFO) ListTVSPerson() ([]common.PersonData, error) {
+	index, err := n.getIndex()
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return persons(index.Show.Actors, index.Show.Directors, index.Show.Credits), nil
+}
+
+func (n *NFO) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	ep, err := n.getEpisode(season, episode)
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return persons(ep.Data.Actors, ep.Data.Directors, ep.Data.Credits), nil
+}
+
+// returns the first episode with an air date in the future
+func (n *NFO) GetTVSUpcoming() (common.UpcomingData, error) {
+	index, err := n.getIndex()
+	if err != nil {
+		return common.UpcomingData{}, err
+	}
+
+	var next *NFOEpisode
+	var nextDate common.Date
+	for _, i := range index.Episodes {
+		ep := i.Data
+		prem := common.ParseDate(first(ep.Aired, ep.Premiered))
+		if prem.IsKnown() && prem.Time.After(time.Now()) && (next == nil || prem.Before(nextDate)) {
+			next = &ep
+			nextDate = prem
+		}
+	}
+	if next == nil {
+		return common.UpcomingData{}, errors.New("no data")
+	}
+
+	return common.UpcomingData{
+		Title:     next.Title,
+		Overview:  first(next.Plot, next.Outline),
+		Premiered: nextDate,
+		ID1:       int64(next.Season),
+		ID2:       int64(next.Episode),
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   n.ScraperID,
+			ScraperName: n.ScraperName,
+		},
+	}, nil
+}
+
+// nfo files only contain one ordering
+func (n *NFO) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	return []common.EpisodeGroupData{}, nil
+}
@@ -0,0 +1,81 @@
+package omdb
+
+import (
+	"errors"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewMovieProvider() common.MovieProvider {
+	p := New()
+	return &p
+}
+
+// movie search
+func (o *OMDB) SearchMovie(name string, year int) ([]common.SearchData, error) {
+	return o.search(name, "movie", year)
+}
+
+// movie search from an imdb id
+func (o *OMDB) FindMovieByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return o.find(source, id, "movie")
+}
+
+func (o *OMDB) GetMovie() (common.MovieData, error) {
+	item, err := o.getItem()
+	if err != nil {
+		return common.MovieData{}, err
+	}
+
+	return common.MovieData{
+		Title:      item.Title,
+		Overview:   value(item.Plot),
+		Icon:       value(item.Poster),
+		Fanart:     "",
+		Website:    value(item.Website),
+		Trailer:    "",
+		Premiered:  parseDate(first(item.Released, item.Year)),
+		Rating:     rating(item.IMDBRating),
+		Ratings:    ratings(item),
+		Awards:     value(item.Awards),
+		Collection: 0,
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   o.ScraperID,
+			ScraperName: o.ScraperName,
+			ScraperData: o.ScraperData,
+			ScraperLink: o.MediaLink(o.ScraperID),
+		},
+	}, nil
+}
+
+func (o *OMDB) GetMovieCollection() (common.MovieCollectionData, error) {
+	return common.MovieCollectionData{}, errors.New("no data")
+}
+
+func (o *OMDB) ListMoviePerson() ([]common.PersonData, error) {
+	item, err := o.getItem()
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return o.persons(item), nil
+}
+
+func (o *OMDB) ListMovieTag() ([]common.TagData, error) {
+	item, err := o.getItem()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return tags(item), nil
+}
+
+func (o *OMDB) ListMovieCompany() ([]common.CompanyData, error) {
+	item, err := o.getItem()
+	if err != nil {
+		return []common.CompanyData{}, err
+	}
+	return o.companies(item), nil
+}
+
+func (o *OMDB) GetMovieUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
@@ -0,0 +1,416 @@
+package omdb
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// provider for the OMDb api, items are identified by their imdb id
+type OMDB struct {
+	APIKey      string
+	APIURL      string // base url of the api, can be changed to use a local server
+	Plot        string // short or full
+	ScraperName string
+	ScraperID   string
+	ScraperData string
+	Logger      *log.Logger
+	item        *OMDBItem // last requested item
+	cacheLock   *sync.Mutex
+}
+
+func New() OMDB {
+	return OMDB{
+		ScraperName: "omdb",
+		APIURL:      "https://www.omdbapi.com/",
+		Plot:        "full",
+		Logger:      nil,
+		ScraperID:   "",
+		ScraperData: "",
+		cacheLock:   &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (o *OMDB) Setup(config map[string]string, logger *log.Logger) error {
+	o.Logger = logger
+	if val, ok := config["api_key"]; ok {
+		o.APIKey = val
+	} else {
+		return errors.New("empty api key")
+	}
+	if val, ok := config["api_url"]; ok && val != "" {
+		o.APIURL = val
+	}
+	if val, ok := config["plot"]; ok && val != "" {
+		o.Plot = val
+	}
+	return nil
+}
+
+func (o *OMDB) Configure(ScraperID string, ScraperData string) {
+	o.ScraperID = ScraperID
+	o.ScraperData = ScraperData
+}
+
+// helper to make a request to the api
+// the api returns a 200 status code with Response set to False on errors
+func (o *OMDB) request(params url.Values, v interface{}) error {
+	errFields := log.Fields{
+		"file":     "omdb",
+		"function": "request",
+	}
+
+	if o.APIKey == "" {
+		o.Logger.WithFields(errFields).Error("empty api key")
+		return errors.New("empty api key")
+	}
+	params.Set("apikey", o.APIKey)
+
+	u := o.APIURL + "?" + params.Encode()
+	resp, err := http.Get(u)
+	if err != nil {
+		o.Logger.WithFields(errFields).Errorf("request error: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		o.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+		return errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		o.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+		return err
+	}
+
+	status := OMDBResponse{}
+	err = json.Unmarshal(data, &status)
+	if err != nil {
+		return err
+	}
+	if status.Response != "True" {
+		if status.Error == "" {
+			status.Error = "no data"
+		}
+		return errors.New(status.Error)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// returns the item selected with Configure
+// the item is cached as it is used to list the tags, people and companies
+func (o *OMDB) getItem() (*OMDBItem, error) {
+	o.cacheLock.Lock()
+	defer o.cacheLock.Unlock()
+
+	if o.item != nil && o.item.IMDBID == o.ScraperID {
+		return o.item, nil
+	}
+
+	decode := OMDBItem{}
+	err := o.request(url.Values{"i": {o.ScraperID}, "plot": {o.Plot}}, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	o.item = &decode
+	return o.item, nil
+}
+
+// search items of the given type (movie or series)
+func (o *OMDB) search(name string, tp string, year int) ([]common.SearchData, error) {
+	params := url.Values{"s": {name}, "type": {tp}}
+	if year > 0 {
+		params.Set("y", strconv.Itoa(year))
+	}
+	decode := OMDBSearch{}
+	err := o.request(params, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, item := range decode.Search {
+		ret = append(ret, o.searchData(item.Title, "", item.Poster, item.Year, item.IMDBID))
+	}
+	return ret, nil
+}
+
+// lookup an item of the given type (movie or series) from its imdb id
+func (o *OMDB) find(source common.ExternalSource, id string, tp string) ([]common.SearchData, error) {
+	if source != common.ExternalIMDB {
+		return nil, errors.New("unsupported external source: " + string(source))
+	}
+	decode := OMDBItem{}
+	err := o.request(url.Values{"i": {id}, "plot": {"short"}}, &decode)
+	if err != nil {
+		return nil, err
+	}
+	if decode.Type != tp {
+		return []common.SearchData{}, nil
+	}
+	return []common.SearchData{o.searchData(decode.Title, decode.Plot, decode.Poster, first(decode.Released, decode.Year), decode.IMDBID)}, nil
+}
+
+func (o *OMDB) searchData(title string, overview string, poster string, released string, id string) common.SearchData {
+	return common.SearchData{
+		Title:     title,
+		Overview:  overview,
+		Icon:      value(poster),
+		Premiered: parseDate(released),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: o.ScraperName,
+			ScraperID:   id,
+			ScraperData: "",
+			ScraperLink: o.MediaLink(id),
+		},
+	}
+}
+
+func (o *OMDB) MediaLink(id string) string {
+	if id == "" {
+		return ""
+	}
+	return "https://www.imdb.com/title/" + id + "/"
+}
+
+// ratings
+
+// convert the ratings of the api (ex: 8.8/10, 87%, 74/100)
+func ratings(item *OMDBItem) []common.RatingData {
+	ret := []common.RatingData{}
+	for _, i := range item.Ratings {
+		r := common.RatingData{}
+		switch i.Source {
+		case "Internet Movie Database":
+			r.Source = common.RatingIMDB
+			r.Votes = parseInt(item.IMDBVotes)
+		case "Rotten Tomatoes":
+			r.Source = common.RatingRottenTomatoes
+		case "Metacritic":
+			r.Source = common.RatingMetacritic
+		default:
+			r.Source = strings.ToLower(i.Source)
+		}
+
+		val := strings.TrimSpace(i.Value)
+		var err error
+		if strings.HasSuffix(val, "%") {
+			r.Max = 100
+			r.Value, err = strconv.ParseFloat(strings.TrimSuffix(val, "%"), 64)
+		} else if parts := strings.SplitN(val, "/", 2); len(parts) == 2 {
+			r.Value, err = strconv.ParseFloat(parts[0], 64)
+			if err == nil {
+				r.Max, err = strconv.ParseFloat(parts[1], 64)
+			}
+		} else {
+			continue
+		}
+		if err == nil {
+			ret = append(ret, r)
+		}
+	}
+
+	// older items only have the imdb rating
+	if len(ret) == 0 {
+		if val, err := strconv.ParseFloat(item.IMDBRating, 64); err == nil {
+			ret = append(ret, common.RatingData{Source: common.RatingIMDB, Value: val, Max: 10, Votes: parseInt(item.IMDBVotes)})
+		}
+	}
+	return ret
+}
+
+// returns the imdb rating on a 0-10 scale
+func rating(val string) int64 {
+	r, _ := strconv.ParseFloat(value(val), 64)
+	return int64(r)
+}
+
+// helpers
+
+// the api uses N/A for missing values
+func value(val string) string {
+	val = strings.TrimSpace(val)
+	if val == "N/A" {
+		return ""
+	}
+	return val
+}
+
+// returns the first non empty value
+func first(values ...string) string {
+	for _, i := range values {
+		if value(i) != "" {
+			return value(i)
+		}
+	}
+	return ""
+}
+
+// parse an integer with thousands separators (ex: 2,000,000)
+func parseInt(val string) int64 {
+	n, _ := strconv.ParseInt(strings.ReplaceAll(value(val), ",", ""), 10, 64)
+	return n
+}
+
+var yearReg = regexp.MustCompile(`^\d{4}`)
+
+// parse a date of the api (ex: 16 Jul 2010, 2010-07-16, 2010 or 2010–2013 for the years of a series)
+func parseDate(val string) common.Date {
+	val = value(val)
+	if d := common.ParseDate(val); d.IsKnown() {
+		return d
+	}
+	if d := common.ParseDate(convertDate(val)); d.IsKnown() {
+		return d
+	}
+	return common.ParseDate(yearReg.FindString(val))
+}
+
+// convert a date with the format 02 Jan 2006 to 2006-01-02
+func convertDate(val string) string {
+	months := map[string]string{"Jan": "01", "Feb": "02", "Mar": "03", "Apr": "04", "May": "05", "Jun": "06", "Jul": "07", "Aug": "08", "Sep": "09", "Oct": "10", "Nov": "11", "Dec": "12"}
+	parts := strings.Fields(val)
+	if len(parts) != 3 || months[parts[1]] == "" {
+		return ""
+	}
+	if len(parts[0]) == 1 {
+		parts[0] = "0" + parts[0]
+	}
+	return parts[2] + "-" + months[parts[1]] + "-" + parts[0]
+}
+
+// split a comma separated list of the api (ex: genres, actors)
+func splitList(val string) []string {
+	ret := []string{}
+	for _, i := range strings.Split(value(val), ",") {
+		if i = strings.TrimSpace(i); i != "" {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+func (o *OMDB) persons(item *OMDBItem) []common.PersonData {
+	pers := []common.PersonData{}
+	for _, i := range splitList(item.Actors) {
+		pers = append(pers, common.PersonData{Name: i, Role: common.RoleActing, IsCharacter: false})
+	}
+	for _, i := range splitList(item.Director) {
+		pers = append(pers, common.PersonData{Name: i, Role: common.RoleDirecting, IsCharacter: false})
+	}
+	// writers can have their contribution in parentheses, ex: Jonathan Nolan (screenplay)
+	known := map[string]bool{}
+	for _, i := range splitList(item.Writer) {
+		if idx := strings.Index(i, "("); idx > 0 {
+			i = strings.TrimSpace(i[:idx])
+		}
+		if !known[i] {
+			known[i] = true
+			pers = append(pers, common.PersonData{Name: i, Role: common.RoleWriting, IsCharacter: false})
+		}
+	}
+	return pers
+}
+
+func tags(item *OMDBItem) []common.TagData {
+	ret := []common.TagData{}
+	for _, i := range splitList(item.Genre) {
+		ret = append(ret, common.TagData{Name: "genre", Value: i})
+	}
+	for _, i := range splitList(item.Country) {
+		ret = append(ret, common.TagData{Name: "country", Value: i})
+	}
+	return ret
+}
+
+// companies have no id in the api, their name is used instead
+func (o *OMDB) companies(item *OMDBItem) []common.CompanyData {
+	ret := []common.CompanyData{}
+	for _, i := range splitList(item.Production) {
+		ret = append(ret, common.CompanyData{
+			Name: i,
+			Type: common.CompanyStudio,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: o.ScraperName,
+				ScraperID:   i,
+			},
+		})
+	}
+	return ret
+}
+
+type OMDBResponse struct {
+	Response string `json:"Response"`
+	Error    string `json:"Error"`
+}
+
+type OMDBSearch struct {
+	Search []struct {
+		Title  string `json:"Title"`
+		Year   string `json:"Year"`
+		IMDBID string `json:"imdbID"`
+		Type   string `json:"Type"`
+		Poster string `json:"Poster"`
+	} `json:"Search"`
+	TotalResults string `json:"totalResults"`
+}
+
+type OMDBItem struct {
+	Title    string `json:"Title"`
+	Year     string `json:"Year"`
+	Rated    string `json:"Rated"`
+	Released string `json:"Released"`
+	Runtime  string `json:"Runtime"`
+	Genre    string `json:"Genre"`
+	Director string `json:"Director"`
+	Writer   string `json:"Writer"`
+	Actors   string `json:"Actors"`
+	Plot     string `json:"Plot"`
+	Language string `json:"Language"`
+	Country  string `json:"Country"`
+	Awards   string `json:"Awards"`
+	Poster   string `json:"Poster"`
+	Ratings  []struct {
+		Source string `json:"Source"`
+		Value  string `json:"Value"`
+	} `json:"Ratings"`
+	Metascore    string `json:"Metascore"`
+	IMDBRating   string `json:"imdbRating"`
+	IMDBVotes    string `json:"imdbVotes"`
+	IMDBID       string `json:"imdbID"`
+	Type         string `json:"Type"`
+	TotalSeasons string `json:"totalSeasons"`
+	SeriesID     string `json:"seriesID"`
+	Season       string `json:"Season"`
+	Episode      string `json:"Episode"`
+	DVD          string `json:"DVD"`
+	BoxOffice    string `json:"BoxOffice"`
+	Production   string `json:"Production"`
+	Website      string `json:"Website"`
+}
+
+type OMDBSeason struct {
+	Title        string `json:"Title"`
+	Season       string `json:"Season"`
+	TotalSeasons string `json:"totalSeasons"`
+	Episodes     []struct {
+		Title      string `json:"Title"`
+		Released   string `json:"Released"`
+		Episode    string `json:"Episode"`
+		IMDBRating string `json:"imdbRating"`
+		IMDBID     string `json:"imdbID"`
+	} `json:"Episodes"`
+}
@@ -0,0 +1,165 @@
+package omdb
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+// tvs search
+func (o *OMDB) SearchTVS(name string) ([]common.SearchData, error) {
+	return o.search(name, "series", 0)
+}
+
+// tvs search from an imdb id
+func (o *OMDB) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return o.find(source, id, "series")
+}
+
+// tvs get show
+func (o *OMDB) GetTVS() (common.TVSData, error) {
+	item, err := o.getItem()
+	if err != nil {
+		return common.TVSData{}, err
+	}
+
+	return common.TVSData{
+		Title:     item.Title,
+		Overview:  value(item.Plot),
+		Icon:      value(item.Poster),
+		Fanart:    "",
+		Website:   value(item.Website),
+		Trailer:   "",
+		Premiered: parseDate(first(item.Released, item.Year)),
+		Rating:    rating(item.IMDBRating),
+		Ratings:   ratings(item),
+		Awards:    value(item.Awards),
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   o.ScraperID,
+			ScraperName: o.ScraperName,
+			ScraperData: o.ScraperData,
+			ScraperLink: o.MediaLink(o.ScraperID),
+		},
+	}, nil
+}
+
+func (o *OMDB) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	decode := OMDBSeason{}
+	err := o.request(url.Values{"i": {o.ScraperID}, "Season": {strconv.Itoa(season)}}, &decode)
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+	if len(decode.Episodes) == 0 {
+		return common.TVSSeasonData{}, errors.New("no data")
+	}
+
+	prem := common.Date{}
+	vote := 0.0
+	count := 0
+	for _, i := range decode.Episodes {
+		if d := parseDate(i.Released); d.Before(prem) {
+			prem = d
+		}
+		if r, err := strconv.ParseFloat(value(i.IMDBRating), 64); err == nil {
+			vote += r
+			count++
+		}
+	}
+	if count > 0 {
+		vote /= float64(count)
+	}
+
+	return common.TVSSeasonData{
+		Title:     "Season " + strconv.Itoa(season),
+		Overview:  "",
+		Icon:      "",
+		Fanart:    "",
+		Trailer:   "",
+		Premiered: prem,
+		Rating:    int64(vote),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: o.ScraperName,
+			ScraperID:   o.ScraperID,
+			ScraperData: o.ScraperData,
+			ScraperLink: "",
+		},
+	}, nil
+}
+
+// returns the episode at the given position
+func (o *OMDB) getEpisode(season int, episode int) (OMDBItem, error) {
+	decode := OMDBItem{}
+	err := o.request(url.Values{"i": {o.ScraperID}, "Season": {strconv.Itoa(season)}, "Episode": {strconv.Itoa(episode)}, "plot": {o.Plot}}, &decode)
+	return decode, err
+}
+
+// get tvs episode
+func (o *OMDB) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	decode, err := o.getEpisode(season, episode)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+
+	return common.TVSEpisodeData{
+		Title:     decode.Title,
+		Overview:  value(decode.Plot),
+		Icon:      value(decode.Poster),
+		Premiered: parseDate(decode.Released),
+		Rating:    rating(decode.IMDBRating),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: o.ScraperName,
+			ScraperID:   o.ScraperID,
+			ScraperData: o.ScraperData,
+			ScraperLink: o.MediaLink(decode.IMDBID),
+		},
+	}, nil
+}
+
+// list the actors, directors and writers of an episode
+func (o *OMDB) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	decode, err := o.getEpisode(season, episode)
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return o.persons(&decode), nil
+}
+
+func (o *OMDB) ListTVSTag() ([]common.TagData, error) {
+	item, err := o.getItem()
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	return tags(item), nil
+}
+
+func (o *OMDB) ListTVSCompany() ([]common.CompanyData, error) {
+	item, err := o.getItem()
+	if err != nil {
+		return []common.CompanyData{}, err
+	}
+	return o.companies(item), nil
+}
+
+func (o *OMDB) ListTVSPerson() ([]common.PersonData, error) {
+	item, err := o.getItem()
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return o.persons(item), nil
+}
+
+func (o *OMDB) GetTVSUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+// the api does not provide alternative episode orderings
+func (o *OMDB) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	return []common.EpisodeGroupData{}, nil
+}
@@ -0,0 +1,308 @@
+package openlibrary
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewBookProvider() common.BookProvider {
+	p := New()
+	return &p
+}
+
+// book search, the results are works
+func (o *OpenLibrary) SearchBook(title string, author string) ([]common.SearchData, error) {
+	params := url.Values{
+		"title":  {title},
+		"limit":  {strconv.Itoa(o.SearchMaxResults)},
+		"fields": {"key,title,subtitle,author_name,first_publish_year,cover_i"},
+	}
+	if author != "" {
+		params.Set("author", author)
+	}
+	decode := OLSearch{}
+	err := o.request("search.json?"+params.Encode(), &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, i := range decode.Docs {
+		prem := common.Date{}
+		if i.FirstPublishYear > 0 {
+			prem = common.ParseDate(strconv.Itoa(i.FirstPublishYear))
+		}
+		ret = append(ret, common.SearchData{
+			Title:     i.Title,
+			Overview:  strings.Join(i.AuthorName, ", "),
+			Icon:      o.ImageURL(i.CoverI),
+			Premiered: prem,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: o.ScraperName,
+				ScraperID:   id(i.Key),
+				ScraperData: "",
+				ScraperLink: o.MediaLink(i.Key),
+			},
+		})
+	}
+	return ret, nil
+}
+
+// book search from an isbn, the edition with this isbn is selected in ScraperData
+func (o *OpenLibrary) FindBookByExternalID(source common.ExternalSource, val string) ([]common.SearchData, error) {
+	if source != common.ExternalISBN {
+		return nil, errors.New("unsupported external source: " + string(source))
+	}
+
+	decode := OLEdition{}
+	err := o.request("isbn/"+url.PathEscape(normalizeISBN(val))+".json", &decode)
+	if err != nil {
+		return nil, err
+	}
+	if len(decode.Works) == 0 {
+		return []common.SearchData{}, nil
+	}
+
+	cover := 0
+	if len(decode.Covers) > 0 {
+		cover = decode.Covers[0]
+	}
+	return []common.SearchData{{
+		Title:     decode.Title,
+		Overview:  decode.ByStatement,
+		Icon:      o.ImageURL(cover),
+		Premiered: parseDate(decode.PublishDate),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: o.ScraperName,
+			ScraperID:   id(decode.Works[0].Key),
+			ScraperData: id(decode.Key),
+			ScraperLink: o.MediaLink(decode.Works[0].Key),
+		},
+	}}, nil
+}
+
+// returns the work and its editions
+// the data is cached as it is used for the book, its editions, tags and people
+func (o *OpenLibrary) getWork() (*OLWork, []OLEdition, error) {
+	o.cacheLock.Lock()
+	defer o.cacheLock.Unlock()
+
+	if o.work != nil && id(o.work.Key) == o.ScraperID {
+		return o.work, o.editions, nil
+	}
+
+	work := OLWork{}
+	err := o.request("works/"+url.PathEscape(o.ScraperID)+".json", &work)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	editions := []OLEdition{}
+	for len(editions) < maxEditions {
+		decode := OLEditions{}
+		params := url.Values{"limit": {"50"}, "offset": {strconv.Itoa(len(editions))}}
+		err = o.request("works/"+url.PathEscape(o.ScraperID)+"/editions.json?"+params.Encode(), &decode)
+		if err != nil {
+			return nil, nil, err
+		}
+		editions = append(editions, decode.Entries...)
+		if len(decode.Entries) == 0 || decode.Links.Next == "" || len(editions) >= decode.Size {
+			break
+		}
+	}
+
+	o.work = &work
+	o.editions = editions
+	return o.work, o.editions, nil
+}
+
+// returns the edition selected with ScraperData, nil if no edition is selected
+func (o *OpenLibrary) selectedEdition(editions []OLEdition) *OLEdition {
+	if o.ScraperData == "" {
+		return nil
+	}
+	for i := range editions {
+		if id(editions[i].Key) == o.ScraperData {
+			return &editions[i]
+		}
+	}
+	return nil
+}
+
+// returns the names of the authors of the work
+func (o *OpenLibrary) authors(work *OLWork) ([]string, error) {
+	ret := []string{}
+	for _, i := range work.Authors {
+		decode := OLAuthor{}
+		err := o.request(strings.TrimPrefix(i.Author.Key, "/")+".json", &decode)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, decode.Name)
+	}
+	return ret, nil
+}
+
+// get the data of the book, the cover, type and series of the selected edition are used if an edition is selected
+// the series is read from the editions, as the works have no series
+func (o *OpenLibrary) GetBook() (common.BookData, error) {
+	work, editions, err := o.getWork()
+	if err != nil {
+		return common.BookData{}, err
+	}
+	authors, err := o.authors(work)
+	if err != nil {
+		return common.BookData{}, err
+	}
+
+	cover := 0
+	if len(work.Covers) > 0 {
+		cover = work.Covers[0]
+	}
+	format := ""
+	series := []string{}
+	if e := o.selectedEdition(editions); e != nil {
+		if len(e.Covers) > 0 {
+			cover = e.Covers[0]
+		}
+		format = e.PhysicalFormat
+		series = e.Series
+	}
+	if len(series) == 0 {
+		for _, i := range editions {
+			if len(i.Series) > 0 {
+				series = i.Series
+				break
+			}
+		}
+	}
+	seriesName, seriesIndex := "", float64(0)
+	if len(series) > 0 {
+		seriesName, seriesIndex = parseSeries(series[0])
+	}
+
+	// the first publication date is missing for many works, the oldest edition is used instead
+	prem := parseDate(work.FirstPublishDate)
+	if !prem.IsKnown() {
+		for _, i := range editions {
+			if d := parseDate(i.PublishDate); d.IsKnown() && (!prem.IsKnown() || d.Before(prem)) {
+				prem = d
+			}
+		}
+	}
+
+	return common.BookData{
+		Title:       work.Title,
+		Subtitle:    work.Subtitle,
+		Author:      strings.Join(authors, ", "),
+		Overview:    text(work.Description),
+		Icon:        o.ImageURL(cover),
+		Type:        bookType(format, work.Subjects),
+		Premiered:   prem,
+		Series:      seriesName,
+		SeriesIndex: seriesIndex,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: o.ScraperName,
+			ScraperID:   o.ScraperID,
+			ScraperData: o.ScraperData,
+			ScraperLink: o.MediaLink("/works/" + o.ScraperID),
+		},
+	}, nil
+}
+
+// list the editions of the book, the ScraperData of an edition can be used to select it
+func (o *OpenLibrary) ListBookEdition() ([]common.BookEditionData, error) {
+	ret := []common.BookEditionData{}
+
+	work, editions, err := o.getWork()
+	if err != nil {
+		return ret, err
+	}
+
+	for _, i := range editions {
+		cover, publisher, language := 0, "", ""
+		if len(i.Covers) > 0 {
+			cover = i.Covers[0]
+		}
+		if len(i.Publishers) > 0 {
+			publisher = i.Publishers[0]
+		}
+		if len(i.Languages) > 0 {
+			language = id(i.Languages[0].Key)
+		}
+		title := i.Title
+		if i.Subtitle != "" {
+			title += ": " + i.Subtitle
+		}
+		ret = append(ret, common.BookEditionData{
+			Title:     title,
+			Publisher: publisher,
+			ISBN:      isbn(i),
+			Language:  language,
+			Format:    i.PhysicalFormat,
+			Type:      bookType(i.PhysicalFormat, work.Subjects),
+			Pages:     int64(i.NumberOfPages),
+			Icon:      o.ImageURL(cover),
+			Premiered: parseDate(i.PublishDate),
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: o.ScraperName,
+				ScraperID:   o.ScraperID,
+				ScraperData: id(i.Key),
+				ScraperLink: o.MediaLink(i.Key),
+			},
+		})
+	}
+
+	return ret, nil
+}
+
+// the subjects of the work are used as tags
+func (o *OpenLibrary) ListBookTag() ([]common.TagData, error) {
+	tags := []common.TagData{}
+
+	work, _, err := o.getWork()
+	if err != nil {
+		return tags, err
+	}
+
+	for _, i := range work.Subjects {
+		tags = append(tags, common.TagData{Name: "tag", Value: i})
+	}
+	for _, i := range work.SubjectPlaces {
+		tags = append(tags, common.TagData{Name: "place", Value: i})
+	}
+	for _, i := range work.SubjectTimes {
+		tags = append(tags, common.TagData{Name: "period", Value: i})
+	}
+
+	return tags, nil
+}
+
+// list the authors of the book
+func (o *OpenLibrary) ListBookPerson() ([]common.PersonData, error) {
+	pers := []common.PersonData{}
+
+	work, _, err := o.getWork()
+	if err != nil {
+		return pers, err
+	}
+	authors, err := o.authors(work)
+	if err != nil {
+		return pers, err
+	}
+
+	// the role of the authors is a job title (ex: Illustrator), the authors without role wrote the book
+	for n, i := range authors {
+		role := common.RoleWriting
+		if work.Authors[n].Role != "" {
+			role = common.JobRole(work.Authors[n].Role)
+		}
+		pers = append(pers, common.PersonData{Name: i, Role: role, IsCharacter: false})
+	}
+
+	return pers, nil
+}
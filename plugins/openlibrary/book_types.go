@@ -0,0 +1,71 @@
+package openlibrary
+
+import "encoding/json"
+
+// reference to another record (ex: {"key": "/authors/OL23919A"})
+type OLKey struct {
+	Key string `json:"key"`
+}
+
+type OLSearch struct {
+	NumFound int `json:"numFound"`
+	Docs     []struct {
+		Key              string   `json:"key"` // ex: /works/OL82563W
+		Title            string   `json:"title"`
+		Subtitle         string   `json:"subtitle"`
+		AuthorName       []string `json:"author_name"`
+		FirstPublishYear int      `json:"first_publish_year"`
+		CoverI           int      `json:"cover_i"`
+	} `json:"docs"`
+}
+
+type OLWork struct {
+	Key              string          `json:"key"`
+	Title            string          `json:"title"`
+	Subtitle         string          `json:"subtitle"`
+	Description      json.RawMessage `json:"description"` // string or text object
+	Covers           []int           `json:"covers"`
+	Subjects         []string        `json:"subjects"`
+	SubjectPlaces    []string        `json:"subject_places"`
+	SubjectTimes     []string        `json:"subject_times"`
+	FirstPublishDate string          `json:"first_publish_date"`
+	Authors          []struct {
+		Author OLKey  `json:"author"`
+		Role   string `json:"role"`
+	} `json:"authors"`
+}
+
+type OLEdition struct {
+	Key            string   `json:"key"` // ex: /books/OL7353617M
+	Title          string   `json:"title"`
+	Subtitle       string   `json:"subtitle"`
+	Publishers     []string `json:"publishers"`
+	PublishDate    string   `json:"publish_date"` // free format, ex: 1997, June 26 1997
+	ISBN10         []string `json:"isbn_10"`
+	ISBN13         []string `json:"isbn_13"`
+	Languages      []OLKey  `json:"languages"`
+	PhysicalFormat string   `json:"physical_format"`
+	NumberOfPages  int      `json:"number_of_pages"`
+	Covers         []int    `json:"covers"`
+	Series         []string `json:"series"` // ex: Harry Potter -- 1
+	Works          []OLKey  `json:"works"`
+	ByStatement    string   `json:"by_statement"`
+}
+
+type OLEditions struct {
+	Size    int         `json:"size"`
+	Entries []OLEdition `json:"entries"`
+	Links   struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+type OLAuthor struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// text with a type (ex: {"type": "/type/text", "value": "..."})
+type OLText struct {
+	Value string `json:"value"`
+}
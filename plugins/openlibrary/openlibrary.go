@@ -0,0 +1,209 @@
+package openlibrary
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// maximum number of editions requested for a book
+const maxEditions = 200
+
+// provider for the Open Library api
+// ScraperID is the id of a work (ex: OL82563W), ScraperData can be the id of one of its editions (ex: OL7353617M)
+type OpenLibrary struct {
+	APIURL           string // url of the api, can be changed to use a local server
+	CoverURL         string // url of the covers
+	SearchMaxResults int
+	ScraperName      string
+	ScraperID        string
+	ScraperData      string
+	Logger           *log.Logger
+	work             *OLWork     // last requested work
+	editions         []OLEdition // editions of the last requested work
+	cacheLock        *sync.Mutex
+}
+
+func New() OpenLibrary {
+	return OpenLibrary{
+		ScraperName:      "openlibrary",
+		APIURL:           "https://openlibrary.org",
+		CoverURL:         "https://covers.openlibrary.org",
+		SearchMaxResults: 20,
+		Logger:           nil,
+		ScraperID:        "",
+		ScraperData:      "",
+		cacheLock:        &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (o *OpenLibrary) Setup(config map[string]string, logger *log.Logger) error {
+	o.Logger = logger
+	if val, ok := config["api_url"]; ok && val != "" {
+		o.APIURL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["cover_url"]; ok && val != "" {
+		o.CoverURL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["search_max_results"]; ok {
+		if n, err := strconv.Atoi(val); err == nil && n > 0 {
+			o.SearchMaxResults = n
+		}
+	}
+	return nil
+}
+
+func (o *OpenLibrary) Configure(ScraperID string, ScraperData string) {
+	o.ScraperID = ScraperID
+	o.ScraperData = ScraperData
+}
+
+// helper to make a request to the api (ex: works/OL82563W.json), data is decoded in v
+func (o *OpenLibrary) request(link string, v interface{}) error {
+	errFields := log.Fields{
+		"file":     "openlibrary",
+		"function": "request",
+	}
+
+	u := o.APIURL + "/" + link
+	resp, err := http.Get(u)
+	if err != nil {
+		o.Logger.WithFields(errFields).Errorf("request error: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		o.Logger.WithFields(errFields).Infof("requested url: %s", u)
+		o.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+		return errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		o.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// returns the url of a cover from its id, empty if the id is unknown
+func (o *OpenLibrary) ImageURL(id int) string {
+	if id <= 0 {
+		return ""
+	}
+	return o.CoverURL + "/b/id/" + strconv.Itoa(id) + "-L.jpg"
+}
+
+func (o *OpenLibrary) MediaLink(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "https://openlibrary.org" + key
+}
+
+// helpers
+
+// returns the id of a record from its key (ex: /works/OL82563W -> OL82563W)
+func id(key string) string {
+	return key[strings.LastIndex(key, "/")+1:]
+}
+
+// texts are either strings or text objects
+func text(raw json.RawMessage) string {
+	var val string
+	if json.Unmarshal(raw, &val) == nil {
+		return strings.TrimSpace(val)
+	}
+	t := OLText{}
+	if json.Unmarshal(raw, &t) == nil {
+		return strings.TrimSpace(t.Value)
+	}
+	return ""
+}
+
+var (
+	dayDateReg   = regexp.MustCompile(`(?i)^([a-z]{3})[a-z]*\.?\s+(\d{1,2}),?\s+(\d{4})$`) // ex: June 26 1997
+	monthDateReg = regexp.MustCompile(`(?i)^([a-z]{3})[a-z]*\.?\s+(\d{4})$`)               // ex: June 1997
+	yearReg      = regexp.MustCompile(`\d{4}`)
+	seriesReg    = regexp.MustCompile(`(?i)^(.*?)[\s,;:#(\-–]*(?:(?:bk|book|vol|volume|no|tome|t|part)\.?\s*)?#?(\d+(?:\.\d+)?)\)?\s*$`)
+	separatorReg = regexp.MustCompile(`(?i)[\s,;:(\-–]+$`)
+	seriesSufReg = regexp.MustCompile(`(?i)\s+series$`)
+	isbnReg      = regexp.MustCompile(`[^0-9Xx]`)
+)
+
+// convert a publication date of the api (ex: 1997, June 26 1997, Jun 26, 1997, 1997-06-26)
+func parseDate(val string) common.Date {
+	val = strings.TrimSpace(val)
+	if d := common.ParseDate(val); d.IsKnown() {
+		return d
+	}
+
+	months := map[string]string{"jan": "01", "feb": "02", "mar": "03", "apr": "04", "may": "05", "jun": "06", "jul": "07", "aug": "08", "sep": "09", "oct": "10", "nov": "11", "dec": "12"}
+	match := dayDateReg.FindStringSubmatch(val)
+	if len(match) == 4 && months[strings.ToLower(match[1])] != "" {
+		day := match[2]
+		if len(day) == 1 {
+			day = "0" + day
+		}
+		return common.ParseDate(match[3] + "-" + months[strings.ToLower(match[1])] + "-" + day)
+	}
+	match = monthDateReg.FindStringSubmatch(val)
+	if len(match) == 3 && months[strings.ToLower(match[1])] != "" {
+		return common.ParseDate(match[2] + "-" + months[strings.ToLower(match[1])])
+	}
+	return common.ParseDate(yearReg.FindString(val))
+}
+
+// split the name of a series and the position of the book (ex: Harry Potter -- 1, The Wheel of Time ; bk. 3, Discworld #2.5)
+func parseSeries(val string) (string, float64) {
+	match := seriesReg.FindStringSubmatch(strings.TrimSpace(val))
+	if len(match) < 3 || match[1] == "" {
+		return strings.TrimSpace(val), 0
+	}
+	index, _ := strconv.ParseFloat(match[2], 64)
+	name := separatorReg.ReplaceAllString(match[1], "")
+	name = seriesSufReg.ReplaceAllString(name, "")
+	return name, index
+}
+
+// returns the type of a book from the format of an edition and the subjects of the work
+func bookType(format string, subjects []string) string {
+	format = strings.ToLower(format)
+	for _, i := range []string{"audio", "mp3", "cassette"} {
+		if strings.Contains(format, i) {
+			return common.BookAudiobook
+		}
+	}
+	for _, i := range subjects {
+		i = strings.ToLower(i)
+		if strings.Contains(i, "comic") || strings.Contains(i, "graphic novel") || strings.Contains(i, "manga") {
+			return common.BookComic
+		}
+	}
+	return common.BookBook
+}
+
+// returns the isbn of an edition, isbn 13 is preferred
+func isbn(e OLEdition) string {
+	if len(e.ISBN13) > 0 {
+		return e.ISBN13[0]
+	}
+	if len(e.ISBN10) > 0 {
+		return e.ISBN10[0]
+	}
+	return ""
+}
+
+// removes the separators of an isbn (ex: 978-0-7475-3269-9)
+func normalizeISBN(val string) string {
+	return strings.ToUpper(isbnReg.ReplaceAllString(val, ""))
+}
@@ -0,0 +1,35 @@
+package rss
+
+import (
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewPodcastProvider() common.PodcastProvider {
+	p := New()
+	return &p
+}
+
+func (r *RSS) GetPodcast() (common.PodcastData, error) {
+	feed, err := r.getFeed(r.ScraperID)
+	if err != nil {
+		return common.PodcastData{}, err
+	}
+	return feed.Podcast, nil
+}
+
+func (r *RSS) ListPodcastEpisode() ([]common.PodcastEpisodeData, error) {
+	feed, err := r.getFeed(r.ScraperID)
+	if err != nil {
+		return []common.PodcastEpisodeData{}, err
+	}
+	return append([]common.PodcastEpisodeData{}, feed.Episodes...), nil
+}
+
+// returns the episode of a downloaded file, matched by guid, enclosure name, date or title
+func (r *RSS) MatchPodcastEpisode(path string) (common.PodcastEpisodeData, error) {
+	feed, err := r.getFeed(r.ScraperID)
+	if err != nil {
+		return common.PodcastEpisodeData{}, err
+	}
+	return matchEpisode(feed.Episodes, path)
+}
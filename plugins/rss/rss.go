@@ -0,0 +1,488 @@
+package rss
+
+import (
+	"encoding/xml"
+	"errors"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// files containing the url of the feed of a podcast, stored in the folder of the podcast
+// the file contains the url or is an internet shortcut with an URL= line
+var feedFiles = []string{"feed.url", "podcast.url"}
+
+// number of parent folders searched for a feed file from the path of an episode
+const maxFeedDepth = 3
+
+// provider for the rss and atom feeds of the podcasts
+// ScraperID is the url of the feed, local feed files are also supported
+type RSS struct {
+	UserAgent     string
+	CacheDuration time.Duration // duration for which a downloaded feed is reused
+	ScraperName   string
+	ScraperID     string
+	ScraperData   string
+	Logger        *log.Logger
+	feed          *ParsedFeed // last requested feed
+	cacheLock     *sync.Mutex
+}
+
+func New() RSS {
+	return RSS{
+		ScraperName:   "rss",
+		UserAgent:     "zogwine-metadata",
+		CacheDuration: 10 * time.Minute,
+		Logger:        nil,
+		ScraperID:     "",
+		ScraperData:   "",
+		cacheLock:     &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (r *RSS) Setup(config map[string]string, logger *log.Logger) error {
+	r.Logger = logger
+	if val, ok := config["user_agent"]; ok && val != "" {
+		r.UserAgent = val
+	}
+	if val, ok := config["cache_duration"]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return errors.New("invalid cache duration: " + val)
+		}
+		r.CacheDuration = d
+	}
+	return nil
+}
+
+func (r *RSS) Configure(ScraperID string, ScraperData string) {
+	r.ScraperID = ScraperID
+	r.ScraperData = ScraperData
+}
+
+// download a feed, the link can be an url or the path of a local file
+func (r *RSS) download(link string) ([]byte, error) {
+	errFields := log.Fields{
+		"file":     "rss",
+		"function": "download",
+	}
+
+	if !strings.HasPrefix(link, "http://") && !strings.HasPrefix(link, "https://") {
+		return os.ReadFile(strings.TrimPrefix(link, "file://"))
+	}
+	req, err := http.NewRequest("GET", link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", r.UserAgent)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		r.Logger.WithFields(errFields).Errorf("request error: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		r.Logger.WithFields(errFields).Infof("requested url: %s", link)
+		r.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+		return nil, errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// returns the parsed feed, the last feed is cached as it is used for every episode of the podcast
+func (r *RSS) getFeed(link string) (*ParsedFeed, error) {
+	r.cacheLock.Lock()
+	defer r.cacheLock.Unlock()
+
+	if link == "" {
+		return nil, errors.New("no feed url")
+	}
+	if r.feed != nil && r.feed.URL == link && time.Since(r.feed.Date) < r.CacheDuration {
+		return r.feed, nil
+	}
+	data, err := r.download(link)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := r.parseFeed(link, data)
+	if err != nil {
+		return nil, err
+	}
+	r.feed = &feed
+	return r.feed, nil
+}
+
+// convert a rss or atom feed to the podcast format
+func (r *RSS) parseFeed(link string, data []byte) (ParsedFeed, error) {
+	decode := Feed{}
+	if err := xml.Unmarshal(data, &decode); err != nil {
+		return ParsedFeed{}, err
+	}
+	feed := ParsedFeed{URL: link, Date: time.Now(), Tags: []string{}, Episodes: []common.PodcastEpisodeData{}}
+	info := common.ScraperInfo{ScraperName: r.ScraperName, ScraperID: link, ScraperData: "", ScraperLink: ""}
+
+	if c := decode.Channel; c != nil {
+		feed.Podcast = common.PodcastData{
+			Title:       firstString(c.Title, c.ITunesTitle),
+			Overview:    text(c.Description, c.Summary),
+			Icon:        image(c.ITunesImage, c.Image.URL),
+			Website:     strings.TrimSpace(c.Link),
+			Author:      strings.TrimSpace(c.Author),
+			Language:    strings.TrimSpace(c.Language),
+			ScraperInfo: info,
+		}
+		feed.Podcast.ScraperLink = feed.Podcast.Website
+		feed.Tags = categories(c.Categories)
+		for _, i := range c.Items {
+			feed.Episodes = append(feed.Episodes, common.PodcastEpisodeData{
+				GUID:          firstString(i.GUID, i.Enclosure.URL, i.Link),
+				Title:         firstString(i.Title, i.ITunesTitle),
+				Overview:      text(i.Summary, i.Description, i.Content),
+				Icon:          image(i.ITunesImage, mediaURL(i.Thumbnail)),
+				Premiered:     parseDate(i.PubDate),
+				Duration:      duration(i.Duration),
+				Season:        number(i.Season),
+				Episode:       number(i.Episode),
+				EnclosureURL:  strings.TrimSpace(i.Enclosure.URL),
+				EnclosureType: i.Enclosure.Type,
+				EnclosureSize: size(i.Enclosure.Length),
+				ScraperInfo:   common.ScraperInfo{ScraperName: r.ScraperName, ScraperID: link, ScraperData: "", ScraperLink: strings.TrimSpace(i.Link)},
+			})
+		}
+	} else if decode.Title != "" || len(decode.Entries) > 0 {
+		feed.Podcast = common.PodcastData{
+			Title:       strings.TrimSpace(decode.Title),
+			Overview:    text(decode.Subtitle),
+			Icon:        firstString(decode.Logo, decode.Icon),
+			Website:     atomLink(decode.Links, "alternate").Href,
+			Author:      strings.TrimSpace(decode.Author),
+			ScraperInfo: info,
+		}
+		feed.Podcast.ScraperLink = feed.Podcast.Website
+		for _, i := range decode.Entries {
+			enclosure := atomLink(i.Links, "enclosure")
+			page := atomLink(i.Links, "alternate").Href
+			feed.Episodes = append(feed.Episodes, common.PodcastEpisodeData{
+				GUID:          firstString(i.ID, enclosure.Href, page),
+				Title:         strings.TrimSpace(i.Title),
+				Overview:      text(i.Summary, i.Content),
+				Icon:          mediaURL(i.Thumbnail),
+				Premiered:     parseDate(firstString(i.Published, i.Updated)),
+				Duration:      duration(i.Duration),
+				Season:        -1,
+				Episode:       -1,
+				EnclosureURL:  enclosure.Href,
+				EnclosureType: enclosure.Type,
+				EnclosureSize: size(enclosure.Length),
+				ScraperInfo:   common.ScraperInfo{ScraperName: r.ScraperName, ScraperID: link, ScraperData: "", ScraperLink: page},
+			})
+		}
+	} else {
+		return ParsedFeed{}, errors.New("not a rss or atom feed: " + link)
+	}
+
+	numberEpisodes(feed.Episodes)
+	for i := range feed.Episodes {
+		feed.Episodes[i].ScraperData = feed.Episodes[i].GUID
+	}
+	return feed, nil
+}
+
+// sort the episodes by publication date and set their season and episode numbers
+// the numbers of the feed are used if available, else the season is the year of publication
+// and the episode is the month, the day and the rank of the episode in the day (ex: 3152 for the second episode of march 15),
+// so that the numbers do not change when old episodes are removed from the feed
+// the episodes without number nor date are added to the season 0 and numbered by their position in the feed
+func numberEpisodes(episodes []common.PodcastEpisodeData) {
+	sort.SliceStable(episodes, func(i, j int) bool {
+		a, b := episodes[i].Premiered, episodes[j].Premiered
+		if a.IsKnown() != b.IsKnown() {
+			return a.IsKnown()
+		}
+		if a.IsKnown() && a.Time.Equal(b.Time) {
+			return episodes[i].GUID < episodes[j].GUID
+		}
+		return a.Before(b)
+	})
+	days := map[string]int64{}
+	count := int64(0)
+	for i := range episodes {
+		e := &episodes[i]
+		if e.Episode > 0 {
+			if e.Season < 0 {
+				e.Season = 1
+			}
+			continue
+		}
+		if !e.Premiered.IsKnown() {
+			count++
+			e.Season = 0
+			e.Episode = count
+			continue
+		}
+		t := e.Premiered.Time
+		days[t.Format("2006-01-02")]++
+		e.Season = int64(t.Year())
+		e.Episode = (int64(t.Month())*100+int64(t.Day()))*10 + days[t.Format("2006-01-02")]
+	}
+}
+
+// returns the url of the feed file of a podcast folder
+func feedURL(dir string) (string, error) {
+	for _, name := range feedFiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(strings.ToUpper(line), "URL=") {
+				return strings.TrimSpace(line[4:]), nil
+			}
+			if line != "" && !strings.HasPrefix(line, "[") && !strings.Contains(line, "=") {
+				return line, nil
+			}
+		}
+	}
+	return "", errors.New("no feed file in: " + dir)
+}
+
+// returns the url of the feed of a podcast from the path of one of its files
+// the feed file is searched in the folder of the file and in its parent folders
+func findFeedURL(path string) (string, error) {
+	dir := filepath.Dir(path)
+	for i := 0; i < maxFeedDepth; i++ {
+		if link, err := feedURL(dir); err == nil {
+			return link, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return "", errors.New("no feed file for: " + path)
+}
+
+// returns the episode of a downloaded file
+// the file is matched by the guid or the enclosure name contained in its name, then by the date of publication
+// and then by the title of the episode
+func matchEpisode(episodes []common.PodcastEpisodeData, path string) (common.PodcastEpisodeData, error) {
+	base := filepath.Base(path)
+	name := normalize(strings.TrimSuffix(base, filepath.Ext(base)))
+	if name == "" {
+		return common.PodcastEpisodeData{}, errors.New("no data")
+	}
+
+	for _, i := range episodes {
+		if guid := normalize(i.GUID); len(guid) >= 8 && strings.Contains(name, guid) {
+			return i, nil
+		}
+		if enclosure := normalize(enclosureName(i.EnclosureURL)); len(enclosure) >= 3 && enclosure == name {
+			return i, nil
+		}
+	}
+
+	if date, ok := fileDate(base); ok {
+		for _, days := range []float64{0, 1} {
+			candidates := []common.PodcastEpisodeData{}
+			for _, i := range episodes {
+				if i.Premiered.IsKnown() && sameDay(i.Premiered.Time, date, days) {
+					candidates = append(candidates, i)
+				}
+			}
+			if len(candidates) == 0 {
+				continue
+			}
+			for _, i := range candidates {
+				if title := normalize(i.Title); title != "" && strings.Contains(name, title) {
+					return i, nil
+				}
+			}
+			return candidates[0], nil
+		}
+	}
+
+	match := -1
+	for index, i := range episodes {
+		title := normalize(i.Title)
+		if len(title) >= 4 && strings.Contains(name, title) && (match < 0 || len(title) > len(normalize(episodes[match].Title))) {
+			match = index
+		}
+	}
+	if match >= 0 {
+		return episodes[match], nil
+	}
+	return common.PodcastEpisodeData{}, errors.New("no episode of the feed matches: " + path)
+}
+
+// helpers
+
+// date formats of the rss feeds, the rfc 822 dates are often written with small variations
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+}
+
+func parseDate(value string) common.Date {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return common.NewDate(t, common.DateTime)
+		}
+	}
+	return common.ParseDate(value)
+}
+
+var (
+	fileDateReg = regexp.MustCompile(`(?:^|\D)((?:19|20)\d\d)[-._ ]?(\d\d)[-._ ]?(\d\d)(?:\D|$)`)
+	htmlTagReg  = regexp.MustCompile(`<[^>]*>`)
+	wordSepReg  = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// returns the date in the name of a file (ex: 2024-03-15, 2024.03.15 or 20240315)
+func fileDate(name string) (time.Time, bool) {
+	for _, match := range fileDateReg.FindAllStringSubmatch(name, -1) {
+		t, err := time.Parse("2006-01-02", match[1]+"-"+match[2]+"-"+match[3])
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// the date of a file is written in the timezone of the podcast, the publication date is converted to utc
+// so a difference of one day is also accepted
+func sameDay(published time.Time, day time.Time, days float64) bool {
+	published = time.Date(published.Year(), published.Month(), published.Day(), 0, 0, 0, 0, time.UTC)
+	diff := published.Sub(day).Hours() / 24
+	return diff <= days && diff >= -days
+}
+
+// returns the duration in seconds of a duration written as seconds, MM:SS or HH:MM:SS
+func duration(val string) int64 {
+	var ret int64
+	for _, i := range strings.Split(strings.TrimSpace(val), ":") {
+		n, err := strconv.ParseFloat(i, 64)
+		if err != nil || n < 0 {
+			return 0
+		}
+		ret = ret*60 + int64(n)
+	}
+	return ret
+}
+
+// returns the number of a value, -1 if it is not a number
+func number(val string) int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// returns the size of an enclosure, 0 if it is unknown
+func size(val string) int64 {
+	n := number(val)
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// returns the first non empty text, html descriptions are converted to text
+func text(values ...string) string {
+	for _, i := range values {
+		i = htmlTagReg.ReplaceAllString(i, " ")
+		i = strings.Join(strings.Fields(html.UnescapeString(i)), " ")
+		if i != "" {
+			return i
+		}
+	}
+	return ""
+}
+
+func firstString(values ...string) string {
+	for _, i := range values {
+		if i = strings.TrimSpace(i); i != "" {
+			return i
+		}
+	}
+	return ""
+}
+
+func image(images []ITunesImage, fallback string) string {
+	for _, i := range images {
+		if i.Href != "" {
+			return strings.TrimSpace(i.Href)
+		}
+	}
+	return strings.TrimSpace(fallback)
+}
+
+func mediaURL(values []MediaURL) string {
+	for _, i := range values {
+		if i.URL != "" {
+			return strings.TrimSpace(i.URL)
+		}
+	}
+	return ""
+}
+
+// returns the first link with the given relation, the relation of the atom links is alternate by default
+func atomLink(links []AtomLink, rel string) AtomLink {
+	for _, i := range links {
+		if i.Rel == rel || (i.Rel == "" && rel == "alternate") {
+			return i
+		}
+	}
+	return AtomLink{}
+}
+
+// returns the itunes categories and subcategories
+func categories(values []ITunesCategory) []string {
+	ret := []string{}
+	for _, i := range values {
+		if i.Text != "" {
+			ret = append(ret, i.Text)
+		}
+		ret = append(ret, categories(i.Categories)...)
+	}
+	return ret
+}
+
+// returns the name of the file of an enclosure url, without its extension
+func enclosureName(link string) string {
+	link = strings.SplitN(strings.SplitN(link, "?", 2)[0], "#", 2)[0]
+	name := link[strings.LastIndex(link, "/")+1:]
+	if val, err := url.PathUnescape(name); err == nil {
+		name = val
+	}
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// lowercase value containing only letters and digits, used to compare the names of the files
+func normalize(val string) string {
+	return strings.Join(wordSepReg.Split(strings.ToLower(val), -1), "")
+}
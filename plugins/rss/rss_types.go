@@ -0,0 +1,100 @@
+package rss
+
+import (
+	"time"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// feed converted to the podcast format, cached as it is used for every episode of the podcast
+type ParsedFeed struct {
+	URL      string
+	Date     time.Time // date of the download
+	Podcast  common.PodcastData
+	Tags     []string // itunes categories
+	Episodes []common.PodcastEpisodeData
+}
+
+// root of the feed, rss feeds have a channel element and atom feeds have entries
+type Feed struct {
+	Channel  *RSSChannel `xml:"channel"`
+	Title    string      `xml:"http://www.w3.org/2005/Atom title"`
+	Subtitle string      `xml:"http://www.w3.org/2005/Atom subtitle"`
+	Links    []AtomLink  `xml:"http://www.w3.org/2005/Atom link"`
+	Logo     string      `xml:"http://www.w3.org/2005/Atom logo"`
+	Icon     string      `xml:"http://www.w3.org/2005/Atom icon"`
+	Author   string      `xml:"http://www.w3.org/2005/Atom author>name"`
+	Entries  []AtomEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type ITunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type ITunesCategory struct {
+	Text       string           `xml:"text,attr"`
+	Categories []ITunesCategory `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category"`
+}
+
+// the elements of the extensions with the same name as the rss elements (ex: atom:link, itunes:title)
+// are read in their own fields as the fields without namespace match every namespace
+type RSSChannel struct {
+	AtomLinks   []AtomLink       `xml:"http://www.w3.org/2005/Atom link"`
+	ITunesTitle string           `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd title"`
+	Title       string           `xml:"title"`
+	Link        string           `xml:"link"`
+	Description string           `xml:"description"`
+	Language    string           `xml:"language"`
+	Author      string           `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+	Summary     string           `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	ITunesImage []ITunesImage    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	Categories  []ITunesCategory `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category"`
+	Image       struct {
+		URL string `xml:"url"`
+	} `xml:"image"`
+	Items []RSSItem `xml:"item"`
+}
+
+type RSSItem struct {
+	ITunesTitle string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd title"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Content     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Summary     string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Enclosure   struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length string `xml:"length,attr"`
+	} `xml:"enclosure"`
+	Duration    string        `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	ITunesImage []ITunesImage `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	Season      string        `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd season"`
+	Episode     string        `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode"`
+	Thumbnail   []MediaURL    `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type MediaURL struct {
+	URL string `xml:"url,attr"`
+}
+
+type AtomLink struct {
+	Rel    string `xml:"rel,attr"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type AtomEntry struct {
+	ID        string     `xml:"http://www.w3.org/2005/Atom id"`
+	Title     string     `xml:"http://www.w3.org/2005/Atom title"`
+	Summary   string     `xml:"http://www.w3.org/2005/Atom summary"`
+	Content   string     `xml:"http://www.w3.org/2005/Atom content"`
+	Published string     `xml:"http://www.w3.org/2005/Atom published"`
+	Updated   string     `xml:"http://www.w3.org/2005/Atom updated"`
+	Links     []AtomLink `xml:"http://www.w3.org/2005/Atom link"`
+	Thumbnail []MediaURL `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	Duration  string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+}
@@ -0,0 +1,184 @@
+package rss
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// video podcasts stored in the tvs libraries, the folder of the podcast contains a feed file with the url of its feed
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+func episodeData(e common.PodcastEpisodeData) common.TVSEpisodeData {
+	return common.TVSEpisodeData{
+		Title:       e.Title,
+		Overview:    e.Overview,
+		Icon:        e.Icon,
+		Premiered:   e.Premiered,
+		Season:      e.Season,
+		Episode:     e.Episode,
+		ScraperInfo: e.ScraperInfo,
+	}
+}
+
+// the podcast premiered with its first episode
+func premiered(episodes []common.PodcastEpisodeData, season int64) common.Date {
+	for _, i := range episodes {
+		if i.Premiered.IsKnown() && (season < 0 || i.Season == season) {
+			return i.Premiered
+		}
+	}
+	return common.Date{}
+}
+
+// tvs search, the feed can only be found from the path of the podcast
+func (r *RSS) SearchTVS(name string) ([]common.SearchData, error) {
+	return nil, errors.New("the rss provider requires the path of the podcast")
+}
+
+// tvs search from the feed file of the podcast folder
+func (r *RSS) SearchTVSFromPath(path string) ([]common.SearchData, error) {
+	link, err := feedURL(path)
+	if err != nil {
+		return []common.SearchData{}, nil
+	}
+	feed, err := r.getFeed(link)
+	if err != nil {
+		return nil, err
+	}
+	return []common.SearchData{{
+		Title:       feed.Podcast.Title,
+		Overview:    feed.Podcast.Overview,
+		Icon:        feed.Podcast.Icon,
+		Premiered:   premiered(feed.Episodes, -1),
+		ScraperInfo: feed.Podcast.ScraperInfo,
+	}}, nil
+}
+
+// the feeds do not contain external ids
+func (r *RSS) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	return nil, errors.New("unsupported external source: " + string(source))
+}
+
+// returns the episode of a downloaded file from the feed of its podcast, the file names of the podcasts
+// do not contain the season and episode numbers
+func (r *RSS) GetTVSEpisodeFromFile(path string) (common.TVSEpisodeData, error) {
+	link, err := findFeedURL(path)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+	feed, err := r.getFeed(link)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+	e, err := matchEpisode(feed.Episodes, path)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+	return episodeData(e), nil
+}
+
+func (r *RSS) GetTVS() (common.TVSData, error) {
+	feed, err := r.getFeed(r.ScraperID)
+	if err != nil {
+		return common.TVSData{}, err
+	}
+	return common.TVSData{
+		Title:       feed.Podcast.Title,
+		Overview:    feed.Podcast.Overview,
+		Icon:        feed.Podcast.Icon,
+		Website:     feed.Podcast.Website,
+		Trailers:    []common.TrailerData{},
+		Premiered:   premiered(feed.Episodes, -1),
+		ScraperInfo: feed.Podcast.ScraperInfo,
+	}, nil
+}
+
+// seasons are named from their number, which is the year of publication for the podcasts without numbers
+func (r *RSS) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	feed, err := r.getFeed(r.ScraperID)
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+	title := "Season " + strconv.Itoa(season)
+	if season == 0 {
+		title = "Specials"
+	} else if season > 1000 {
+		title = strconv.Itoa(season)
+	}
+	count := 0
+	for _, i := range feed.Episodes {
+		if i.Season == int64(season) {
+			count++
+		}
+	}
+	return common.TVSSeasonData{
+		Title:        title,
+		Premiered:    premiered(feed.Episodes, int64(season)),
+		EpisodeCount: int64(count),
+		ScraperInfo:  feed.Podcast.ScraperInfo,
+	}, nil
+}
+
+func (r *RSS) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	feed, err := r.getFeed(r.ScraperID)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+	for _, i := range feed.Episodes {
+		if i.Season == int64(season) && i.Episode == int64(episode) {
+			return episodeData(i), nil
+		}
+	}
+	return common.TVSEpisodeData{}, errors.New("no data")
+}
+
+func (r *RSS) ListTVSEpisode() ([]common.TVSEpisodeData, error) {
+	feed, err := r.getFeed(r.ScraperID)
+	if err != nil {
+		return []common.TVSEpisodeData{}, err
+	}
+	ret := []common.TVSEpisodeData{}
+	for _, i := range feed.Episodes {
+		ret = append(ret, episodeData(i))
+	}
+	return ret, nil
+}
+
+// the itunes categories are used as the genres of the podcast
+func (r *RSS) ListTVSTag() ([]common.TagData, error) {
+	feed, err := r.getFeed(r.ScraperID)
+	if err != nil {
+		return []common.TagData{}, err
+	}
+	ret := []common.TagData{}
+	for _, i := range feed.Tags {
+		ret = append(ret, common.TagData{Name: "genre", Value: i})
+	}
+	return ret, nil
+}
+
+func (r *RSS) ListTVSCompany() ([]common.CompanyData, error) {
+	return []common.CompanyData{}, nil
+}
+
+// the author of the podcast is not linked to a person, it is often the name of the company or of the show
+func (r *RSS) ListTVSPerson() ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (r *RSS) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	return []common.PersonData{}, nil
+}
+
+func (r *RSS) GetTVSUpcoming() (common.UpcomingData, error) {
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+func (r *RSS) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	return []common.EpisodeGroupData{}, nil
+}
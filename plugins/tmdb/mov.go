@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/url"
+	"sort"
 	"strconv"
 	"time"
 
@@ -19,39 +20,45 @@ func NewMovieProvider() common.MovieProvider {
 func (t *TMDB) SearchMovie(name string, year int) ([]common.SearchData, error) {
 	// retreive data with pagination
 	data := TMDBMovieSearch{}
-	page := 1
-	for {
-		params := url.Values{}
-		params.Add("query", name)
-		if year != 0 {
-			params.Add("year", strconv.Itoa(year))
-		}
-		raw, err := t.request("search/movie?"+params.Encode(), page)
-		if err != nil {
-			return nil, err
-		}
+	params := url.Values{}
+	params.Add("query", name)
+	params.Add("include_adult", strconv.FormatBool(t.IncludeAdult))
+	if t.Region != "" {
+		params.Add("region", t.Region)
+	}
+	if year != 0 && t.PrimaryReleaseYear {
+		params.Add("primary_release_year", strconv.Itoa(year))
+	} else if year != 0 {
+		params.Add("year", strconv.Itoa(year))
+	}
+	err := t.requestPages("search/movie?"+params.Encode(), func(raw []byte) (int, error) {
 		decode := TMDBMovieSearch{}
-		err = json.Unmarshal(raw, &decode)
-		if err != nil {
-			return nil, err
-		}
+		err := json.Unmarshal(raw, &decode)
 		data.Results = append(data.Results, decode.Results...)
-		if decode.Page < decode.TotalPages {
-			page++
-		} else {
-			break
-		}
+		return decode.TotalPages, err
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// keep the most popular results, the results are truncated after the adult filter
+	sort.SliceStable(data.Results, func(i, j int) bool {
+		return data.Results[i].Popularity > data.Results[j].Popularity
+	})
+
 	// process data
 	ret := make([]common.SearchData, 0)
 	for _, item := range data.Results {
-		prem, _ := time.Parse("2006-01-02", item.ReleaseDate)
+		if item.Adult && !t.IncludeAdult {
+			continue
+		}
+		prem := common.ParseDate(item.ReleaseDate)
 		sd := common.SearchData{
 			Title:     item.Title,
 			Overview:  item.Overview,
-			Icon:      t.ImageURL(item.PosterPath),
-			Premiered: prem.Unix(),
+			Icon:      t.ImageURL(ImagePoster, item.PosterPath),
+			Premiered: prem,
+			Adult:     item.Adult,
 			ScraperInfo: common.ScraperInfo{
 				ScraperName: t.ScraperName,
 				ScraperID:   strconv.Itoa(item.ID),
@@ -60,11 +67,50 @@ func (t *TMDB) SearchMovie(name string, year int) ([]common.SearchData, error) {
 			},
 		}
 		ret = append(ret, sd)
+		if t.SearchMaxResults > 0 && len(ret) >= t.SearchMaxResults {
+			break
+		}
 	}
 
 	return ret, nil
 }
 
+// mov search from an external id (imdb, tvdb, ...)
+func (t *TMDB) FindMovieByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	decode, err := t.find(source, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, item := range decode.MovieResults {
+		if item.Adult && !t.IncludeAdult {
+			continue
+		}
+		prem := common.ParseDate(item.ReleaseDate)
+		ret = append(ret, common.SearchData{
+			Title:     item.Title,
+			Overview:  item.Overview,
+			Icon:      t.ImageURL(ImagePoster, item.PosterPath),
+			Premiered: prem,
+			Adult:     item.Adult,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   strconv.Itoa(item.ID),
+				ScraperData: "",
+				ScraperLink: t.MediaLink(3, strconv.Itoa(item.ID), "", ""),
+			},
+		})
+	}
+
+	return ret, nil
+}
+
+// list the movies modified since a given date
+func (t *TMDB) MovieChangedSince(since time.Time) ([]string, error) {
+	return t.changes("movie", since)
+}
+
 // get movie data
 func (t *TMDB) GetMovie() (common.MovieData, error) {
 	// get movie details
@@ -78,27 +124,24 @@ func (t *TMDB) GetMovie() (common.MovieData, error) {
 		return common.MovieData{}, err
 	}
 
-	// get associated videos (to extract trailer)
-	raw, err = t.request("movie/"+t.ScraperID+"/videos", 1)
-	if err != nil {
-		return common.MovieData{}, err
-	}
-	vid := TMDBVideo{}
-	err = json.Unmarshal(raw, &vid)
+	// get associated videos (to extract trailers)
+	trailers, err := t.getTrailers("movie/" + t.ScraperID)
 	if err != nil {
 		return common.MovieData{}, err
 	}
 
-	prem, _ := time.Parse("2006-01-02", decode.ReleaseDate)
+	prem := common.ParseDate(decode.ReleaseDate)
 	return common.MovieData{
 		Title:      decode.Title,
 		Overview:   decode.Overview,
-		Icon:       t.ImageURL(decode.PosterPath),
-		Fanart:     t.ImageURL(decode.BackdropPath),
+		Icon:       t.ImageURL(ImagePoster, decode.PosterPath),
+		Fanart:     t.ImageURL(ImageBackdrop, decode.BackdropPath),
 		Website:    decode.Homepage,
-		Trailer:    t.getTrailerFromVideo(vid),
-		Premiered:  prem.Unix(),
+		Trailer:    common.SelectTrailer(trailers, t.TrailerPolicy).Link,
+		Trailers:   trailers,
+		Premiered:  prem,
 		Rating:     int64(decode.VoteAverage),
+		Ratings:    []common.RatingData{{Source: t.ScraperName, Value: decode.VoteAverage, Max: 10, Votes: int64(decode.VoteCount)}},
 		Collection: int64(decode.BelongsToCollection.ID),
 		ScraperInfo: common.ScraperInfo{
 			ScraperID:   t.ScraperID,
@@ -121,14 +164,14 @@ func (t *TMDB) GetMovieCollection() (common.MovieCollectionData, error) {
 	if err != nil {
 		return common.MovieCollectionData{}, err
 	}
-	prem, _ := time.Parse("2006-01-02", decode.Parts[0].ReleaseDate)
+	prem := common.ParseDate(decode.Parts[0].ReleaseDate)
 
 	return common.MovieCollectionData{
 		Title:     decode.Name,
 		Overview:  decode.Overview,
-		Icon:      t.ImageURL(decode.PosterPath),
-		Fanart:    t.ImageURL(decode.BackdropPath),
-		Premiered: prem.Unix(),
+		Icon:      t.ImageURL(ImagePoster, decode.PosterPath),
+		Fanart:    t.ImageURL(ImageBackdrop, decode.BackdropPath),
+		Premiered: prem,
 		Rating:    int64(decode.Parts[0].VoteAverage),
 		ScraperInfo: common.ScraperInfo{
 			ScraperID:   t.ScraperID,
@@ -155,7 +198,8 @@ func (t *TMDB) ListMoviePerson() ([]common.PersonData, error) {
 	for _, i := range decode.Cast {
 		pers = append(pers, common.PersonData{
 			Name:        i.Name,
-			Role:        i.KnownForDepartment,
+			Role:        common.RoleActing,
+			Character:   i.Character,
 			IsCharacter: false,
 		})
 	}
@@ -163,7 +207,7 @@ func (t *TMDB) ListMoviePerson() ([]common.PersonData, error) {
 	for _, i := range decode.Crew {
 		pers = append(pers, common.PersonData{
 			Name:        i.Name,
-			Role:        i.KnownForDepartment,
+			Role:        i.Department,
 			IsCharacter: false,
 		})
 	}
@@ -191,15 +235,32 @@ func (t *TMDB) ListMovieTag() ([]common.TagData, error) {
 		})
 	}
 
+	return tags, nil
+}
+
+// list the production companies of the movie
+func (t *TMDB) ListMovieCompany() ([]common.CompanyData, error) {
+	companies := []common.CompanyData{}
+
+	raw, err := t.request("movie/"+t.ScraperID, 1)
+	if err != nil {
+		return companies, err
+	}
+	decode := TMDBMovie{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return companies, err
+	}
+
+	known := map[int]bool{}
 	for _, i := range decode.ProductionCompanies {
-		tags = append(tags, common.TagData{
-			Name:  "production",
-			Value: i.Name,
-			Icon:  t.ImageURL(i.LogoPath),
-		})
+		if !known[i.ID] {
+			known[i.ID] = true
+			companies = append(companies, t.companyData(common.CompanyStudio, i.ID, i.Name, i.LogoPath, i.OriginCountry))
+		}
 	}
 
-	return tags, nil
+	return companies, nil
 }
 
 func (t *TMDB) GetMovieUpcoming() (common.UpcomingData, error) {
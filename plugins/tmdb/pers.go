@@ -2,8 +2,9 @@ package tmdb
 
 import (
 	"encoding/json"
+	"net/url"
+	"sort"
 	"strconv"
-	"time"
 
 	"github.com/zogwine/metadata/internal/providers/common"
 )
@@ -16,22 +17,69 @@ func NewPersonProvider() common.PersonProvider {
 func (t *TMDB) SearchPerson(name string) ([]common.SearchData, error) {
 	ret := make([]common.SearchData, 0)
 
-	raw, err := t.request("movie/"+t.ScraperID, 1)
+	// retreive data with pagination
+	data := TMDBPersonSearch{}
+	params := url.Values{}
+	params.Add("query", name)
+	params.Add("include_adult", strconv.FormatBool(t.IncludeAdult))
+	err := t.requestPages("search/person?"+params.Encode(), func(raw []byte) (int, error) {
+		decode := TMDBPersonSearch{}
+		err := json.Unmarshal(raw, &decode)
+		data.Results = append(data.Results, decode.Results...)
+		return decode.TotalPages, err
+	})
 	if err != nil {
 		return ret, err
 	}
-	decode := TMDBPersonSearch{}
-	err = json.Unmarshal(raw, &decode)
+
+	// keep the most popular results, the results are truncated after the adult filter
+	sort.SliceStable(data.Results, func(i, j int) bool {
+		return data.Results[i].Popularity > data.Results[j].Popularity
+	})
+
+	for _, item := range data.Results {
+		if item.Adult && !t.IncludeAdult {
+			continue
+		}
+		ret = append(ret, common.SearchData{
+			Title:     item.Name,
+			Overview:  "",
+			Icon:      t.ImageURL(ImageProfile, item.ProfilePath),
+			Premiered: common.Date{},
+			Adult:     item.Adult,
+			ScraperInfo: common.ScraperInfo{
+				ScraperID:   strconv.Itoa(item.ID),
+				ScraperName: t.ScraperName,
+				ScraperData: "",
+				ScraperLink: t.MediaLink(5, strconv.Itoa(item.ID), "", ""),
+			},
+		})
+		if t.SearchMaxResults > 0 && len(ret) >= t.SearchMaxResults {
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+// person search from an external id (imdb, wikidata, ...)
+func (t *TMDB) FindPersonByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	decode, err := t.find(source, id)
 	if err != nil {
-		return ret, err
+		return nil, err
 	}
 
-	for _, item := range decode.Results {
+	ret := make([]common.SearchData, 0)
+	for _, item := range decode.PersonResults {
+		if item.Adult && !t.IncludeAdult {
+			continue
+		}
 		ret = append(ret, common.SearchData{
 			Title:     item.Name,
 			Overview:  "",
-			Icon:      t.ImageURL(item.ProfilePath),
-			Premiered: 0,
+			Icon:      t.ImageURL(ImageProfile, item.ProfilePath),
+			Premiered: common.Date{},
+			Adult:     item.Adult,
 			ScraperInfo: common.ScraperInfo{
 				ScraperID:   strconv.Itoa(item.ID),
 				ScraperName: t.ScraperName,
@@ -55,21 +103,18 @@ func (t *TMDB) GetPerson() (common.PersonDetails, error) {
 		return common.PersonDetails{}, err
 	}
 
-	birth, _ := time.Parse("2006-01-02", decode.Birthday)
-	deathdate := int64(0)
-	death, err := time.Parse("2006-01-02", decode.Deathday)
-	if err == nil {
-		deathdate = death.Unix()
-	}
 	return common.PersonDetails{
 		Name:        decode.Name,
-		Birthdate:   birth.Unix(),
-		Deathdate:   deathdate,
+		Birthdate:   common.ParseDate(decode.Birthday),
+		Deathdate:   common.ParseDate(decode.Deathday),
 		Gender:      int64(decode.Gender),
+		Aliases:     decode.AlsoKnownAs,
+		Birthplace:  decode.PlaceOfBirth,
 		Description: decode.Biography,
-		Icon:        t.ImageURL(decode.ProfilePath),
+		Icon:        t.ImageURL(ImageProfile, decode.ProfilePath),
 		Rating:      int64(decode.Popularity),
 		KnownFor:    decode.KnownForDepartment,
+		ExternalIDs: externalIDs(common.ExternalIMDB, decode.IMDBID),
 		ScraperInfo: common.ScraperInfo{
 			ScraperID:   t.ScraperID,
 			ScraperName: t.ScraperName,
@@ -78,3 +123,11 @@ func (t *TMDB) GetPerson() (common.PersonDetails, error) {
 		},
 	}, nil
 }
+
+// returns the external id if it is known
+func externalIDs(source common.ExternalSource, id string) []common.ExternalID {
+	if id == "" {
+		return []common.ExternalID{}
+	}
+	return []common.ExternalID{{Source: source, ID: id}}
+}
@@ -1,190 +1,540 @@
-package tmdb
-
-import (
-	"errors"
-	"io"
-	"net/http"
-	"net/url"
-	"strconv"
-	"strings"
-	"time"
-
-	log "github.com/sirupsen/logrus"
-)
-
-type TMDB struct {
-	APIKey      string
-	Language    string
-	ScraperName string
-	ScraperID   string
-	ScraperData string
-	Logger      *log.Logger
-}
-
-func New() TMDB {
-	return TMDB{ScraperName: "tmdb", Language: "en-US", Logger: nil, ScraperID: "", ScraperData: ""}
-}
-
-// configure the provider's settings
-func (t *TMDB) Setup(config map[string]string, logger *log.Logger) error {
-	t.Logger = logger
-	if val, ok := config["api_key"]; ok {
-		t.APIKey = val
-	} else {
-		return errors.New("empty api key")
-	}
-	if val, ok := config["language"]; ok {
-		t.Language = val
-	}
-	return nil
-}
-
-func (p *TMDB) Configure(ScraperID string, ScraperData string) {
-	p.ScraperID = ScraperID
-	p.ScraperData = ScraperData
-}
-
-// helper to make a request to the api
-func (t *TMDB) request(link string, page int) ([]byte, error) {
-	errFields := log.Fields{
-		"file":     "tmdb",
-		"function": "request",
-		"code":     false,
-	}
-
-	if link == "" {
-		t.Logger.WithFields(errFields).Error("empty url")
-		return nil, errors.New("empty url")
-	}
-	if t.APIKey == "" {
-		t.Logger.WithFields(errFields).Error("empty api key")
-		return nil, errors.New("empty api key")
-	}
-
-	param := "?"
-	m, _ := url.ParseQuery(link)
-	for _, v := range m {
-		if len(v) > 0 && v[0] != "" {
-			param = "&"
-		}
-	}
-
-	u := "https://api.themoviedb.org/3/" + link + param + "api_key=" + t.APIKey + "&page=" + strconv.Itoa(page) + "&language=" + t.Language
-	resp, err := http.Get(u)
-
-	if err != nil || resp.StatusCode != 200 {
-		t.Logger.WithFields(errFields).Infof("requested url: %s", u)
-		t.Logger.WithFields(errFields).Errorf("request error: %v - status code: %d", err, resp.StatusCode)
-		return nil, err
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		t.Logger.WithFields(errFields).Errorf("request read error: %v", err)
-		return nil, err
-	}
-
-	return data, nil
-}
-
-func (t *TMDB) ImageURL(id string) string {
-	if id == "" {
-		return ""
-	}
-	return "https://image.tmdb.org/t/p/w500/" + id
-}
-
-func (t *TMDB) MediaLink(tp int, id1 string, id2 string, id3 string) string {
-	if id1 == "" {
-		return ""
-	}
-	if tp == 0 {
-		return "https://www.themoviedb.org/tv/" + id1
-	} else if tp == 1 {
-		if id2 == "" {
-			return ""
-		}
-		return "https://www.themoviedb.org/tv/" + id1 + "/season/" + id2
-	} else if tp == 2 {
-		if id2 == "" || id3 == "" {
-			return ""
-		}
-		return "https://www.themoviedb.org/tv/" + id1 + "/season/" + id2 + "/episode/" + id3
-	} else if tp == 3 {
-		return "https://www.themoviedb.org/movie/" + id1
-	} else if tp == 4 {
-		return "https://www.themoviedb.org/collection/" + id1
-	} else if tp == 5 {
-		return "https://www.themoviedb.org/person/" + id1
-	}
-	return ""
-}
-
-// trailer
-
-func (t *TMDB) getTrailerFromVideo(data TMDBVideo) string {
-	layout := "2006-01-02T15:04:05.000Z"
-
-	type kv map[string]string
-	trailers := make([]kv, 4)
-	for i := range trailers {
-		trailers[i] = map[string]string{}
-	}
-
-	// sort videos in 4 categories, Official Trailers, Official Videos, Unofficial Trailer, Unofficial Videos
-	for _, item := range data.Results {
-		link := "https://www.youtube.com/watch?v=" + item.Key
-		if item.Site == "YouTube" {
-			if item.Official {
-				if strings.Contains(strings.ToLower(item.Name), "trailer") {
-					trailers[0][item.PublishedAt] = link
-				} else {
-					trailers[1][item.PublishedAt] = link
-				}
-			} else {
-				if strings.Contains(strings.ToLower(item.Name), "trailer") {
-					trailers[2][item.PublishedAt] = link
-				} else {
-					trailers[3][item.PublishedAt] = link
-				}
-			}
-		}
-	}
-
-	// return the oldest date from the first non empty category
-	// ideally this would return the first official trailer for a given media
-	for _, tr := range trailers {
-		dt := ""
-		for k := range tr {
-			if dt == "" {
-				dt = k
-			} else {
-				t1, _ := time.Parse(layout, k)
-				t2, _ := time.Parse(layout, dt)
-				if t1.Unix() < t2.Unix() {
-					dt = k
-				}
-			}
-			if dt != "" {
-				return tr[dt]
-			}
-		}
-	}
-	return ""
-}
-
-type TMDBVideo struct {
-	ID      int `json:"id"`
-	Results []struct {
-		ISO6391     string `json:"iso_639_1"`
-		ISO31661    string `json:"iso_3166_1"`
-		Name        string `json:"name"`
-		Key         string `json:"key"`
-		Site        string `json:"site"`
-		Size        int    `json:"size"`
-		Type        string `json:"type"`
-		Official    bool   `json:"official"`
-		PublishedAt string `json:"published_at"`
-		ID          string `json:"id"`
-	} `json:"results"`
-}
+package tmdb
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// artwork types, used to select the size of the images returned by the api
+const (
+	ImagePoster   = "poster"
+	ImageBackdrop = "backdrop"
+	ImageStill    = "still"
+	ImageLogo     = "logo"
+	ImageProfile  = "profile"
+)
+
+// maximum number of days of changes returned by the api
+const changesMaxDays = 14
+
+// delay before requesting the api configuration again when it could not be retreived
+const configRetryDelay = 10 * time.Minute
+
+type TMDB struct {
+	APIKey             string
+	Language           string
+	ImageSizes         map[string]string // artwork type -> size (ex: w500, original)
+	SearchMaxPages     int               // maximum number of pages requested for a search, 0 for no limit
+	SearchMaxResults   int               // maximum number of results returned by a search, 0 for no limit
+	TrailerPolicy      common.TrailerPolicy
+	IncludeAdult       bool   // include adult content in search results
+	Region             string // iso 3166-1 code used to filter movie release dates
+	FirstAirDateYear   bool   // search shows by first air date if the name ends with a year, ex: "Show (2019)"
+	PrimaryReleaseYear bool   // search movies by primary release year instead of any release year
+	ScraperName        string
+	ScraperID          string
+	ScraperData        string
+	Logger             *log.Logger
+	config             *TMDBConfiguration
+	configRetry        time.Time             // date of the next configuration request if config is the fallback, zero once the configuration is retreived
+	episodeGroup       *TMDBEpisodeGroupData // last requested episode group
+	cacheLock          *sync.Mutex
+}
+
+func New() TMDB {
+	return TMDB{
+		ScraperName: "tmdb",
+		Language:    "en-US",
+		ImageSizes: map[string]string{
+			ImagePoster:   "w500",
+			ImageBackdrop: "w1280",
+			ImageStill:    "w300",
+			ImageLogo:     "w500",
+			ImageProfile:  "h632",
+		},
+		SearchMaxPages:   2,
+		SearchMaxResults: 20,
+		TrailerPolicy:    common.NewTrailerPolicy(),
+		Logger:           nil,
+		ScraperID:        "",
+		ScraperData:      "",
+		cacheLock:        &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (t *TMDB) Setup(config map[string]string, logger *log.Logger) error {
+	t.Logger = logger
+	if val, ok := config["api_key"]; ok {
+		t.APIKey = val
+	} else {
+		return errors.New("empty api key")
+	}
+	if val, ok := config["language"]; ok {
+		t.Language = val
+	}
+	// trailer selection
+	t.TrailerPolicy.Languages = []string{strings.Split(t.Language, "-")[0]}
+	if val, ok := config["trailer_languages"]; ok {
+		t.TrailerPolicy.Languages = splitList(val)
+	}
+	if val, ok := config["trailer_types"]; ok {
+		t.TrailerPolicy.Types = splitList(val)
+	}
+	if val, ok := config["trailer_sites"]; ok {
+		t.TrailerPolicy.Sites = splitList(val)
+	}
+	if val, ok := config["trailer_official_only"]; ok {
+		t.TrailerPolicy.OfficialOnly = val == "true"
+	}
+	if val, ok := config["trailer_order"]; ok {
+		t.TrailerPolicy.Newest = val == "newest"
+	}
+	// search filters
+	if val, ok := config["include_adult"]; ok {
+		t.IncludeAdult = val == "true"
+	}
+	if val, ok := config["region"]; ok {
+		t.Region = val
+	}
+	if val, ok := config["first_air_date_year"]; ok {
+		t.FirstAirDateYear = val == "true"
+	}
+	if val, ok := config["primary_release_year"]; ok {
+		t.PrimaryReleaseYear = val == "true"
+	}
+	if val, ok := config["search_max_pages"]; ok {
+		if n, err := strconv.Atoi(val); err == nil {
+			t.SearchMaxPages = n
+		}
+	}
+	if val, ok := config["search_max_results"]; ok {
+		if n, err := strconv.Atoi(val); err == nil {
+			t.SearchMaxResults = n
+		}
+	}
+	// image size for each artwork type (ex: poster_size: original)
+	for tp := range t.ImageSizes {
+		if val, ok := config[tp+"_size"]; ok && val != "" {
+			t.ImageSizes[tp] = val
+		}
+	}
+	return nil
+}
+
+func (p *TMDB) Configure(ScraperID string, ScraperData string) {
+	p.ScraperID = ScraperID
+	p.ScraperData = ScraperData
+}
+
+// helper to make a request to the api
+func (t *TMDB) request(link string, page int) ([]byte, error) {
+	errFields := log.Fields{
+		"file":     "tmdb",
+		"function": "request",
+		"code":     false,
+	}
+
+	if link == "" {
+		t.Logger.WithFields(errFields).Error("empty url")
+		return nil, errors.New("empty url")
+	}
+	if t.APIKey == "" {
+		t.Logger.WithFields(errFields).Error("empty api key")
+		return nil, errors.New("empty api key")
+	}
+
+	param := "?"
+	m, _ := url.ParseQuery(link)
+	for _, v := range m {
+		if len(v) > 0 && v[0] != "" {
+			param = "&"
+		}
+	}
+
+	u := "https://api.themoviedb.org/3/" + link + param + "api_key=" + t.APIKey + "&page=" + strconv.Itoa(page) + "&language=" + t.Language
+	resp, err := http.Get(u)
+
+	if err != nil || resp.StatusCode != 200 {
+		t.Logger.WithFields(errFields).Infof("requested url: %s", u)
+		t.Logger.WithFields(errFields).Errorf("request error: %v - status code: %d", err, resp.StatusCode)
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// helper to request the pages of a search, at most SearchMaxPages pages are requested
+// decode is called for each page and returns the total number of pages
+func (t *TMDB) requestPages(link string, decode func(raw []byte) (int, error)) error {
+	for page := 1; t.SearchMaxPages <= 0 || page <= t.SearchMaxPages; page++ {
+		raw, err := t.request(link, page)
+		if err != nil {
+			return err
+		}
+		totalPages, err := decode(raw)
+		if err != nil {
+			return err
+		}
+		if page >= totalPages {
+			break
+		}
+	}
+	return nil
+}
+
+// list the ids of the items (tv, movie or person) modified since a given date
+// the api only allows to request 14 days at once, older dates return common.ErrChangesTooOld so everything is refreshed
+func (t *TMDB) changes(kind string, since time.Time) ([]string, error) {
+	ids := []string{}
+	known := map[int]bool{}
+	now := time.Now()
+
+	if since.Before(now.AddDate(0, 0, -changesMaxDays)) {
+		return ids, common.ErrChangesTooOld
+	}
+	link := kind + "/changes?start_date=" + since.Format("2006-01-02") + "&end_date=" + now.Format("2006-01-02")
+
+	for page := 1; ; page++ {
+		raw, err := t.request(link, page)
+		if err != nil {
+			return ids, err
+		}
+		decode := TMDBChanges{}
+		err = json.Unmarshal(raw, &decode)
+		if err != nil {
+			return ids, err
+		}
+		for _, i := range decode.Results {
+			if !known[i.ID] {
+				known[i.ID] = true
+				ids = append(ids, strconv.Itoa(i.ID))
+			}
+		}
+		if page >= decode.TotalPages {
+			break
+		}
+	}
+
+	return ids, nil
+}
+
+// returns the api configuration, it is requested only once and then cached
+// if the request fails, fallback values are cached until configRetryDelay has passed
+func (t *TMDB) configuration() *TMDBConfiguration {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+
+	if t.config != nil && (t.configRetry.IsZero() || time.Now().Before(t.configRetry)) {
+		return t.config
+	}
+
+	errFields := log.Fields{"file": "tmdb", "function": "configuration"}
+
+	// fallback values used if the configuration cannot be retreived
+	fallback := &TMDBConfiguration{}
+	fallback.Images.SecureBaseURL = "https://image.tmdb.org/t/p/"
+
+	raw, err := t.request("configuration", 1)
+	if err != nil {
+		t.Logger.WithFields(errFields).Warnf("unable to retreive configuration: %v", err)
+		return t.configFallback(fallback)
+	}
+	decode := TMDBConfiguration{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil || decode.Images.SecureBaseURL == "" {
+		t.Logger.WithFields(errFields).Warnf("unable to decode configuration: %v", err)
+		return t.configFallback(fallback)
+	}
+	t.config = &decode
+	t.configRetry = time.Time{}
+	return t.config
+}
+
+// cache the fallback configuration until the next retry, must be called with cacheLock held
+func (t *TMDB) configFallback(fallback *TMDBConfiguration) *TMDBConfiguration {
+	t.config = fallback
+	t.configRetry = time.Now().Add(configRetryDelay)
+	return t.config
+}
+
+// returns the size to use for the given artwork type
+// the configured size is used if it is supported by the api, else the closest size is selected
+func (t *TMDB) imageSize(tp string, config *TMDBConfiguration) string {
+	size, ok := t.ImageSizes[tp]
+	if !ok {
+		size = "original"
+	}
+
+	var sizes []string
+	switch tp {
+	case ImagePoster:
+		sizes = config.Images.PosterSizes
+	case ImageBackdrop:
+		sizes = config.Images.BackdropSizes
+	case ImageStill:
+		sizes = config.Images.StillSizes
+	case ImageLogo:
+		sizes = config.Images.LogoSizes
+	case ImageProfile:
+		sizes = config.Images.ProfileSizes
+	}
+	if len(sizes) == 0 || size == "original" {
+		return size
+	}
+
+	width, err := strconv.Atoi(strings.TrimLeft(size, "wh"))
+	if err != nil {
+		return size
+	}
+	best := "original"
+	bestDiff := -1
+	for _, s := range sizes {
+		if s == size {
+			return s
+		}
+		w, err := strconv.Atoi(strings.TrimLeft(s, "wh"))
+		if err != nil {
+			continue
+		}
+		diff := w - width
+		if diff < 0 {
+			diff = -diff
+		}
+		if bestDiff == -1 || diff < bestDiff {
+			best = s
+			bestDiff = diff
+		}
+	}
+	return best
+}
+
+// returns the url of an image for the given artwork type (ImagePoster, ImageBackdrop, ...)
+func (t *TMDB) ImageURL(tp string, id string) string {
+	if id == "" {
+		return ""
+	}
+	config := t.configuration()
+	return config.Images.SecureBaseURL + t.imageSize(tp, config) + "/" + strings.TrimPrefix(id, "/")
+}
+
+// helper to find items from an external id (imdb, tvdb, ...)
+func (t *TMDB) find(source common.ExternalSource, id string) (TMDBFind, error) {
+	decode := TMDBFind{}
+
+	var externalSource string
+	switch source {
+	case common.ExternalIMDB:
+		externalSource = "imdb_id"
+	case common.ExternalTVDB:
+		externalSource = "tvdb_id"
+	case common.ExternalWikidata:
+		externalSource = "wikidata_id"
+	default:
+		return decode, errors.New("unsupported external source: " + string(source))
+	}
+
+	raw, err := t.request("find/"+url.PathEscape(id)+"?external_source="+externalSource, 1)
+	if err != nil {
+		return decode, err
+	}
+	err = json.Unmarshal(raw, &decode)
+	return decode, err
+}
+
+func (t *TMDB) MediaLink(tp int, id1 string, id2 string, id3 string) string {
+	if id1 == "" {
+		return ""
+	}
+	if tp == 0 {
+		return "https://www.themoviedb.org/tv/" + id1
+	} else if tp == 1 {
+		if id2 == "" {
+			return ""
+		}
+		return "https://www.themoviedb.org/tv/" + id1 + "/season/" + id2
+	} else if tp == 2 {
+		if id2 == "" || id3 == "" {
+			return ""
+		}
+		return "https://www.themoviedb.org/tv/" + id1 + "/season/" + id2 + "/episode/" + id3
+	} else if tp == 3 {
+		return "https://www.themoviedb.org/movie/" + id1
+	} else if tp == 4 {
+		return "https://www.themoviedb.org/collection/" + id1
+	} else if tp == 5 {
+		return "https://www.themoviedb.org/person/" + id1
+	} else if tp == 6 {
+		return "https://www.themoviedb.org/network/" + id1
+	} else if tp == 7 {
+		return "https://www.themoviedb.org/company/" + id1
+	}
+	return ""
+}
+
+// companies
+
+// networks and companies have different ids, ScraperData is used to know the type of id
+func (t *TMDB) companyData(tp common.CompanyType, id int, name string, logo string, country string) common.CompanyData {
+	data := common.CompanyData{
+		Name:          name,
+		Logo:          t.ImageURL(ImageLogo, logo),
+		OriginCountry: country,
+		Type:          tp,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   strconv.Itoa(id),
+		},
+	}
+	if tp == common.CompanyNetwork {
+		data.ScraperData = "network"
+		data.ScraperLink = t.MediaLink(6, strconv.Itoa(id), "", "")
+	} else {
+		data.ScraperData = "company"
+		data.ScraperLink = t.MediaLink(7, strconv.Itoa(id), "", "")
+	}
+	return data
+}
+
+// trailers
+
+// request the videos of a media (ex: tv/1399) and returns its trailers and teasers
+func (t *TMDB) getTrailers(link string) ([]common.TrailerData, error) {
+	// videos are filtered by language, also request videos in the preferred trailer languages and videos without language
+	langs := append([]string{}, t.TrailerPolicy.Languages...)
+	langs = append(langs, strings.Split(t.Language, "-")[0], "null")
+
+	raw, err := t.request(link+"/videos?include_video_language="+strings.Join(langs, ","), 1)
+	if err != nil {
+		return nil, err
+	}
+	decode := TMDBVideo{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	trailers := []common.TrailerData{}
+	for _, item := range decode.Results {
+		tp := strings.ToLower(item.Type)
+		if tp != "trailer" && tp != "teaser" {
+			continue
+		}
+
+		var link string
+		switch item.Site {
+		case "YouTube":
+			link = "https://www.youtube.com/watch?v=" + item.Key
+		case "Vimeo":
+			link = "https://vimeo.com/" + item.Key
+		default:
+			continue
+		}
+
+		trailers = append(trailers, common.TrailerData{
+			Title:     item.Name,
+			Link:      link,
+			Site:      strings.ToLower(item.Site),
+			Type:      tp,
+			Language:  item.ISO6391,
+			Official:  item.Official,
+			Published: common.ParseDate(item.PublishedAt),
+		})
+	}
+
+	return trailers, nil
+}
+
+// split a comma separated list from the settings
+func splitList(val string) []string {
+	ret := []string{}
+	for _, i := range strings.Split(val, ",") {
+		if i = strings.TrimSpace(i); i != "" {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+type TMDBConfiguration struct {
+	Images struct {
+		BaseURL       string   `json:"base_url"`
+		SecureBaseURL string   `json:"secure_base_url"`
+		BackdropSizes []string `json:"backdrop_sizes"`
+		LogoSizes     []string `json:"logo_sizes"`
+		PosterSizes   []string `json:"poster_sizes"`
+		ProfileSizes  []string `json:"profile_sizes"`
+		StillSizes    []string `json:"still_sizes"`
+	} `json:"images"`
+	ChangeKeys []string `json:"change_keys"`
+}
+
+type TMDBChanges struct {
+	Results []struct {
+		ID    int  `json:"id"`
+		Adult bool `json:"adult"`
+	} `json:"results"`
+	Page         int `json:"page"`
+	TotalPages   int `json:"total_pages"`
+	TotalResults int `json:"total_results"`
+}
+
+type TMDBFind struct {
+	MovieResults []struct {
+		ID          int     `json:"id"`
+		Title       string  `json:"title"`
+		Overview    string  `json:"overview"`
+		PosterPath  string  `json:"poster_path"`
+		ReleaseDate string  `json:"release_date"`
+		Adult       bool    `json:"adult"`
+		Popularity  float64 `json:"popularity"`
+	} `json:"movie_results"`
+	PersonResults []struct {
+		ID                 int     `json:"id"`
+		Name               string  `json:"name"`
+		ProfilePath        string  `json:"profile_path"`
+		Adult              bool    `json:"adult"`
+		KnownForDepartment string  `json:"known_for_department"`
+		Popularity         float64 `json:"popularity"`
+	} `json:"person_results"`
+	TVResults []struct {
+		ID           int     `json:"id"`
+		Name         string  `json:"name"`
+		Overview     string  `json:"overview"`
+		PosterPath   string  `json:"poster_path"`
+		FirstAirDate string  `json:"first_air_date"`
+		Popularity   float64 `json:"popularity"`
+	} `json:"tv_results"`
+}
+
+type TMDBVideo struct {
+	ID      int `json:"id"`
+	Results []struct {
+		ISO6391     string `json:"iso_639_1"`
+		ISO31661    string `json:"iso_3166_1"`
+		Name        string `json:"name"`
+		Key         string `json:"key"`
+		Site        string `json:"site"`
+		Size        int    `json:"size"`
+		Type        string `json:"type"`
+		Official    bool   `json:"official"`
+		PublishedAt string `json:"published_at"`
+		ID          string `json:"id"`
+	} `json:"results"`
+}
@@ -1,333 +1,577 @@
-package tmdb
-
-import (
-	"encoding/json"
-	"errors"
-	"net/url"
-	"strconv"
-	"time"
-
-	"github.com/zogwine/metadata/internal/providers/common"
-)
-
-func NewTVShowProvider() common.TVShowProvider {
-	p := New()
-	return &p
-}
-
-// tvs search
-func (t *TMDB) SearchTVS(name string) ([]common.SearchData, error) {
-	// retreive data with pagination
-	data := TMDBTVSearch{}
-	page := 1
-	for {
-		params := url.Values{}
-		params.Add("query", name)
-		raw, err := t.request("search/tv?"+params.Encode(), page)
-		if err != nil {
-			return nil, err
-		}
-		decode := TMDBTVSearch{}
-		err = json.Unmarshal(raw, &decode)
-		if err != nil {
-			return nil, err
-		}
-		data.Results = append(data.Results, decode.Results...)
-		if decode.Page < decode.TotalPages {
-			page++
-		} else {
-			break
-		}
-	}
-
-	// process data
-	ret := make([]common.SearchData, 0)
-	for _, item := range data.Results {
-		prem, _ := time.Parse("2006-01-02", item.FirstAirDate)
-		sd := common.SearchData{
-			Title:     item.Name,
-			Overview:  item.Overview,
-			Icon:      t.ImageURL(item.PosterPath),
-			Premiered: prem.Unix(),
-			ScraperInfo: common.ScraperInfo{
-				ScraperName: t.ScraperName,
-				ScraperID:   strconv.Itoa(item.ID),
-				ScraperData: "",
-				ScraperLink: t.MediaLink(0, strconv.Itoa(item.ID), "", ""),
-			},
-		}
-		ret = append(ret, sd)
-
-		// add episode groups for each result
-		raw, err := t.request("tv/"+strconv.Itoa(item.ID)+"/episode_groups", 1)
-		if err == nil {
-			decode := TMDBEpisodeGroup{}
-			err = json.Unmarshal(raw, &decode)
-			if err == nil {
-				for _, d := range decode.Results {
-					// make a copy of the original result and edit the fields relative to the episode group
-					eg := sd
-					eg.Title = d.Name
-					eg.Overview = d.Description
-					eg.ScraperData = d.ID
-					ret = append(ret, eg)
-				}
-			}
-		}
-	}
-
-	return ret, nil
-}
-
-// tvs get show
-func (t *TMDB) GetTVS() (common.TVSData, error) {
-	// get tvs details
-	raw, err := t.request("tv/"+t.ScraperID, 1)
-	if err != nil {
-		return common.TVSData{}, err
-	}
-	decode := TMDBTVShow{}
-	err = json.Unmarshal(raw, &decode)
-	if err != nil {
-		return common.TVSData{}, err
-	}
-
-	// get associated videos (to extract trailer)
-	raw, err = t.request("tv/"+t.ScraperID+"/videos", 1)
-	if err != nil {
-		return common.TVSData{}, err
-	}
-	vid := TMDBVideo{}
-	err = json.Unmarshal(raw, &vid)
-	if err != nil {
-		return common.TVSData{}, err
-	}
-
-	prem, _ := time.Parse("2006-01-02", decode.FirstAirDate)
-	return common.TVSData{
-		Title:     decode.Name,
-		Overview:  decode.Overview,
-		Icon:      t.ImageURL(decode.PosterPath),
-		Fanart:    t.ImageURL(decode.BackdropPath),
-		Website:   decode.Homepage,
-		Trailer:   t.getTrailerFromVideo(vid),
-		Premiered: prem.Unix(),
-		Rating:    int64(decode.VoteAverage),
-		ScraperInfo: common.ScraperInfo{
-			ScraperID:   t.ScraperID,
-			ScraperName: t.ScraperName,
-			ScraperData: t.ScraperData,
-			ScraperLink: t.MediaLink(0, t.ScraperID, "", ""),
-		},
-	}, nil
-}
-
-func (t *TMDB) GetTVSSeason(season int) (common.TVSSeasonData, error) {
-	raw, err := t.request("tv/"+t.ScraperID+"/season/"+strconv.Itoa(season), 1)
-	if err != nil {
-		return common.TVSSeasonData{}, err
-	}
-	decode := TMDBSeason{}
-	err = json.Unmarshal(raw, &decode)
-	if err != nil {
-		return common.TVSSeasonData{}, err
-	}
-
-	prem := time.Now()
-	vote := 0.0
-	for _, i := range decode.Episodes {
-		vote += i.VoteAverage
-	}
-
-	if len(decode.Episodes) > 0 {
-		prem, _ = time.Parse("2006-01-02", decode.Episodes[0].AirDate)
-		vote /= float64(len(decode.Episodes))
-	}
-
-	return common.TVSSeasonData{
-		Title:     decode.Name,
-		Overview:  decode.Overview,
-		Icon:      t.ImageURL(decode.PosterPath),
-		Fanart:    "",
-		Trailer:   "",
-		Premiered: prem.Unix(),
-		Rating:    int64(vote),
-		ScraperInfo: common.ScraperInfo{
-			ScraperName: t.ScraperName,
-			ScraperID:   t.ScraperID,
-			ScraperData: t.ScraperData,
-			ScraperLink: t.MediaLink(1, t.ScraperID, strconv.Itoa(season), ""),
-		},
-	}, nil
-}
-
-// get tvs episode
-func (t *TMDB) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
-	var decode TMDBEpisode
-
-	if t.ScraperData == "" {
-		raw, err := t.request("tv/"+t.ScraperID+"/season/"+strconv.Itoa(season)+"/episode/"+strconv.Itoa(episode), 1)
-		if err != nil {
-			return common.TVSEpisodeData{}, err
-		}
-		decode = TMDBEpisode{}
-		err = json.Unmarshal(raw, &decode)
-		if err != nil {
-			return common.TVSEpisodeData{}, err
-		}
-	} else {
-		raw, err := t.request("tv/episode_group/"+t.ScraperData, 1)
-		if err != nil {
-			return common.TVSEpisodeData{}, err
-		}
-		dec := TMDBEpisodeGroupData{}
-		err = json.Unmarshal(raw, &dec)
-		if err != nil {
-			return common.TVSEpisodeData{}, err
-		}
-
-		if len(dec.Groups) == 0 {
-			return common.TVSEpisodeData{}, errors.New("no data")
-		}
-		for _, i := range dec.Groups {
-			if i.Order == season {
-				if len(i.Episodes) >= episode {
-					decode = i.Episodes[episode-1]
-				}
-				break
-			}
-		}
-	}
-
-	if decode.Name == "" {
-		return common.TVSEpisodeData{}, errors.New("no data")
-	}
-
-	prem, _ := time.Parse("2006-01-02", decode.AirDate)
-
-	return common.TVSEpisodeData{
-		Title:     decode.Name,
-		Overview:  decode.Overview,
-		Icon:      t.ImageURL(decode.StillPath),
-		Premiered: prem.Unix(),
-		Rating:    int64(decode.VoteAverage),
-		ScraperInfo: common.ScraperInfo{
-			ScraperName: t.ScraperName,
-			ScraperID:   t.ScraperID,
-			ScraperData: t.ScraperData,
-			ScraperLink: t.MediaLink(2, t.ScraperID, strconv.Itoa(season), strconv.Itoa(episode)),
-		},
-	}, nil
-}
-
-func (t *TMDB) ListTVSTag() ([]common.TagData, error) {
-	tags := []common.TagData{}
-
-	raw, err := t.request("tv/"+t.ScraperID, 1)
-	if err != nil {
-		return tags, err
-	}
-	decode := TMDBTVShow{}
-	err = json.Unmarshal(raw, &decode)
-	if err != nil {
-		return tags, err
-	}
-
-	for _, i := range decode.Genres {
-		tags = append(tags, common.TagData{
-			Name:  "genre",
-			Value: i.Name,
-		})
-	}
-
-	for _, i := range decode.OriginCountry {
-		tags = append(tags, common.TagData{
-			Name:  "country",
-			Value: i,
-		})
-	}
-
-	for _, i := range decode.Networks {
-		tags = append(tags, common.TagData{
-			Name:  "network",
-			Value: i.Name,
-			Icon:  t.ImageURL(i.LogoPath),
-		})
-	}
-
-	for _, i := range decode.ProductionCompanies {
-		tags = append(tags, common.TagData{
-			Name:  "production",
-			Value: i.Name,
-			Icon:  t.ImageURL(i.LogoPath),
-		})
-	}
-
-	return tags, nil
-}
-
-func (t *TMDB) ListTVSPerson() ([]common.PersonData, error) {
-	pers := []common.PersonData{}
-
-	raw, err := t.request("tv/"+t.ScraperID+"/credits", 1)
-	if err != nil {
-		return pers, err
-	}
-	decode := TMDBTVSCredits{}
-	err = json.Unmarshal(raw, &decode)
-	if err != nil {
-		return pers, err
-	}
-
-	for _, i := range decode.Cast {
-		pers = append(pers, common.PersonData{
-			Name:        i.Name,
-			Role:        i.KnownForDepartment,
-			IsCharacter: false,
-		})
-	}
-
-	for _, i := range decode.Crew {
-		pers = append(pers, common.PersonData{
-			Name:        i.Name,
-			Role:        i.KnownForDepartment,
-			IsCharacter: false,
-		})
-	}
-
-	return pers, nil
-}
-
-func (t *TMDB) GetTVSUpcoming() (common.UpcomingData, error) {
-
-	raw, err := t.request("tv/"+t.ScraperID, 1)
-	if err != nil {
-		return common.UpcomingData{}, err
-	}
-	decode := TMDBTVShow{}
-	err = json.Unmarshal(raw, &decode)
-	if err != nil {
-		return common.UpcomingData{}, err
-	}
-
-	if decode.NextEpisodeToAir.Name == "" {
-		return common.UpcomingData{}, errors.New("no data")
-	}
-
-	prem, _ := time.Parse("2006-01-02", decode.NextEpisodeToAir.AirDate)
-
-	return common.UpcomingData{
-		Title:     decode.NextEpisodeToAir.Name,
-		Overview:  decode.NextEpisodeToAir.Overview,
-		Icon:      t.ImageURL(decode.NextEpisodeToAir.StillPath),
-		Premiered: prem.Unix(),
-		ID1:       int64(decode.NextEpisodeToAir.SeasonNumber),
-		ID2:       int64(decode.NextEpisodeToAir.EpisodeNumber),
-		ScraperInfo: common.ScraperInfo{
-			ScraperID:   strconv.Itoa(decode.NextEpisodeToAir.ID),
-			ScraperName: t.ScraperName,
-			ScraperData: "",
-			ScraperLink: "",
-		},
-	}, nil
-}
+package tmdb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+// name ending with a year, ex: "Show (2019)"
+var yearSuffixReg = regexp.MustCompile(`^(.+?)\s*\((\d{4})\)$`)
+
+// tvs search
+func (t *TMDB) SearchTVS(name string) ([]common.SearchData, error) {
+	// retreive data with pagination
+	data := TMDBTVSearch{}
+	params := url.Values{}
+	params.Add("include_adult", strconv.FormatBool(t.IncludeAdult))
+	if match := yearSuffixReg.FindStringSubmatch(name); t.FirstAirDateYear && len(match) > 2 {
+		params.Add("query", match[1])
+		params.Add("first_air_date_year", match[2])
+	} else {
+		params.Add("query", name)
+	}
+	err := t.requestPages("search/tv?"+params.Encode(), func(raw []byte) (int, error) {
+		decode := TMDBTVSearch{}
+		err := json.Unmarshal(raw, &decode)
+		data.Results = append(data.Results, decode.Results...)
+		return decode.TotalPages, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the most popular results, the results are truncated after the adult filter
+	sort.SliceStable(data.Results, func(i, j int) bool {
+		return data.Results[i].Popularity > data.Results[j].Popularity
+	})
+
+	// process data
+	ret := make([]common.SearchData, 0)
+	for _, item := range data.Results {
+		if item.Adult && !t.IncludeAdult {
+			continue
+		}
+		prem := common.ParseDate(item.FirstAirDate)
+		sd := common.SearchData{
+			Title:     item.Name,
+			Overview:  item.Overview,
+			Icon:      t.ImageURL(ImagePoster, item.PosterPath),
+			Premiered: prem,
+			Adult:     item.Adult,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   strconv.Itoa(item.ID),
+				ScraperData: "",
+				ScraperLink: t.MediaLink(0, strconv.Itoa(item.ID), "", ""),
+			},
+		}
+		ret = append(ret, sd)
+		if t.SearchMaxResults > 0 && len(ret) >= t.SearchMaxResults {
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+// tvs search from an external id (imdb, tvdb, ...)
+func (t *TMDB) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	decode, err := t.find(source, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, item := range decode.TVResults {
+		prem := common.ParseDate(item.FirstAirDate)
+		ret = append(ret, common.SearchData{
+			Title:     item.Name,
+			Overview:  item.Overview,
+			Icon:      t.ImageURL(ImagePoster, item.PosterPath),
+			Premiered: prem,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   strconv.Itoa(item.ID),
+				ScraperData: "",
+				ScraperLink: t.MediaLink(0, strconv.Itoa(item.ID), "", ""),
+			},
+		})
+	}
+
+	return ret, nil
+}
+
+// list the shows modified since a given date
+func (t *TMDB) TVSChangedSince(since time.Time) ([]string, error) {
+	return t.changes("tv", since)
+}
+
+// tvs get show
+func (t *TMDB) GetTVS() (common.TVSData, error) {
+	// get tvs details
+	raw, err := t.request("tv/"+t.ScraperID, 1)
+	if err != nil {
+		return common.TVSData{}, err
+	}
+	decode := TMDBTVShow{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return common.TVSData{}, err
+	}
+
+	// get associated videos (to extract trailers)
+	trailers, err := t.getTrailers("tv/" + t.ScraperID)
+	if err != nil {
+		return common.TVSData{}, err
+	}
+
+	prem := common.ParseDate(decode.FirstAirDate)
+	return common.TVSData{
+		Title:     decode.Name,
+		Overview:  decode.Overview,
+		Icon:      t.ImageURL(ImagePoster, decode.PosterPath),
+		Fanart:    t.ImageURL(ImageBackdrop, decode.BackdropPath),
+		Website:   decode.Homepage,
+		Trailer:   common.SelectTrailer(trailers, t.TrailerPolicy).Link,
+		Trailers:  trailers,
+		Premiered: prem,
+		Rating:    int64(decode.VoteAverage),
+		Ratings:   []common.RatingData{{Source: t.ScraperName, Value: decode.VoteAverage, Max: 10, Votes: int64(decode.VoteCount)}},
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   t.ScraperID,
+			ScraperName: t.ScraperName,
+			ScraperData: t.ScraperData,
+			ScraperLink: t.MediaLink(0, t.ScraperID, "", ""),
+		},
+	}, nil
+}
+
+func (t *TMDB) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	if t.ScraperData != "" {
+		return t.getTVSGroupSeason(season)
+	}
+
+	raw, err := t.request("tv/"+t.ScraperID+"/season/"+strconv.Itoa(season), 1)
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+	decode := TMDBSeason{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+
+	prem := common.ParseDate(decode.AirDate)
+	vote := 0.0
+	for _, i := range decode.Episodes {
+		vote += i.VoteAverage
+	}
+
+	if len(decode.Episodes) > 0 {
+		if !prem.IsKnown() {
+			prem = common.ParseDate(decode.Episodes[0].AirDate)
+		}
+		vote /= float64(len(decode.Episodes))
+	}
+
+	return common.TVSSeasonData{
+		Title:        decode.Name,
+		Overview:     decode.Overview,
+		Icon:         t.ImageURL(ImagePoster, decode.PosterPath),
+		Fanart:       "",
+		Trailer:      "",
+		Premiered:    prem,
+		Rating:       int64(vote),
+		EpisodeCount: int64(len(decode.Episodes)),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   t.ScraperID,
+			ScraperData: t.ScraperData,
+			ScraperLink: t.MediaLink(1, t.ScraperID, strconv.Itoa(season), ""),
+		},
+	}, nil
+}
+
+// get a season from the selected episode group
+func (t *TMDB) getTVSGroupSeason(season int) (common.TVSSeasonData, error) {
+	group, err := t.getEpisodeGroup()
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+
+	for _, i := range group.Groups {
+		if i.Order != season {
+			continue
+		}
+
+		prem := common.Date{}
+		vote := 0.0
+		for _, e := range i.Episodes {
+			vote += e.VoteAverage
+		}
+		if len(i.Episodes) > 0 {
+			prem = common.ParseDate(i.Episodes[0].AirDate)
+			vote /= float64(len(i.Episodes))
+		}
+
+		// groups do not have posters, use the poster of the original season
+		// if all the episodes of the group come from the same season
+		icon := ""
+		if len(i.Episodes) > 0 {
+			origSeason := i.Episodes[0].SeasonNumber
+			sameSeason := true
+			for _, e := range i.Episodes {
+				if e.SeasonNumber != origSeason {
+					sameSeason = false
+					break
+				}
+			}
+			if sameSeason {
+				raw, err := t.request("tv/"+t.ScraperID+"/season/"+strconv.Itoa(origSeason), 1)
+				if err == nil {
+					decode := TMDBSeason{}
+					if json.Unmarshal(raw, &decode) == nil {
+						icon = t.ImageURL(ImagePoster, decode.PosterPath)
+					}
+				}
+			}
+		}
+
+		return common.TVSSeasonData{
+			Title:        i.Name,
+			Overview:     "",
+			Icon:         icon,
+			Fanart:       "",
+			Trailer:      "",
+			Premiered:    prem,
+			Rating:       int64(vote),
+			EpisodeCount: int64(len(i.Episodes)),
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   t.ScraperID,
+				ScraperData: t.ScraperData,
+				ScraperLink: t.MediaLink(1, t.ScraperID, strconv.Itoa(season), ""),
+			},
+		}, nil
+	}
+
+	return common.TVSSeasonData{}, errors.New("no data")
+}
+
+// get tvs episode
+func (t *TMDB) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	decode, err := t.getEpisode(season, episode)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+
+	prem := common.ParseDate(decode.AirDate)
+
+	return common.TVSEpisodeData{
+		Title:     decode.Name,
+		Overview:  decode.Overview,
+		Icon:      t.ImageURL(ImageStill, decode.StillPath),
+		Premiered: prem,
+		Rating:    int64(decode.VoteAverage),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   t.ScraperID,
+			ScraperData: t.ScraperData,
+			ScraperLink: t.MediaLink(2, t.ScraperID, strconv.Itoa(season), strconv.Itoa(episode)),
+		},
+	}, nil
+}
+
+// returns the episode at the given position, using the selected episode group if any
+func (t *TMDB) getEpisode(season int, episode int) (TMDBEpisode, error) {
+	var decode TMDBEpisode
+
+	if t.ScraperData == "" {
+		raw, err := t.request("tv/"+t.ScraperID+"/season/"+strconv.Itoa(season)+"/episode/"+strconv.Itoa(episode), 1)
+		if err != nil {
+			return decode, err
+		}
+		err = json.Unmarshal(raw, &decode)
+		if err != nil {
+			return decode, err
+		}
+	} else {
+		dec, err := t.getEpisodeGroup()
+		if err != nil {
+			return decode, err
+		}
+
+		// the episodes of a group are numbered by their position in the group, not by their original number
+		for _, i := range dec.Groups {
+			if i.Order != season {
+				continue
+			}
+			for _, e := range i.Episodes {
+				if e.Order+1 == episode {
+					decode = e
+					break
+				}
+			}
+			break
+		}
+	}
+
+	if decode.Name == "" {
+		return decode, errors.New("no data")
+	}
+	return decode, nil
+}
+
+// list the guest stars and crew of an episode
+func (t *TMDB) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	pers := []common.PersonData{}
+
+	decode, err := t.getEpisode(season, episode)
+	if err != nil {
+		return pers, err
+	}
+
+	if t.ScraperData != "" {
+		// episode groups do not contain the credits, request the original episode
+		raw, err := t.request("tv/"+t.ScraperID+"/season/"+strconv.Itoa(decode.SeasonNumber)+"/episode/"+strconv.Itoa(decode.EpisodeNumber), 1)
+		if err != nil {
+			return pers, err
+		}
+		decode = TMDBEpisode{}
+		err = json.Unmarshal(raw, &decode)
+		if err != nil {
+			return pers, err
+		}
+	}
+
+	for _, i := range decode.GuestStars {
+		pers = append(pers, common.PersonData{
+			Name:        i.Name,
+			Role:        i.KnownForDepartment,
+			Character:   i.Character,
+			IsCharacter: false,
+		})
+	}
+
+	for _, i := range decode.Crew {
+		pers = append(pers, common.PersonData{
+			Name:        i.Name,
+			Role:        i.Department,
+			IsCharacter: false,
+		})
+	}
+
+	return pers, nil
+}
+
+func (t *TMDB) ListTVSTag() ([]common.TagData, error) {
+	tags := []common.TagData{}
+
+	raw, err := t.request("tv/"+t.ScraperID, 1)
+	if err != nil {
+		return tags, err
+	}
+	decode := TMDBTVShow{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return tags, err
+	}
+
+	for _, i := range decode.Genres {
+		tags = append(tags, common.TagData{
+			Name:  "genre",
+			Value: i.Name,
+		})
+	}
+
+	for _, i := range decode.OriginCountry {
+		tags = append(tags, common.TagData{
+			Name:  "country",
+			Value: i,
+		})
+	}
+
+	return tags, nil
+}
+
+// list the networks and production companies of the tvs
+func (t *TMDB) ListTVSCompany() ([]common.CompanyData, error) {
+	companies := []common.CompanyData{}
+
+	raw, err := t.request("tv/"+t.ScraperID, 1)
+	if err != nil {
+		return companies, err
+	}
+	decode := TMDBTVShow{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return companies, err
+	}
+
+	known := map[string]bool{}
+	for _, i := range decode.Networks {
+		if !known["n"+strconv.Itoa(i.ID)] {
+			known["n"+strconv.Itoa(i.ID)] = true
+			companies = append(companies, t.companyData(common.CompanyNetwork, i.ID, i.Name, i.LogoPath, i.OriginCountry))
+		}
+	}
+
+	for _, i := range decode.ProductionCompanies {
+		if !known["c"+strconv.Itoa(i.ID)] {
+			known["c"+strconv.Itoa(i.ID)] = true
+			companies = append(companies, t.companyData(common.CompanyStudio, i.ID, i.Name, i.LogoPath, i.OriginCountry))
+		}
+	}
+
+	return companies, nil
+}
+
+func (t *TMDB) ListTVSPerson() ([]common.PersonData, error) {
+	pers := []common.PersonData{}
+
+	raw, err := t.request("tv/"+t.ScraperID+"/credits", 1)
+	if err != nil {
+		return pers, err
+	}
+	decode := TMDBTVSCredits{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return pers, err
+	}
+
+	for _, i := range decode.Cast {
+		pers = append(pers, common.PersonData{
+			Name:        i.Name,
+			Role:        common.RoleActing,
+			Character:   i.Character,
+			IsCharacter: false,
+		})
+	}
+
+	for _, i := range decode.Crew {
+		pers = append(pers, common.PersonData{
+			Name:        i.Name,
+			Role:        i.Department,
+			IsCharacter: false,
+		})
+	}
+
+	return pers, nil
+}
+
+func (t *TMDB) GetTVSUpcoming() (common.UpcomingData, error) {
+
+	raw, err := t.request("tv/"+t.ScraperID, 1)
+	if err != nil {
+		return common.UpcomingData{}, err
+	}
+	decode := TMDBTVShow{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return common.UpcomingData{}, err
+	}
+
+	if decode.NextEpisodeToAir.Name == "" {
+		return common.UpcomingData{}, errors.New("no data")
+	}
+
+	prem := common.ParseDate(decode.NextEpisodeToAir.AirDate)
+
+	return common.UpcomingData{
+		Title:     decode.NextEpisodeToAir.Name,
+		Overview:  decode.NextEpisodeToAir.Overview,
+		Icon:      t.ImageURL(ImageStill, decode.NextEpisodeToAir.StillPath),
+		Premiered: prem,
+		ID1:       int64(decode.NextEpisodeToAir.SeasonNumber),
+		ID2:       int64(decode.NextEpisodeToAir.EpisodeNumber),
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   strconv.Itoa(decode.NextEpisodeToAir.ID),
+			ScraperName: t.ScraperName,
+			ScraperData: "",
+			ScraperLink: "",
+		},
+	}, nil
+}
+
+// list the episode groups available for the tvs
+func (t *TMDB) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	groups := []common.EpisodeGroupData{}
+
+	raw, err := t.request("tv/"+t.ScraperID+"/episode_groups", 1)
+	if err != nil {
+		return groups, err
+	}
+	decode := TMDBEpisodeGroup{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return groups, err
+	}
+
+	for _, i := range decode.Results {
+		groups = append(groups, common.EpisodeGroupData{
+			Title:        i.Name,
+			Overview:     i.Description,
+			Type:         episodeGroupType(i.Type),
+			EpisodeCount: int64(i.EpisodeCount),
+			GroupCount:   int64(i.GroupCount),
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   t.ScraperID,
+				ScraperData: i.ID,
+				ScraperLink: t.MediaLink(0, t.ScraperID, "", ""),
+			},
+		})
+	}
+
+	return groups, nil
+}
+
+// returns the episode group selected with ScraperData
+// the group is cached as it is used for every season and episode of the tvs
+func (t *TMDB) getEpisodeGroup() (*TMDBEpisodeGroupData, error) {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+
+	if t.episodeGroup != nil && t.episodeGroup.ID == t.ScraperData {
+		return t.episodeGroup, nil
+	}
+
+	raw, err := t.request("tv/episode_group/"+t.ScraperData, 1)
+	if err != nil {
+		return nil, err
+	}
+	decode := TMDBEpisodeGroupData{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return nil, err
+	}
+	if len(decode.Groups) == 0 {
+		return nil, errors.New("no data")
+	}
+
+	t.episodeGroup = &decode
+	return t.episodeGroup, nil
+}
+
+// convert the tmdb episode group type to its name
+func episodeGroupType(tp int) string {
+	switch tp {
+	case 1:
+		return "original"
+	case 2:
+		return "absolute"
+	case 3:
+		return "dvd"
+	case 4:
+		return "digital"
+	case 5:
+		return "story_arc"
+	case 6:
+		return "production"
+	case 7:
+		return "tv"
+	}
+	return ""
+}
@@ -6,6 +6,7 @@ type TMDBTVSearch struct {
 	TotalResults int `json:"total_results"`
 	Results      []struct {
 		PosterPath       string   `json:"poster_path"`
+		Adult            bool     `json:"adult"`
 		Popularity       float64  `json:"popularity"`
 		ID               int      `json:"id"`
 		BackdropPath     string   `json:"backdrop_path"`
@@ -187,13 +188,15 @@ type TMDBEpisode struct {
 		ProfilePath string `json:"profile_path"`
 	} `json:"crew"`
 	EpisodeNumber int `json:"episode_number"`
+	Order         int `json:"order"` // position of the episode in an episode group, starting at 0
 	GuestStars    []struct {
-		ID          int    `json:"id"`
-		CreditID    string `json:"credit_id"`
-		Name        string `json:"name"`
-		ProfilePath string `json:"profile_path"`
-		Character   string `json:"character"`
-		Order       int    `json:"order"`
+		ID                 int    `json:"id"`
+		CreditID           string `json:"credit_id"`
+		Name               string `json:"name"`
+		ProfilePath        string `json:"profile_path"`
+		Character          string `json:"character"`
+		KnownForDepartment string `json:"known_for_department"`
+		Order              int    `json:"order"`
 	} `json:"guest_stars"`
 	Name           string  `json:"name"`
 	Overview       string  `json:"overview"`
@@ -0,0 +1,499 @@
+package tvdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// artwork types of the api
+const (
+	ArtworkSeriesBanner     = 1
+	ArtworkSeriesPoster     = 2
+	ArtworkSeriesBackground = 3
+	ArtworkSeasonPoster     = 7
+	ArtworkEpisodeScreencap = 11
+	ArtworkSeriesClearLogo  = 23
+)
+
+// episode orderings (season types of the api)
+const (
+	OrderAired    = "official"
+	OrderDVD      = "dvd"
+	OrderAbsolute = "absolute"
+)
+
+type TVDB struct {
+	APIKey           string
+	PIN              string // subscriber pin, only needed for user supported keys
+	APIURL           string // base url of the api, can be changed to use a local server
+	Language         string // iso 639-2 code (ex: eng, fra)
+	SearchMaxResults int    // maximum number of results returned by a search, 0 for no limit
+	TrailerPolicy    common.TrailerPolicy
+	ScraperName      string
+	ScraperID        string
+	ScraperData      string // episode ordering (OrderAired, OrderDVD or OrderAbsolute)
+	Logger           *log.Logger
+	token            string
+	tokenLock        *sync.Mutex         // the token is shared by the concurrent scans
+	series           *TVDBSeriesExtended // last requested series
+	episodes         []TVDBEpisode       // episodes of the last requested series and ordering
+	episodesKey      string              // series id and ordering of the cached episodes
+	cacheLock        *sync.Mutex
+}
+
+func New() TVDB {
+	return TVDB{
+		ScraperName:      "tvdb",
+		APIURL:           "https://api4.thetvdb.com/v4",
+		Language:         "eng",
+		SearchMaxResults: 20,
+		TrailerPolicy:    common.NewTrailerPolicy(),
+		Logger:           nil,
+		ScraperID:        "",
+		ScraperData:      "",
+		cacheLock:        &sync.Mutex{},
+		tokenLock:        &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (t *TVDB) Setup(config map[string]string, logger *log.Logger) error {
+	t.Logger = logger
+	if val, ok := config["api_key"]; ok {
+		t.APIKey = val
+	} else {
+		return errors.New("empty api key")
+	}
+	if val, ok := config["pin"]; ok {
+		t.PIN = val
+	}
+	if val, ok := config["api_url"]; ok && val != "" {
+		t.APIURL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["language"]; ok && val != "" {
+		t.Language = val
+	}
+	// trailer selection, the policy uses iso 639-1 codes
+	t.TrailerPolicy.Languages = []string{shortLanguage(t.Language)}
+	if val, ok := config["trailer_languages"]; ok {
+		t.TrailerPolicy.Languages = []string{}
+		for _, i := range splitList(val) {
+			t.TrailerPolicy.Languages = append(t.TrailerPolicy.Languages, shortLanguage(i))
+		}
+	}
+	if val, ok := config["search_max_results"]; ok {
+		if n, err := strconv.Atoi(val); err == nil {
+			t.SearchMaxResults = n
+		}
+	}
+	return nil
+}
+
+func (t *TVDB) Configure(ScraperID string, ScraperData string) {
+	t.ScraperID = ScraperID
+	t.ScraperData = ScraperData
+}
+
+// request a token from the api key, the token is valid for one month
+func (t *TVDB) login() (string, error) {
+	errFields := log.Fields{
+		"file":     "tvdb",
+		"function": "login",
+	}
+
+	if t.APIKey == "" {
+		t.Logger.WithFields(errFields).Error("empty api key")
+		return "", errors.New("empty api key")
+	}
+
+	body, err := json.Marshal(map[string]string{"apikey": t.APIKey, "pin": t.PIN})
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.Post(t.APIURL+"/login", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Logger.WithFields(errFields).Errorf("login error: %v", err)
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Logger.WithFields(errFields).Errorf("login error: status code: %d", resp.StatusCode)
+		return "", errors.New("login failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+
+	decode := TVDBLogin{}
+	err = json.NewDecoder(resp.Body).Decode(&decode)
+	if err != nil {
+		return "", err
+	}
+	if decode.Data.Token == "" {
+		return "", errors.New("empty token")
+	}
+	return decode.Data.Token, nil
+}
+
+// returns the current token, a new token is requested if there is none
+func (t *TVDB) getToken() (string, error) {
+	t.tokenLock.Lock()
+	defer t.tokenLock.Unlock()
+
+	if t.token == "" {
+		token, err := t.login()
+		if err != nil {
+			return "", err
+		}
+		t.token = token
+	}
+	return t.token, nil
+}
+
+// discard an expired token, unless another request already renewed it
+func (t *TVDB) resetToken(token string) {
+	t.tokenLock.Lock()
+	defer t.tokenLock.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+// helper to make a request to the api, the token is renewed if it expired
+func (t *TVDB) request(link string) ([]byte, error) {
+	errFields := log.Fields{
+		"file":     "tvdb",
+		"function": "request",
+	}
+
+	if link == "" {
+		t.Logger.WithFields(errFields).Error("empty url")
+		return nil, errors.New("empty url")
+	}
+
+	for retry := 0; retry < 2; retry++ {
+		token, err := t.getToken()
+		if err != nil {
+			return nil, err
+		}
+
+		u := t.APIURL + "/" + link
+		req, err := http.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept-Language", t.Language)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Logger.WithFields(errFields).Infof("requested url: %s", u)
+			t.Logger.WithFields(errFields).Errorf("request error: %v", err)
+			return nil, err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode == 401 {
+			// the token expired, login again
+			t.resetToken(token)
+			continue
+		}
+		if resp.StatusCode != 200 {
+			t.Logger.WithFields(errFields).Infof("requested url: %s", u)
+			t.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+			return nil, errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+		}
+		if err != nil {
+			t.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+			return nil, err
+		}
+		return data, nil
+	}
+
+	return nil, errors.New("unauthorized")
+}
+
+// returns the url of an image, the api returns either full urls or paths
+func (t *TVDB) ImageURL(link string) string {
+	if link == "" || strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+		return link
+	}
+	return "https://artworks.thetvdb.com" + "/" + strings.TrimPrefix(link, "/")
+}
+
+// select the best artwork of a given type
+// artworks in the configured language are preferred, then artworks without text, then the others
+func (t *TVDB) selectArtwork(artworks []TVDBArtwork, tp int, fallback string) string {
+	list := []TVDBArtwork{}
+	for _, i := range artworks {
+		if i.Type == tp && i.Image != "" {
+			list = append(list, i)
+		}
+	}
+	rank := func(a TVDBArtwork) int {
+		if a.Language == t.Language {
+			return 0
+		} else if a.Language == "" {
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if rank(list[i]) != rank(list[j]) {
+			return rank(list[i]) < rank(list[j])
+		}
+		return list[i].Score > list[j].Score
+	})
+	if len(list) > 0 {
+		return t.ImageURL(list[0].Image)
+	}
+	return t.ImageURL(fallback)
+}
+
+// returns the translation in the configured language if available
+func (t *TVDB) translate(translations []TVDBTranslation, fallback string, overview bool) string {
+	for _, i := range translations {
+		if i.Language != t.Language {
+			continue
+		}
+		if overview && i.Overview != "" {
+			return i.Overview
+		}
+		if !overview && i.Name != "" {
+			return i.Name
+		}
+	}
+	return fallback
+}
+
+func (t *TVDB) MediaLink(tp int, id string) string {
+	if id == "" {
+		return ""
+	}
+	if tp == 0 {
+		return "https://thetvdb.com/dereferrer/series/" + id
+	} else if tp == 1 {
+		return "https://thetvdb.com/dereferrer/season/" + id
+	} else if tp == 2 {
+		return "https://thetvdb.com/dereferrer/episode/" + id
+	} else if tp == 3 {
+		return "https://thetvdb.com/dereferrer/movie/" + id
+	} else if tp == 4 {
+		return "https://thetvdb.com/dereferrer/people/" + id
+	} else if tp == 5 {
+		return "https://thetvdb.com/dereferrer/company/" + id
+	}
+	return ""
+}
+
+// list the ids of the records of the given type (series, movies, people) modified since a given date
+func (t *TVDB) changes(kind string, since time.Time) ([]string, error) {
+	ids := []string{}
+	known := map[int]bool{}
+
+	for page := 0; ; page++ {
+		params := url.Values{}
+		params.Add("since", strconv.FormatInt(since.Unix(), 10))
+		params.Add("type", kind)
+		params.Add("page", strconv.Itoa(page))
+		raw, err := t.request("updates?" + params.Encode())
+		if err != nil {
+			return ids, err
+		}
+		decode := TVDBUpdates{}
+		err = json.Unmarshal(raw, &decode)
+		if err != nil {
+			return ids, err
+		}
+		for _, i := range decode.Data {
+			if !known[i.RecordID] {
+				known[i.RecordID] = true
+				ids = append(ids, strconv.Itoa(i.RecordID))
+			}
+		}
+		if decode.Links.Next == nil || len(decode.Data) == 0 {
+			break
+		}
+	}
+
+	return ids, nil
+}
+
+// companies
+
+func (t *TVDB) companyData(tp common.CompanyType, company TVDBCompany) common.CompanyData {
+	return common.CompanyData{
+		Name:          company.Name,
+		Logo:          "",
+		OriginCountry: company.Country,
+		Type:          tp,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   strconv.Itoa(company.ID),
+			ScraperLink: t.MediaLink(5, strconv.Itoa(company.ID)),
+		},
+	}
+}
+
+// trailers
+
+func trailers(list []TVDBTrailer) []common.TrailerData {
+	ret := []common.TrailerData{}
+	for _, i := range list {
+		if i.URL == "" {
+			continue
+		}
+		site := ""
+		if strings.Contains(i.URL, "youtube.com") || strings.Contains(i.URL, "youtu.be") {
+			site = "youtube"
+		} else if strings.Contains(i.URL, "vimeo.com") {
+			site = "vimeo"
+		}
+		ret = append(ret, common.TrailerData{
+			Title:    i.Name,
+			Link:     i.URL,
+			Site:     site,
+			Type:     "trailer",
+			Language: shortLanguage(i.Language),
+		})
+	}
+	return ret
+}
+
+// people
+
+// the people type of the api is used as role, the name of an actor's entry is the character played
+func person(item TVDBCharacter) common.PersonData {
+	role := common.JobRole(item.PeopleType)
+	character := ""
+	if role == common.RoleActing {
+		character = item.Name
+	}
+	return common.PersonData{
+		Name:        item.PersonName,
+		Role:        role,
+		Character:   character,
+		IsCharacter: false,
+	}
+}
+
+// iso 639-2 codes used by the api and their iso 639-1 equivalent
+var languages = map[string]string{
+	"eng": "en", "fra": "fr", "deu": "de", "spa": "es", "ita": "it", "por": "pt",
+	"nld": "nl", "swe": "sv", "nor": "no", "dan": "da", "fin": "fi", "pol": "pl", "ces": "cs",
+	"hun": "hu", "ron": "ro", "ell": "el", "tur": "tr", "rus": "ru", "ukr": "uk", "ara": "ar",
+	"heb": "he", "hin": "hi", "jpn": "ja", "zho": "zh", "kor": "ko", "tha": "th", "vie": "vi",
+}
+
+// returns the iso 639-1 code of an iso 639-2 code, other codes are returned unchanged
+func shortLanguage(code string) string {
+	if val, ok := languages[strings.ToLower(code)]; ok {
+		return val
+	}
+	return code
+}
+
+// split a comma separated list from the settings
+func splitList(val string) []string {
+	ret := []string{}
+	for _, i := range strings.Split(val, ",") {
+		if i = strings.TrimSpace(i); i != "" {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+type TVDBLogin struct {
+	Status string `json:"status"`
+	Data   struct {
+		Token string `json:"token"`
+	} `json:"data"`
+}
+
+type TVDBLinks struct {
+	Prev       *string `json:"prev"`
+	Self       *string `json:"self"`
+	Next       *string `json:"next"`
+	TotalItems int     `json:"total_items"`
+	PageSize   int     `json:"page_size"`
+}
+
+type TVDBUpdates struct {
+	Status string `json:"status"`
+	Data   []struct {
+		RecordID   int    `json:"recordId"`
+		EntityType string `json:"entityType"`
+		Method     string `json:"method"`
+		TimeStamp  int64  `json:"timeStamp"`
+	} `json:"data"`
+	Links TVDBLinks `json:"links"`
+}
+
+type TVDBArtwork struct {
+	ID        int     `json:"id"`
+	Image     string  `json:"image"`
+	Thumbnail string  `json:"thumbnail"`
+	Language  string  `json:"language"`
+	Type      int     `json:"type"`
+	Score     float64 `json:"score"`
+	Width     int     `json:"width"`
+	Height    int     `json:"height"`
+}
+
+type TVDBTranslation struct {
+	Name      string `json:"name"`
+	Overview  string `json:"overview"`
+	Language  string `json:"language"`
+	IsPrimary bool   `json:"isPrimary"`
+}
+
+type TVDBTranslations struct {
+	NameTranslations     []TVDBTranslation `json:"nameTranslations"`
+	OverviewTranslations []TVDBTranslation `json:"overviewTranslations"`
+}
+
+type TVDBCompany struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Country     string `json:"country"`
+	CompanyType struct {
+		CompanyTypeID   int    `json:"companyTypeId"`
+		CompanyTypeName string `json:"companyTypeName"`
+	} `json:"companyType"`
+}
+
+type TVDBCharacter struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	PeopleID   int    `json:"peopleId"`
+	PersonName string `json:"personName"`
+	PeopleType string `json:"peopleType"`
+	Type       int    `json:"type"`
+	Sort       int    `json:"sort"`
+}
+
+type TVDBTrailer struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Language string `json:"language"`
+}
+
+type TVDBRemoteID struct {
+	ID         string `json:"id"`
+	Type       int    `json:"type"`
+	SourceName string `json:"sourceName"`
+}
@@ -0,0 +1,124 @@
+package tvdb
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// responses of the stand-in api, by request path
+var responses = map[string]string{
+	"/login": `{"status": "success", "data": {"token": "token"}}`,
+	"/series/1/extended": `{"status": "success", "data": {
+		"id": 1, "name": "Show", "firstAired": "2010-05-12",
+		"characters": [
+			{"name": "Jane Doe", "personName": "Actress One", "peopleType": "Actor"},
+			{"name": "", "personName": "Director One", "peopleType": "Director"},
+			{"name": "", "personName": "Writer One", "peopleType": "Writer"},
+			{"name": "", "personName": "Producer One", "peopleType": "Executive Producer"}
+		],
+		"trailers": [
+			{"name": "French trailer", "url": "https://www.youtube.com/watch?v=fr", "language": "fra"},
+			{"name": "English trailer", "url": "https://www.youtube.com/watch?v=en", "language": "eng"}
+		]
+	}}`,
+	"/series/1/episodes/official/eng": `{"status": "success", "data": {"episodes": [
+		{"id": 10, "name": "Pilot", "number": 1, "seasonNumber": 1, "aired": "2010-05-12"}
+	]}, "links": {"next": null}}`,
+	"/episodes/10/extended": `{"status": "success", "data": {"id": 10, "characters": [
+		{"name": "John Doe", "personName": "Guest One", "peopleType": "Guest Star"}
+	]}}`,
+}
+
+func newTestTVDB(t *testing.T) *TVDB {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/login" && r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(401)
+			return
+		}
+		if val, ok := responses[r.URL.Path]; ok {
+			io.WriteString(w, val)
+			return
+		}
+		w.WriteHeader(404)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	tvdb := New()
+	err := tvdb.Setup(map[string]string{"api_key": "key", "api_url": server.URL + "/"}, logger)
+	if err != nil {
+		t.Fatalf("Setup() returned %v", err)
+	}
+	tvdb.Configure("1", "")
+	return &tvdb
+}
+
+func TestSetupTrailerLanguages(t *testing.T) {
+	tests := []struct {
+		config    map[string]string
+		languages []string
+	}{
+		{map[string]string{"api_key": "key"}, []string{"en"}},
+		{map[string]string{"api_key": "key", "language": "fra"}, []string{"fr"}},
+		{map[string]string{"api_key": "key", "trailer_languages": "jpn, eng,en"}, []string{"ja", "en", "en"}},
+	}
+	for _, i := range tests {
+		tvdb := New()
+		tvdb.Setup(i.config, log.New())
+		if !reflect.DeepEqual(tvdb.TrailerPolicy.Languages, i.languages) {
+			t.Errorf("Setup(%v) trailer languages = %v, want %v", i.config, tvdb.TrailerPolicy.Languages, i.languages)
+		}
+	}
+}
+
+func TestGetTVSTrailer(t *testing.T) {
+	tvdb := newTestTVDB(t)
+	data, err := tvdb.GetTVS()
+	if err != nil {
+		t.Fatalf("GetTVS() returned %v", err)
+	}
+	if data.Trailer != "https://www.youtube.com/watch?v=en" {
+		t.Errorf("GetTVS().Trailer = %q, want the english trailer", data.Trailer)
+	}
+	for _, i := range data.Trailers {
+		if i.Language != "en" && i.Language != "fr" {
+			t.Errorf("trailer %q language = %q, want an iso 639-1 code", i.Title, i.Language)
+		}
+	}
+}
+
+func TestListTVSPerson(t *testing.T) {
+	tvdb := newTestTVDB(t)
+	pers, err := tvdb.ListTVSPerson()
+	if err != nil {
+		t.Fatalf("ListTVSPerson() returned %v", err)
+	}
+	want := []common.PersonData{
+		{Name: "Actress One", Role: common.RoleActing, Character: "Jane Doe"},
+		{Name: "Director One", Role: common.RoleDirecting},
+		{Name: "Writer One", Role: common.RoleWriting},
+		{Name: "Producer One", Role: common.RoleProduction},
+	}
+	if !reflect.DeepEqual(pers, want) {
+		t.Errorf("ListTVSPerson() = %+v, want %+v", pers, want)
+	}
+}
+
+func TestListTVSEpisodePerson(t *testing.T) {
+	tvdb := newTestTVDB(t)
+	pers, err := tvdb.ListTVSEpisodePerson(1, 1)
+	if err != nil {
+		t.Fatalf("ListTVSEpisodePerson() returned %v", err)
+	}
+	want := []common.PersonData{{Name: "Guest One", Role: common.RoleActing, Character: "John Doe"}}
+	if !reflect.DeepEqual(pers, want) {
+		t.Errorf("ListTVSEpisodePerson(1, 1) = %+v, want %+v", pers, want)
+	}
+}
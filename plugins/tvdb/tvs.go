@@ -0,0 +1,539 @@
+package tvdb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+// tvs search
+func (t *TVDB) SearchTVS(name string) ([]common.SearchData, error) {
+	params := url.Values{}
+	params.Add("query", name)
+	params.Add("type", "series")
+	if t.SearchMaxResults > 0 {
+		params.Add("limit", strconv.Itoa(t.SearchMaxResults))
+	}
+	raw, err := t.request("search?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	data := TVDBSearch{}
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return nil, err
+	}
+	if t.SearchMaxResults > 0 && len(data.Data) > t.SearchMaxResults {
+		data.Data = data.Data[:t.SearchMaxResults]
+	}
+
+	// process data
+	ret := make([]common.SearchData, 0)
+	for _, item := range data.Data {
+		title := item.Name
+		if val, ok := item.Translations[t.Language]; ok && val != "" {
+			title = val
+		}
+		overview := item.Overview
+		if val, ok := item.Overviews[t.Language]; ok && val != "" {
+			overview = val
+		}
+		prem := common.ParseDate(item.FirstAirTime)
+		if !prem.IsKnown() {
+			prem = common.ParseDate(item.Year)
+		}
+		ret = append(ret, common.SearchData{
+			Title:     title,
+			Overview:  overview,
+			Icon:      t.ImageURL(item.ImageURL),
+			Premiered: prem,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   item.TVDBID,
+				ScraperData: "",
+				ScraperLink: t.MediaLink(0, item.TVDBID),
+			},
+		})
+	}
+
+	return ret, nil
+}
+
+func (t *TVDB) seriesSearchData(item TVDBSeries) common.SearchData {
+	return common.SearchData{
+		Title:     item.Name,
+		Overview:  item.Overview,
+		Icon:      t.ImageURL(item.Image),
+		Premiered: common.ParseDate(item.FirstAired),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   strconv.Itoa(item.ID),
+			ScraperData: "",
+			ScraperLink: t.MediaLink(0, strconv.Itoa(item.ID)),
+		},
+	}
+}
+
+// tvs search from an external id (imdb, tvdb, ...)
+func (t *TVDB) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	ret := make([]common.SearchData, 0)
+
+	if source == common.ExternalTVDB {
+		// the id can be used directly
+		raw, err := t.request("series/" + url.PathEscape(id))
+		if err != nil {
+			return nil, err
+		}
+		decode := TVDBSeriesBase{}
+		err = json.Unmarshal(raw, &decode)
+		if err != nil {
+			return nil, err
+		}
+		return append(ret, t.seriesSearchData(decode.Data)), nil
+	}
+
+	raw, err := t.request("search/remoteid/" + url.PathEscape(id))
+	if err != nil {
+		return nil, err
+	}
+	decode := TVDBRemoteIDSearch{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range decode.Data {
+		if item.Series != nil {
+			ret = append(ret, t.seriesSearchData(*item.Series))
+		}
+	}
+
+	return ret, nil
+}
+
+// list the shows modified since a given date
+func (t *TVDB) TVSChangedSince(since time.Time) ([]string, error) {
+	return t.changes("series", since)
+}
+
+// returns the extended data of the tvs
+// the data is cached as it is used for every season and episode of the tvs
+func (t *TVDB) getSeries() (*TVDBSeriesExtended, error) {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+
+	if t.series != nil && strconv.Itoa(t.series.Data.ID) == t.ScraperID {
+		return t.series, nil
+	}
+
+	raw, err := t.request("series/" + t.ScraperID + "/extended?meta=translations")
+	if err != nil {
+		return nil, err
+	}
+	decode := TVDBSeriesExtended{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	t.series = &decode
+	return t.series, nil
+}
+
+// returns the selected episode ordering
+func (t *TVDB) order() string {
+	if t.ScraperData == "" {
+		return OrderAired
+	}
+	return t.ScraperData
+}
+
+// returns the episodes of the tvs in the selected ordering
+// the episodes are cached as they are used for every season and episode of the tvs
+func (t *TVDB) getEpisodes() ([]TVDBEpisode, error) {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+
+	key := t.ScraperID + "/" + t.order()
+	if t.episodes != nil && t.episodesKey == key {
+		return t.episodes, nil
+	}
+
+	episodes := []TVDBEpisode{}
+	for page := 0; ; page++ {
+		// request the translated episodes, the original ones are used if no translation exists
+		link := "series/" + t.ScraperID + "/episodes/" + t.order()
+		raw, err := t.request(link + "/" + t.Language + "?page=" + strconv.Itoa(page))
+		if err != nil {
+			raw, err = t.request(link + "?page=" + strconv.Itoa(page))
+			if err != nil {
+				return nil, err
+			}
+		}
+		decode := TVDBEpisodeList{}
+		err = json.Unmarshal(raw, &decode)
+		if err != nil {
+			return nil, err
+		}
+		episodes = append(episodes, decode.Data.Episodes...)
+		if decode.Links.Next == nil || len(decode.Data.Episodes) == 0 {
+			break
+		}
+	}
+
+	t.episodes = episodes
+	t.episodesKey = key
+	return t.episodes, nil
+}
+
+// returns the season and episode numbers of an episode in the selected ordering
+// with the absolute ordering, all the episodes are in the first season
+func (t *TVDB) episodePosition(e TVDBEpisode) (int, int) {
+	if t.order() == OrderAbsolute {
+		if e.AbsoluteNumber > 0 {
+			return 1, e.AbsoluteNumber
+		}
+		return 1, e.Number
+	}
+	return e.SeasonNumber, e.Number
+}
+
+// returns the episode at the given position in the selected ordering
+func (t *TVDB) getEpisode(season int, episode int) (TVDBEpisode, error) {
+	episodes, err := t.getEpisodes()
+	if err != nil {
+		return TVDBEpisode{}, err
+	}
+	for _, i := range episodes {
+		s, e := t.episodePosition(i)
+		if s == season && e == episode {
+			return i, nil
+		}
+	}
+	return TVDBEpisode{}, errors.New("no data")
+}
+
+// tvs get show
+func (t *TVDB) GetTVS() (common.TVSData, error) {
+	decode, err := t.getSeries()
+	if err != nil {
+		return common.TVSData{}, err
+	}
+
+	tr := trailers(decode.Data.Trailers)
+
+	return common.TVSData{
+		Title:     t.translate(decode.Data.Translations.NameTranslations, decode.Data.Name, false),
+		Overview:  t.translate(decode.Data.Translations.OverviewTranslations, decode.Data.Overview, true),
+		Icon:      t.selectArtwork(decode.Data.Artworks, ArtworkSeriesPoster, decode.Data.Image),
+		Fanart:    t.selectArtwork(decode.Data.Artworks, ArtworkSeriesBackground, ""),
+		Website:   "",
+		Trailer:   common.SelectTrailer(tr, t.TrailerPolicy).Link,
+		Trailers:  tr,
+		Premiered: common.ParseDate(decode.Data.FirstAired),
+		Rating:    0,
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   t.ScraperID,
+			ScraperName: t.ScraperName,
+			ScraperData: t.ScraperData,
+			ScraperLink: t.MediaLink(0, t.ScraperID),
+		},
+	}, nil
+}
+
+func (t *TVDB) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	series, err := t.getSeries()
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+	episodes, err := t.getEpisodes()
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+
+	// the season is premiered with its first episode
+	prem := common.Date{}
+	count := 0
+	for _, i := range episodes {
+		if s, _ := t.episodePosition(i); s == season {
+			count++
+			if d := common.ParseDate(i.Aired); d.Before(prem) {
+				prem = d
+			}
+		}
+	}
+
+	for _, i := range series.Data.Seasons {
+		if i.Type.Type != t.order() || i.Number != season {
+			continue
+		}
+
+		raw, err := t.request("seasons/" + strconv.Itoa(i.ID) + "/extended?meta=translations")
+		if err != nil {
+			return common.TVSSeasonData{}, err
+		}
+		decode := TVDBSeasonExtended{}
+		err = json.Unmarshal(raw, &decode)
+		if err != nil {
+			return common.TVSSeasonData{}, err
+		}
+
+		title := t.translate(decode.Data.Translations.NameTranslations, decode.Data.Name, false)
+		if title == "" {
+			title = "Season " + strconv.Itoa(season)
+			if season == 0 {
+				title = "Specials"
+			}
+		}
+
+		return common.TVSSeasonData{
+			Title:        title,
+			Overview:     t.translate(decode.Data.Translations.OverviewTranslations, decode.Data.Overview, true),
+			Icon:         t.selectArtwork(decode.Data.Artwork, ArtworkSeasonPoster, decode.Data.Image),
+			Fanart:       "",
+			Trailer:      "",
+			Premiered:    prem,
+			Rating:       0,
+			EpisodeCount: int64(count),
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   t.ScraperID,
+				ScraperData: t.ScraperData,
+				ScraperLink: t.MediaLink(1, strconv.Itoa(i.ID)),
+			},
+		}, nil
+	}
+
+	if count == 0 {
+		return common.TVSSeasonData{}, errors.New("no data")
+	}
+
+	// the season exists only in the episode list (ex: absolute ordering)
+	return common.TVSSeasonData{
+		Title:        "Season " + strconv.Itoa(season),
+		Premiered:    prem,
+		EpisodeCount: int64(count),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   t.ScraperID,
+			ScraperData: t.ScraperData,
+			ScraperLink: "",
+		},
+	}, nil
+}
+
+// get tvs episode
+func (t *TVDB) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	decode, err := t.getEpisode(season, episode)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+
+	return common.TVSEpisodeData{
+		Title:     decode.Name,
+		Overview:  decode.Overview,
+		Icon:      t.ImageURL(decode.Image),
+		Premiered: common.ParseDate(decode.Aired),
+		Rating:    0,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   t.ScraperID,
+			ScraperData: t.ScraperData,
+			ScraperLink: t.MediaLink(2, strconv.Itoa(decode.ID)),
+		},
+	}, nil
+}
+
+// list the guest stars and crew of an episode
+func (t *TVDB) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	pers := []common.PersonData{}
+
+	ep, err := t.getEpisode(season, episode)
+	if err != nil {
+		return pers, err
+	}
+	raw, err := t.request("episodes/" + strconv.Itoa(ep.ID) + "/extended")
+	if err != nil {
+		return pers, err
+	}
+	decode := TVDBEpisodeExtended{}
+	err = json.Unmarshal(raw, &decode)
+	if err != nil {
+		return pers, err
+	}
+
+	for _, i := range decode.Data.Characters {
+		pers = append(pers, person(i))
+	}
+
+	return pers, nil
+}
+
+func (t *TVDB) ListTVSTag() ([]common.TagData, error) {
+	tags := []common.TagData{}
+
+	decode, err := t.getSeries()
+	if err != nil {
+		return tags, err
+	}
+
+	for _, i := range decode.Data.Genres {
+		tags = append(tags, common.TagData{
+			Name:  "genre",
+			Value: i.Name,
+		})
+	}
+
+	if decode.Data.OriginalCountry != "" {
+		tags = append(tags, common.TagData{
+			Name:  "country",
+			Value: decode.Data.OriginalCountry,
+		})
+	}
+
+	return tags, nil
+}
+
+// list the networks and production companies of the tvs
+func (t *TVDB) ListTVSCompany() ([]common.CompanyData, error) {
+	companies := []common.CompanyData{}
+
+	decode, err := t.getSeries()
+	if err != nil {
+		return companies, err
+	}
+
+	known := map[int]bool{}
+	add := func(tp common.CompanyType, company TVDBCompany) {
+		if company.ID != 0 && !known[company.ID] {
+			known[company.ID] = true
+			companies = append(companies, t.companyData(tp, company))
+		}
+	}
+
+	add(common.CompanyNetwork, decode.Data.OriginalNetwork)
+	add(common.CompanyNetwork, decode.Data.LatestNetwork)
+	for _, i := range decode.Data.Companies {
+		switch i.CompanyType.CompanyTypeName {
+		case "Network":
+			add(common.CompanyNetwork, i)
+		case "Studio", "Production Company":
+			add(common.CompanyStudio, i)
+		}
+	}
+
+	return companies, nil
+}
+
+func (t *TVDB) ListTVSPerson() ([]common.PersonData, error) {
+	pers := []common.PersonData{}
+
+	decode, err := t.getSeries()
+	if err != nil {
+		return pers, err
+	}
+
+	for _, i := range decode.Data.Characters {
+		pers = append(pers, person(i))
+	}
+
+	return pers, nil
+}
+
+func (t *TVDB) GetTVSUpcoming() (common.UpcomingData, error) {
+	series, err := t.getSeries()
+	if err != nil {
+		return common.UpcomingData{}, err
+	}
+	if series.Data.NextAired == "" {
+		return common.UpcomingData{}, errors.New("no data")
+	}
+
+	episodes, err := t.getEpisodes()
+	if err != nil {
+		return common.UpcomingData{}, err
+	}
+
+	for _, i := range episodes {
+		if i.Aired != series.Data.NextAired {
+			continue
+		}
+		season, episode := t.episodePosition(i)
+		return common.UpcomingData{
+			Title:     i.Name,
+			Overview:  i.Overview,
+			Icon:      t.ImageURL(i.Image),
+			Premiered: common.ParseDate(i.Aired),
+			ID1:       int64(season),
+			ID2:       int64(episode),
+			ScraperInfo: common.ScraperInfo{
+				ScraperID:   strconv.Itoa(i.ID),
+				ScraperName: t.ScraperName,
+				ScraperData: "",
+				ScraperLink: t.MediaLink(2, strconv.Itoa(i.ID)),
+			},
+		}, nil
+	}
+
+	return common.UpcomingData{}, errors.New("no data")
+}
+
+// list the episode orderings available for the tvs (aired, dvd and absolute)
+func (t *TVDB) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	groups := []common.EpisodeGroupData{}
+
+	decode, err := t.getSeries()
+	if err != nil {
+		return groups, err
+	}
+
+	for _, tp := range []string{OrderAired, OrderDVD, OrderAbsolute} {
+		title := ""
+		seasons := int64(0)
+		for _, i := range decode.Data.Seasons {
+			if i.Type.Type == tp {
+				title = i.Type.Name
+				seasons++
+			}
+		}
+		if seasons == 0 {
+			continue
+		}
+		groups = append(groups, common.EpisodeGroupData{
+			Title:        title,
+			Overview:     "",
+			Type:         episodeGroupType(tp),
+			EpisodeCount: 0,
+			GroupCount:   seasons,
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   t.ScraperID,
+				ScraperData: tp,
+				ScraperLink: t.MediaLink(0, t.ScraperID),
+			},
+		})
+	}
+
+	return groups, nil
+}
+
+// convert the tvdb season type to the episode group type
+func episodeGroupType(tp string) string {
+	switch tp {
+	case OrderAired:
+		return "original"
+	case OrderDVD:
+		return "dvd"
+	case OrderAbsolute:
+		return "absolute"
+	}
+	return ""
+}
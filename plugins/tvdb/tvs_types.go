@@ -0,0 +1,136 @@
+package tvdb
+
+type TVDBSearch struct {
+	Status string `json:"status"`
+	Data   []struct {
+		ObjectID        string            `json:"objectID"`
+		TVDBID          string            `json:"tvdb_id"`
+		Name            string            `json:"name"`
+		Overview        string            `json:"overview"`
+		ImageURL        string            `json:"image_url"`
+		FirstAirTime    string            `json:"first_air_time"`
+		Year            string            `json:"year"`
+		Type            string            `json:"type"`
+		Network         string            `json:"network"`
+		Country         string            `json:"country"`
+		PrimaryLanguage string            `json:"primary_language"`
+		Translations    map[string]string `json:"translations"`
+		Overviews       map[string]string `json:"overviews"`
+	} `json:"data"`
+	Links TVDBLinks `json:"links"`
+}
+
+type TVDBSeries struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	Slug             string `json:"slug"`
+	Image            string `json:"image"`
+	Overview         string `json:"overview"`
+	FirstAired       string `json:"firstAired"`
+	LastAired        string `json:"lastAired"`
+	NextAired        string `json:"nextAired"`
+	Score            int    `json:"score"`
+	OriginalCountry  string `json:"originalCountry"`
+	OriginalLanguage string `json:"originalLanguage"`
+	Year             string `json:"year"`
+	Status           struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"status"`
+}
+
+type TVDBRemoteIDSearch struct {
+	Status string `json:"status"`
+	Data   []struct {
+		Series *TVDBSeries `json:"series"`
+	} `json:"data"`
+}
+
+type TVDBSeriesBase struct {
+	Status string     `json:"status"`
+	Data   TVDBSeries `json:"data"`
+}
+
+type TVDBSeasonType struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type TVDBSeason struct {
+	ID       int            `json:"id"`
+	SeriesID int            `json:"seriesId"`
+	Type     TVDBSeasonType `json:"type"`
+	Number   int            `json:"number"`
+	Name     string         `json:"name"`
+	Image    string         `json:"image"`
+}
+
+type TVDBSeriesExtended struct {
+	Status string `json:"status"`
+	Data   struct {
+		TVDBSeries
+		Genres []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+			Slug string `json:"slug"`
+		} `json:"genres"`
+		OriginalNetwork TVDBCompany      `json:"originalNetwork"`
+		LatestNetwork   TVDBCompany      `json:"latestNetwork"`
+		Companies       []TVDBCompany    `json:"companies"`
+		Characters      []TVDBCharacter  `json:"characters"`
+		Artworks        []TVDBArtwork    `json:"artworks"`
+		Seasons         []TVDBSeason     `json:"seasons"`
+		SeasonTypes     []TVDBSeasonType `json:"seasonTypes"`
+		Trailers        []TVDBTrailer    `json:"trailers"`
+		RemoteIDs       []TVDBRemoteID   `json:"remoteIds"`
+		Translations    TVDBTranslations `json:"translations"`
+	} `json:"data"`
+}
+
+type TVDBSeasonExtended struct {
+	Status string `json:"status"`
+	Data   struct {
+		TVDBSeason
+		Artwork      []TVDBArtwork    `json:"artwork"`
+		Episodes     []TVDBEpisode    `json:"episodes"`
+		Trailers     []TVDBTrailer    `json:"trailers"`
+		Overview     string           `json:"overview"`
+		Year         string           `json:"year"`
+		Translations TVDBTranslations `json:"translations"`
+	} `json:"data"`
+}
+
+type TVDBEpisode struct {
+	ID             int    `json:"id"`
+	SeriesID       int    `json:"seriesId"`
+	Name           string `json:"name"`
+	Overview       string `json:"overview"`
+	Aired          string `json:"aired"`
+	Runtime        int    `json:"runtime"`
+	Image          string `json:"image"`
+	Number         int    `json:"number"`
+	AbsoluteNumber int    `json:"absoluteNumber"`
+	SeasonNumber   int    `json:"seasonNumber"`
+	SeasonName     string `json:"seasonName"`
+	FinaleType     string `json:"finaleType"`
+}
+
+type TVDBEpisodeList struct {
+	Status string `json:"status"`
+	Data   struct {
+		Series   TVDBSeries    `json:"series"`
+		Episodes []TVDBEpisode `json:"episodes"`
+	} `json:"data"`
+	Links TVDBLinks `json:"links"`
+}
+
+type TVDBEpisodeExtended struct {
+	Status string `json:"status"`
+	Data   struct {
+		TVDBEpisode
+		Characters []TVDBCharacter `json:"characters"`
+		Companies  []TVDBCompany   `json:"companies"`
+		Trailers   []TVDBTrailer   `json:"trailers"`
+	} `json:"data"`
+}
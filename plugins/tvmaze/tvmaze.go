@@ -0,0 +1,213 @@
+package tvmaze
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// maximum number of retries when the rate limit of the api is reached
+const maxRetries = 3
+
+// provider for the TVmaze api, tvs are identified by their tvmaze id
+// specials are numbered in season 0 in the order of the api
+type TVMaze struct {
+	APIURL           string // url of the api, can be changed to use a local server
+	SearchMaxResults int
+	ScraperName      string
+	ScraperID        string
+	ScraperData      string
+	Logger           *log.Logger
+	show             *TVMazeShow     // last requested tvs
+	episodes         []TVMazeEpisode // episodes of the last requested tvs
+	episodesID       string          // ScraperID of the cached episodes
+	cacheLock        *sync.Mutex
+}
+
+func New() TVMaze {
+	return TVMaze{
+		ScraperName:      "tvmaze",
+		APIURL:           "https://api.tvmaze.com",
+		SearchMaxResults: 20,
+		Logger:           nil,
+		ScraperID:        "",
+		ScraperData:      "",
+		cacheLock:        &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (t *TVMaze) Setup(config map[string]string, logger *log.Logger) error {
+	t.Logger = logger
+	if val, ok := config["api_url"]; ok && val != "" {
+		t.APIURL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["search_max_results"]; ok {
+		if n, err := strconv.Atoi(val); err == nil {
+			t.SearchMaxResults = n
+		}
+	}
+	return nil
+}
+
+func (t *TVMaze) Configure(ScraperID string, ScraperData string) {
+	t.ScraperID = ScraperID
+	t.ScraperData = ScraperData
+}
+
+// helper to make a request to the api, data is decoded in v
+// the api returns a 429 status code when too many requests are made, the request is retried after a delay
+func (t *TVMaze) request(link string, v interface{}) error {
+	errFields := log.Fields{
+		"file":     "tvmaze",
+		"function": "request",
+	}
+
+	u := t.APIURL + "/" + link
+	for retry := 0; ; retry++ {
+		resp, err := http.Get(u)
+		if err != nil {
+			t.Logger.WithFields(errFields).Errorf("request error: %v", err)
+			return err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode == 429 && retry < maxRetries {
+			time.Sleep(time.Duration(retry+1) * 2 * time.Second)
+			continue
+		}
+		if resp.StatusCode != 200 {
+			t.Logger.WithFields(errFields).Infof("requested url: %s", u)
+			t.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+			return errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+		}
+		if err != nil {
+			t.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+			return err
+		}
+		return json.Unmarshal(data, v)
+	}
+}
+
+// list the ids of the shows modified since a given date
+// the api only returns the changes of the last day, week or month, or all the changes
+func (t *TVMaze) changes(since time.Time) ([]string, error) {
+	link := "updates/shows"
+	switch age := time.Since(since); {
+	case age <= 24*time.Hour:
+		link += "?since=day"
+	case age <= 7*24*time.Hour:
+		link += "?since=week"
+	case age <= 30*24*time.Hour:
+		link += "?since=month"
+	}
+
+	decode := map[string]int64{}
+	err := t.request(link, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for id, updated := range decode {
+		if updated >= since.Unix() {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (t *TVMaze) MediaLink(tp int, id string) string {
+	if id == "" {
+		return ""
+	}
+	if tp == 0 {
+		return "https://www.tvmaze.com/shows/" + id
+	} else if tp == 1 {
+		return "https://www.tvmaze.com/seasons/" + id
+	} else if tp == 2 {
+		return "https://www.tvmaze.com/episodes/" + id
+	} else if tp == 3 {
+		return "https://www.tvmaze.com/networks/" + id
+	} else if tp == 4 {
+		return "https://www.tvmaze.com/webchannels/" + id
+	}
+	return ""
+}
+
+// returns the url of the largest version of an image
+func imageURL(img *TVMazeImage) string {
+	if img == nil {
+		return ""
+	}
+	if img.Original != "" {
+		return img.Original
+	}
+	return img.Medium
+}
+
+var (
+	lineBreakReg = regexp.MustCompile(`(?i)</p>\s*<p>|<br\s*/?>`)
+	htmlTagReg   = regexp.MustCompile(`<[^>]+>`)
+)
+
+// summaries are html paragraphs (ex: <p><b>Lost</b> follows...</p>)
+func summary(val string) string {
+	val = lineBreakReg.ReplaceAllString(val, "\n")
+	val = htmlTagReg.ReplaceAllString(val, "")
+	return strings.TrimSpace(val)
+}
+
+// returns the exact air time of an episode, or its air date if the time is unknown
+func airDate(e TVMazeEpisode) common.Date {
+	if d := common.ParseDate(e.Airstamp); d.IsKnown() {
+		return d
+	}
+	return common.ParseDate(e.Airdate)
+}
+
+// companies
+
+// networks broadcast the tvs, web channels stream them (ex: Netflix)
+func (t *TVMaze) companyData(tp common.CompanyType, channel *TVMazeChannel) common.CompanyData {
+	country := ""
+	if channel.Country != nil {
+		country = channel.Country.Code
+	}
+	link := t.MediaLink(3, strconv.Itoa(channel.ID))
+	if tp == common.CompanyWebChannel {
+		link = t.MediaLink(4, strconv.Itoa(channel.ID))
+	}
+	return common.CompanyData{
+		Name:          channel.Name,
+		Logo:          "",
+		OriginCountry: country,
+		Type:          tp,
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   strconv.Itoa(channel.ID),
+			ScraperLink: link,
+		},
+	}
+}
+
+// returns the timezone of the network of the tvs
+// the country of a web channel is used if the tvs has no network, global web channels have no timezone
+func timezone(show *TVMazeShow) string {
+	for _, i := range []*TVMazeChannel{show.Network, show.WebChannel} {
+		if i != nil && i.Country != nil && i.Country.Timezone != "" {
+			return i.Country.Timezone
+		}
+	}
+	return ""
+}
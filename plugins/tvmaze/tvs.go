@@ -0,0 +1,398 @@
+package tvmaze
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+func NewTVShowProvider() common.TVShowProvider {
+	p := New()
+	return &p
+}
+
+// tvs search
+func (t *TVMaze) SearchTVS(name string) ([]common.SearchData, error) {
+	decode := TVMazeSearch{}
+	err := t.request("search/shows?"+url.Values{"q": {name}}.Encode(), &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]common.SearchData, 0)
+	for _, i := range decode {
+		if t.SearchMaxResults > 0 && len(ret) >= t.SearchMaxResults {
+			break
+		}
+		ret = append(ret, t.searchData(i.Show))
+	}
+	return ret, nil
+}
+
+func (t *TVMaze) searchData(show TVMazeShow) common.SearchData {
+	return common.SearchData{
+		Title:     show.Name,
+		Overview:  summary(show.Summary),
+		Icon:      imageURL(show.Image),
+		Premiered: common.ParseDate(show.Premiered),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   strconv.Itoa(show.ID),
+			ScraperData: "",
+			ScraperLink: t.MediaLink(0, strconv.Itoa(show.ID)),
+		},
+	}
+}
+
+// tvs search from an external id (imdb, tvdb)
+func (t *TVMaze) FindTVSByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	var params url.Values
+	switch source {
+	case common.ExternalIMDB:
+		params = url.Values{"imdb": {id}}
+	case common.ExternalTVDB:
+		params = url.Values{"thetvdb": {id}}
+	default:
+		return nil, errors.New("unsupported external source: " + string(source))
+	}
+
+	decode := TVMazeShow{}
+	err := t.request("lookup/shows?"+params.Encode(), &decode)
+	if err != nil {
+		return nil, err
+	}
+	return []common.SearchData{t.searchData(decode)}, nil
+}
+
+// list the shows modified since a given date
+func (t *TVMaze) TVSChangedSince(since time.Time) ([]string, error) {
+	return t.changes(since)
+}
+
+// returns the tvs with its seasons, cast, crew and next episode
+// the data is cached as it is used for every season and episode of the tvs
+func (t *TVMaze) getShow() (*TVMazeShow, error) {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+
+	if t.show != nil && strconv.Itoa(t.show.ID) == t.ScraperID {
+		return t.show, nil
+	}
+
+	params := url.Values{"embed[]": {"seasons", "cast", "crew", "nextepisode"}}
+	decode := TVMazeShow{}
+	err := t.request("shows/"+url.PathEscape(t.ScraperID)+"?"+params.Encode(), &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	t.show = &decode
+	return t.show, nil
+}
+
+// returns all the episodes of the tvs, including the specials
+// specials have no number in the api, they are moved to season 0 and numbered in the order of the api
+func (t *TVMaze) getEpisodes() ([]TVMazeEpisode, error) {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+
+	if t.episodes != nil && t.episodesID == t.ScraperID {
+		return t.episodes, nil
+	}
+
+	decode := []TVMazeEpisode{}
+	err := t.request("shows/"+url.PathEscape(t.ScraperID)+"/episodes?specials=1", &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	special := 0
+	for i := range decode {
+		if decode[i].Number == 0 {
+			special++
+			decode[i].Season = 0
+			decode[i].Number = special
+		}
+	}
+
+	t.episodes = decode
+	t.episodesID = t.ScraperID
+	return t.episodes, nil
+}
+
+func (t *TVMaze) getEpisode(season int, episode int) (TVMazeEpisode, error) {
+	episodes, err := t.getEpisodes()
+	if err != nil {
+		return TVMazeEpisode{}, err
+	}
+	for _, i := range episodes {
+		if i.Season == season && i.Number == episode {
+			return i, nil
+		}
+	}
+	return TVMazeEpisode{}, errors.New("no data")
+}
+
+// tvs get show
+func (t *TVMaze) GetTVS() (common.TVSData, error) {
+	decode, err := t.getShow()
+	if err != nil {
+		return common.TVSData{}, err
+	}
+
+	ratings := []common.RatingData{}
+	if decode.Rating.Average > 0 {
+		ratings = append(ratings, common.RatingData{Source: t.ScraperName, Value: decode.Rating.Average, Max: 10})
+	}
+
+	return common.TVSData{
+		Title:     decode.Name,
+		Overview:  summary(decode.Summary),
+		Icon:      imageURL(decode.Image),
+		Fanart:    "",
+		Website:   decode.OfficialSite,
+		Trailer:   "",
+		Trailers:  []common.TrailerData{},
+		Premiered: common.ParseDate(decode.Premiered),
+		Rating:    int64(decode.Rating.Average),
+		Ratings:   ratings,
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   t.ScraperID,
+			ScraperName: t.ScraperName,
+			ScraperData: t.ScraperData,
+			ScraperLink: t.MediaLink(0, t.ScraperID),
+		},
+	}, nil
+}
+
+func (t *TVMaze) GetTVSSeason(season int) (common.TVSSeasonData, error) {
+	decode, err := t.getShow()
+	if err != nil {
+		return common.TVSSeasonData{}, err
+	}
+
+	// the api has no season for the specials
+	if season == 0 {
+		return common.TVSSeasonData{
+			Title: "Specials",
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   t.ScraperID,
+				ScraperData: t.ScraperData,
+				ScraperLink: t.MediaLink(0, t.ScraperID),
+			},
+		}, nil
+	}
+
+	for _, i := range decode.Embedded.Seasons {
+		if i.Number != season {
+			continue
+		}
+		title := i.Name
+		if title == "" {
+			title = "Season " + strconv.Itoa(season)
+		}
+		return common.TVSSeasonData{
+			Title:        title,
+			Overview:     summary(i.Summary),
+			Icon:         imageURL(i.Image),
+			Fanart:       "",
+			Trailer:      "",
+			Premiered:    common.ParseDate(i.PremiereDate),
+			Rating:       0,
+			EpisodeCount: int64(i.EpisodeOrder),
+			ScraperInfo: common.ScraperInfo{
+				ScraperName: t.ScraperName,
+				ScraperID:   t.ScraperID,
+				ScraperData: t.ScraperData,
+				ScraperLink: t.MediaLink(1, strconv.Itoa(i.ID)),
+			},
+		}, nil
+	}
+
+	return common.TVSSeasonData{}, errors.New("no data")
+}
+
+func (t *TVMaze) episodeData(e TVMazeEpisode) common.TVSEpisodeData {
+	return common.TVSEpisodeData{
+		Title:     e.Name,
+		Overview:  summary(e.Summary),
+		Icon:      imageURL(e.Image),
+		Premiered: airDate(e),
+		Rating:    int64(e.Rating.Average),
+		Season:    int64(e.Season),
+		Episode:   int64(e.Number),
+		ScraperInfo: common.ScraperInfo{
+			ScraperName: t.ScraperName,
+			ScraperID:   t.ScraperID,
+			ScraperData: t.ScraperData,
+			ScraperLink: t.MediaLink(2, strconv.Itoa(e.ID)),
+		},
+	}
+}
+
+// get tvs episode, the premiered date contains the exact air time if it is known
+func (t *TVMaze) GetTVSEpisode(season int, episode int) (common.TVSEpisodeData, error) {
+	e, err := t.getEpisode(season, episode)
+	if err != nil {
+		return common.TVSEpisodeData{}, err
+	}
+	return t.episodeData(e), nil
+}
+
+// list all the episodes of the tvs
+func (t *TVMaze) ListTVSEpisode() ([]common.TVSEpisodeData, error) {
+	ret := []common.TVSEpisodeData{}
+
+	episodes, err := t.getEpisodes()
+	if err != nil {
+		return ret, err
+	}
+	for _, i := range episodes {
+		ret = append(ret, t.episodeData(i))
+	}
+	return ret, nil
+}
+
+// list the guest stars and crew of an episode
+func (t *TVMaze) ListTVSEpisodePerson(season int, episode int) ([]common.PersonData, error) {
+	pers := []common.PersonData{}
+
+	e, err := t.getEpisode(season, episode)
+	if err != nil {
+		return pers, err
+	}
+	params := url.Values{"embed[]": {"guestcast", "guestcrew"}}
+	decode := TVMazeEpisode{}
+	err = t.request("episodes/"+strconv.Itoa(e.ID)+"?"+params.Encode(), &decode)
+	if err != nil {
+		return pers, err
+	}
+
+	return append(persons(decode.Embedded.GuestCast), crew(decode.Embedded.GuestCrew)...), nil
+}
+
+func (t *TVMaze) ListTVSTag() ([]common.TagData, error) {
+	tags := []common.TagData{}
+
+	decode, err := t.getShow()
+	if err != nil {
+		return tags, err
+	}
+
+	for _, i := range decode.Genres {
+		tags = append(tags, common.TagData{
+			Name:  "genre",
+			Value: i,
+		})
+	}
+
+	for _, i := range []*TVMazeChannel{decode.Network, decode.WebChannel} {
+		if i != nil && i.Country != nil {
+			tags = append(tags, common.TagData{
+				Name:  "country",
+				Value: i.Country.Code,
+			})
+			break
+		}
+	}
+
+	return tags, nil
+}
+
+// list the network and the web channel of the tvs
+func (t *TVMaze) ListTVSCompany() ([]common.CompanyData, error) {
+	companies := []common.CompanyData{}
+
+	decode, err := t.getShow()
+	if err != nil {
+		return companies, err
+	}
+
+	if decode.Network != nil {
+		companies = append(companies, t.companyData(common.CompanyNetwork, decode.Network))
+	}
+	if decode.WebChannel != nil {
+		companies = append(companies, t.companyData(common.CompanyWebChannel, decode.WebChannel))
+	}
+
+	return companies, nil
+}
+
+func (t *TVMaze) ListTVSPerson() ([]common.PersonData, error) {
+	decode, err := t.getShow()
+	if err != nil {
+		return []common.PersonData{}, err
+	}
+	return append(persons(decode.Embedded.Cast), crew(decode.Embedded.Crew)...), nil
+}
+
+// returns the next episode with its exact air time and the timezone of the network
+// the air time is converted to utc, the timezone can be used to display it in the local time of the network
+func (t *TVMaze) GetTVSUpcoming() (common.UpcomingData, error) {
+	decode, err := t.getShow()
+	if err != nil {
+		return common.UpcomingData{}, err
+	}
+	next := decode.Embedded.NextEpisode
+	if next == nil {
+		return common.UpcomingData{}, errors.New("no data")
+	}
+
+	// find the position of the episode, specials are renumbered in getEpisodes
+	season, episode := next.Season, next.Number
+	if episode == 0 {
+		episodes, err := t.getEpisodes()
+		if err != nil {
+			return common.UpcomingData{}, err
+		}
+		for _, i := range episodes {
+			if i.ID == next.ID {
+				season, episode = i.Season, i.Number
+				break
+			}
+		}
+	}
+
+	return common.UpcomingData{
+		Title:     next.Name,
+		Overview:  summary(next.Summary),
+		Icon:      imageURL(next.Image),
+		Premiered: airDate(*next),
+		ID1:       int64(season),
+		ID2:       int64(episode),
+		Timezone:  timezone(decode),
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   strconv.Itoa(next.ID),
+			ScraperName: t.ScraperName,
+			ScraperData: "",
+			ScraperLink: t.MediaLink(2, strconv.Itoa(next.ID)),
+		},
+	}, nil
+}
+
+// the api only provides the aired order
+func (t *TVMaze) ListEpisodeGroups() ([]common.EpisodeGroupData, error) {
+	return []common.EpisodeGroupData{}, nil
+}
+
+// the name of the character played is kept in its own field
+func persons(cast []TVMazeCast) []common.PersonData {
+	pers := []common.PersonData{}
+	for _, i := range cast {
+		pers = append(pers, common.PersonData{Name: i.Person.Name, Role: common.RoleActing, Character: i.Character.Name, IsCharacter: false})
+	}
+	return pers
+}
+
+// the crew types are job titles (ex: Executive Producer), they are converted to departments
+func crew(list []TVMazeCrew) []common.PersonData {
+	pers := []common.PersonData{}
+	for _, i := range list {
+		pers = append(pers, common.PersonData{Name: i.Person.Name, Role: common.JobRole(i.Type), IsCharacter: false})
+	}
+	return pers
+}
@@ -0,0 +1,115 @@
+package tvmaze
+
+type TVMazeImage struct {
+	Medium   string `json:"medium"`
+	Original string `json:"original"`
+}
+
+type TVMazeCountry struct {
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+	Timezone string `json:"timezone"` // ex: America/New_York
+}
+
+// network or web channel
+type TVMazeChannel struct {
+	ID           int            `json:"id"`
+	Name         string         `json:"name"`
+	Country      *TVMazeCountry `json:"country"`
+	OfficialSite string         `json:"officialSite"`
+}
+
+type TVMazeShow struct {
+	ID           int      `json:"id"`
+	URL          string   `json:"url"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Language     string   `json:"language"`
+	Genres       []string `json:"genres"`
+	Status       string   `json:"status"`
+	Premiered    string   `json:"premiered"`
+	OfficialSite string   `json:"officialSite"`
+	Schedule     struct {
+		Time string   `json:"time"` // local time of the network, ex: 22:00
+		Days []string `json:"days"`
+	} `json:"schedule"`
+	Rating struct {
+		Average float64 `json:"average"`
+	} `json:"rating"`
+	Network    *TVMazeChannel `json:"network"`
+	WebChannel *TVMazeChannel `json:"webChannel"`
+	Externals  struct {
+		TVRage  int    `json:"tvrage"`
+		TheTVDB int    `json:"thetvdb"`
+		IMDB    string `json:"imdb"`
+	} `json:"externals"`
+	Image    *TVMazeImage `json:"image"`
+	Summary  string       `json:"summary"`
+	Updated  int64        `json:"updated"`
+	Embedded struct {
+		Episodes    []TVMazeEpisode `json:"episodes"`
+		Seasons     []TVMazeSeason  `json:"seasons"`
+		Cast        []TVMazeCast    `json:"cast"`
+		Crew        []TVMazeCrew    `json:"crew"`
+		NextEpisode *TVMazeEpisode  `json:"nextepisode"`
+	} `json:"_embedded"`
+}
+
+type TVMazeSearch []struct {
+	Score float64    `json:"score"`
+	Show  TVMazeShow `json:"show"`
+}
+
+type TVMazeSeason struct {
+	ID           int            `json:"id"`
+	URL          string         `json:"url"`
+	Number       int            `json:"number"`
+	Name         string         `json:"name"`
+	EpisodeOrder int            `json:"episodeOrder"`
+	PremiereDate string         `json:"premiereDate"`
+	EndDate      string         `json:"endDate"`
+	Network      *TVMazeChannel `json:"network"`
+	WebChannel   *TVMazeChannel `json:"webChannel"`
+	Image        *TVMazeImage   `json:"image"`
+	Summary      string         `json:"summary"`
+}
+
+type TVMazeEpisode struct {
+	ID       int    `json:"id"`
+	URL      string `json:"url"`
+	Name     string `json:"name"`
+	Season   int    `json:"season"`
+	Number   int    `json:"number"` // null for specials
+	Type     string `json:"type"`   // regular, significant_special or insignificant_special
+	Airdate  string `json:"airdate"`
+	Airtime  string `json:"airtime"`
+	Airstamp string `json:"airstamp"` // exact air time, ex: 2013-06-25T02:00:00+00:00
+	Runtime  int    `json:"runtime"`
+	Rating   struct {
+		Average float64 `json:"average"`
+	} `json:"rating"`
+	Image    *TVMazeImage `json:"image"`
+	Summary  string       `json:"summary"`
+	Embedded struct {
+		GuestCast []TVMazeCast `json:"guestcast"`
+		GuestCrew []TVMazeCrew `json:"guestcrew"`
+	} `json:"_embedded"`
+}
+
+type TVMazePerson struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type TVMazeCast struct {
+	Person    TVMazePerson `json:"person"`
+	Character struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"character"`
+}
+
+type TVMazeCrew struct {
+	Type   string       `json:"type"` // ex: Creator, Director
+	Person TVMazePerson `json:"person"`
+}
@@ -0,0 +1,167 @@
+package wikidata
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// properties storing the ids of a person in other databases
+var personExternalIDs = map[common.ExternalSource]string{
+	common.ExternalIMDB: PropIMDBID,
+	common.ExternalTMDB: PropTMDBPerson,
+}
+
+// genders of the api, converted to the values used by tmdb
+var genders = map[string]int64{
+	"Q6581072": 1, // female
+	"Q6581097": 2, // male
+	"Q48270":   3, // non-binary
+}
+
+func NewPersonProvider() common.PersonProvider {
+	p := New()
+	return &p
+}
+
+// person search, only the entities which are instances of human are returned
+func (w *Wikidata) SearchPerson(name string) ([]common.SearchData, error) {
+	ids, err := w.search(name + " haswbstatement:" + PropInstanceOf + "=" + ItemHuman)
+	if err != nil {
+		return nil, err
+	}
+	return w.searchData(ids)
+}
+
+// person search from an external id, imdb and tmdb ids are resolved with the properties of the entities
+func (w *Wikidata) FindPersonByExternalID(source common.ExternalSource, id string) ([]common.SearchData, error) {
+	if source == common.ExternalWikidata {
+		return w.searchData([]string{id})
+	}
+	prop, ok := personExternalIDs[source]
+	if !ok {
+		return nil, errors.New("unsupported external source: " + string(source))
+	}
+	ids, err := w.search("haswbstatement:" + prop + "=" + id)
+	if err != nil {
+		return nil, err
+	}
+	return w.searchData(ids)
+}
+
+func (w *Wikidata) searchData(ids []string) ([]common.SearchData, error) {
+	ret := make([]common.SearchData, 0)
+	if len(ids) == 0 {
+		return ret, nil
+	}
+
+	data, err := w.getEntities(ids, "labels|descriptions|claims")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range data {
+		ret = append(ret, common.SearchData{
+			Title:     w.text(e.Labels),
+			Overview:  w.text(e.Descriptions),
+			Icon:      ImageURL(firstString(&e, PropImage)),
+			Premiered: dateClaim(&e, PropBirthdate),
+			ScraperInfo: common.ScraperInfo{
+				ScraperID:   e.ID,
+				ScraperName: w.ScraperName,
+				ScraperData: "",
+				ScraperLink: w.MediaLink(e.ID),
+			},
+		})
+	}
+	return ret, nil
+}
+
+// the description is the summary of the wikipedia article if available, else the short description of the entity
+func (w *Wikidata) GetPerson() (common.PersonDetails, error) {
+	e, err := w.getEntity()
+	if err != nil {
+		return common.PersonDetails{}, err
+	}
+
+	// labels of the birthplace, nationalities and occupation
+	birthplace := itemClaims(e, PropBirthplace)
+	nationality := itemClaims(e, PropNationality)
+	occupation := itemClaims(e, PropOccupation)
+	labels := map[string]string{}
+	ids := append(append(append([]string{}, birthplace...), nationality...), occupation...)
+	if len(ids) > 0 {
+		data, err := w.getEntities(ids, "labels")
+		if err != nil {
+			return common.PersonDetails{}, err
+		}
+		for _, i := range data {
+			labels[i.ID] = w.text(i.Labels)
+		}
+	}
+
+	countries := []string{}
+	for _, i := range nationality {
+		if labels[i] != "" {
+			countries = append(countries, labels[i])
+		}
+	}
+
+	aliases := []string{}
+	for _, lang := range w.languages() {
+		if len(e.Aliases[lang]) > 0 {
+			for _, i := range e.Aliases[lang] {
+				aliases = append(aliases, i.Value)
+			}
+			break
+		}
+	}
+
+	gender := int64(0)
+	if g := itemClaims(e, PropGender); len(g) > 0 {
+		gender = genders[g[0]]
+	}
+
+	desc, err := w.summary(e)
+	if err != nil || desc == "" {
+		desc = w.text(e.Descriptions)
+	}
+
+	externalIDs := []common.ExternalID{}
+	for _, source := range []common.ExternalSource{common.ExternalIMDB, common.ExternalTMDB} {
+		if id := firstString(e, personExternalIDs[source]); id != "" {
+			externalIDs = append(externalIDs, common.ExternalID{Source: source, ID: id})
+		}
+	}
+
+	return common.PersonDetails{
+		Name:        w.text(e.Labels),
+		Birthdate:   dateClaim(e, PropBirthdate),
+		Deathdate:   dateClaim(e, PropDeathdate),
+		Gender:      gender,
+		Aliases:     aliases,
+		Birthplace:  first(labels, birthplace),
+		Nationality: strings.Join(countries, ", "),
+		Description: desc,
+		Icon:        ImageURL(firstString(e, PropImage)),
+		KnownFor:    first(labels, occupation),
+		Rating:      0,
+		ExternalIDs: externalIDs,
+		ScraperInfo: common.ScraperInfo{
+			ScraperID:   w.ScraperID,
+			ScraperName: w.ScraperName,
+			ScraperData: "",
+			ScraperLink: w.MediaLink(w.ScraperID),
+		},
+	}, nil
+}
+
+// returns the first known label of a list of items
+func first(labels map[string]string, ids []string) string {
+	for _, i := range ids {
+		if labels[i] != "" {
+			return labels[i]
+		}
+	}
+	return ""
+}
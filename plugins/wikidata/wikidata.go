@@ -0,0 +1,376 @@
+package wikidata
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zogwine/metadata/internal/providers/common"
+)
+
+// properties of the entities
+const (
+	PropInstanceOf  = "P31"
+	PropGender      = "P21"
+	PropBirthdate   = "P569"
+	PropDeathdate   = "P570"
+	PropBirthplace  = "P19"
+	PropNationality = "P27"
+	PropOccupation  = "P106"
+	PropImage       = "P18"
+	PropIMDBID      = "P345"
+	PropTMDBPerson  = "P4985"
+)
+
+// item used by PropInstanceOf for people
+const ItemHuman = "Q5"
+
+// maximum number of ids accepted by wbgetentities
+const maxEntitiesPerRequest = 50
+
+// provider for the Wikidata api, items are identified by their id (ex: Q42)
+type Wikidata struct {
+	APIURL           string // url of the api, can be changed to use a local server
+	WikipediaURL     string // url of wikipedia, {lang} is replaced by the language
+	Language         string
+	SearchMaxResults int
+	ScraperName      string
+	ScraperID        string
+	ScraperData      string
+	Logger           *log.Logger
+	entity           *WikidataEntity // last requested entity
+	cacheLock        *sync.Mutex
+}
+
+func New() Wikidata {
+	return Wikidata{
+		ScraperName:      "wikidata",
+		APIURL:           "https://www.wikidata.org/w/api.php",
+		WikipediaURL:     "https://{lang}.wikipedia.org",
+		Language:         "en",
+		SearchMaxResults: 10,
+		Logger:           nil,
+		ScraperID:        "",
+		ScraperData:      "",
+		cacheLock:        &sync.Mutex{},
+	}
+}
+
+// configure the provider's settings
+func (w *Wikidata) Setup(config map[string]string, logger *log.Logger) error {
+	w.Logger = logger
+	if val, ok := config["api_url"]; ok && val != "" {
+		w.APIURL = val
+	}
+	if val, ok := config["wikipedia_url"]; ok {
+		w.WikipediaURL = strings.TrimSuffix(val, "/")
+	}
+	if val, ok := config["language"]; ok && val != "" {
+		w.Language = val
+	}
+	if val, ok := config["search_max_results"]; ok {
+		if n, err := strconv.Atoi(val); err == nil {
+			w.SearchMaxResults = n
+		}
+	}
+	return nil
+}
+
+func (w *Wikidata) Configure(ScraperID string, ScraperData string) {
+	w.ScraperID = ScraperID
+	w.ScraperData = ScraperData
+}
+
+// helper to get a json document
+func (w *Wikidata) get(u string, v interface{}) error {
+	errFields := log.Fields{
+		"file":     "wikidata",
+		"function": "get",
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	// the wikimedia apis require a user agent
+	req.Header.Set("User-Agent", "zogwine-metadata")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		w.Logger.WithFields(errFields).Errorf("request error: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		w.Logger.WithFields(errFields).Infof("requested url: %s", u)
+		w.Logger.WithFields(errFields).Errorf("request error: status code: %d", resp.StatusCode)
+		return errors.New("request failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		w.Logger.WithFields(errFields).Errorf("request read error: %v", err)
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// helper to make a request to the api
+// the api returns a 200 status code with an error object on errors
+func (w *Wikidata) request(params url.Values, v interface{}) error {
+	params.Set("format", "json")
+
+	var raw json.RawMessage
+	err := w.get(w.APIURL+"?"+params.Encode(), &raw)
+	if err != nil {
+		return err
+	}
+
+	status := WikidataError{}
+	err = json.Unmarshal(raw, &status)
+	if err != nil {
+		return err
+	}
+	if status.Error != nil {
+		return errors.New(status.Error.Code + ": " + status.Error.Info)
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+// returns the languages requested to the api, english is used as a fallback
+func (w *Wikidata) languages() []string {
+	if w.Language == "en" {
+		return []string{"en"}
+	}
+	return []string{w.Language, "en"}
+}
+
+// get entities from their ids, props is the list of the requested data (ex: labels|claims)
+// the entities are returned in the order of the ids, missing and duplicate entities are skipped
+func (w *Wikidata) getEntities(ids []string, props string) ([]WikidataEntity, error) {
+	ret := []WikidataEntity{}
+	// the api rejects the requests with more than maxEntitiesPerRequest ids, duplicates count too
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	ids = unique
+	for start := 0; start < len(ids); start += maxEntitiesPerRequest {
+		end := start + maxEntitiesPerRequest
+		if end > len(ids) {
+			end = len(ids)
+		}
+		decode := WikidataEntities{}
+		err := w.request(url.Values{
+			"action":    {"wbgetentities"},
+			"ids":       {strings.Join(ids[start:end], "|")},
+			"props":     {props},
+			"languages": {strings.Join(w.languages(), "|")},
+		}, &decode)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids[start:end] {
+			if e, ok := decode.Entities[id]; ok && e.Missing == nil {
+				ret = append(ret, e)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// returns the entity selected with Configure
+func (w *Wikidata) getEntity() (*WikidataEntity, error) {
+	w.cacheLock.Lock()
+	defer w.cacheLock.Unlock()
+
+	if w.entity != nil && w.entity.ID == w.ScraperID {
+		return w.entity, nil
+	}
+
+	data, err := w.getEntities([]string{w.ScraperID}, "labels|descriptions|aliases|claims|sitelinks")
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("no data")
+	}
+
+	w.entity = &data[0]
+	return w.entity, nil
+}
+
+// full text search of entities, query can contain filters (ex: haswbstatement:P31=Q5)
+// the ids of the results are returned
+func (w *Wikidata) search(query string) ([]string, error) {
+	params := url.Values{
+		"action":   {"query"},
+		"list":     {"search"},
+		"srsearch": {query},
+	}
+	if w.SearchMaxResults > 0 {
+		params.Set("srlimit", strconv.Itoa(w.SearchMaxResults))
+	}
+	decode := WikidataSearch{}
+	err := w.request(params, &decode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []string{}
+	for _, i := range decode.Query.Search {
+		ret = append(ret, i.Title)
+	}
+	return ret, nil
+}
+
+// returns the summary of the wikipedia article in the configured language
+func (w *Wikidata) summary(e *WikidataEntity) (string, error) {
+	if w.WikipediaURL == "" {
+		return "", errors.New("wikipedia disabled")
+	}
+	link, ok := e.Sitelinks[w.Language+"wiki"]
+	if !ok {
+		return "", errors.New("no wikipedia article in language: " + w.Language)
+	}
+
+	decode := WikipediaSummary{}
+	base := strings.ReplaceAll(w.WikipediaURL, "{lang}", w.Language)
+	err := w.get(base+"/api/rest_v1/page/summary/"+url.PathEscape(strings.ReplaceAll(link.Title, " ", "_")), &decode)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(decode.Extract), nil
+}
+
+func (w *Wikidata) MediaLink(id string) string {
+	if id == "" {
+		return ""
+	}
+	return "https://www.wikidata.org/wiki/" + id
+}
+
+// returns the url of a file from wikimedia commons
+func ImageURL(file string) string {
+	if file == "" {
+		return ""
+	}
+	return "https://commons.wikimedia.org/wiki/Special:FilePath/" + url.PathEscape(strings.ReplaceAll(file, " ", "_"))
+}
+
+// helpers
+
+// returns a text in the configured language, english is used as a fallback
+func (w *Wikidata) text(values map[string]WikidataText) string {
+	for _, lang := range w.languages() {
+		if v, ok := values[lang]; ok {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+// returns the values of a property, preferred values first and deprecated values skipped
+func claims(e *WikidataEntity, prop string) []json.RawMessage {
+	preferred, normal := []json.RawMessage{}, []json.RawMessage{}
+	for _, c := range e.Claims[prop] {
+		if c.Mainsnak.Snaktype != "value" {
+			continue
+		}
+		switch c.Rank {
+		case "preferred":
+			preferred = append(preferred, c.Mainsnak.Datavalue.Value)
+		case "deprecated":
+		default:
+			normal = append(normal, c.Mainsnak.Datavalue.Value)
+		}
+	}
+	return append(preferred, normal...)
+}
+
+// returns the string values of a property (ex: ids, file names)
+func stringClaims(e *WikidataEntity, prop string) []string {
+	ret := []string{}
+	for _, i := range claims(e, prop) {
+		var v string
+		if json.Unmarshal(i, &v) == nil && v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// returns the ids of the items of a property (ex: Q142)
+func itemClaims(e *WikidataEntity, prop string) []string {
+	ret := []string{}
+	for _, i := range claims(e, prop) {
+		v := WikidataItemValue{}
+		if json.Unmarshal(i, &v) == nil && v.ID != "" {
+			ret = append(ret, v.ID)
+		}
+	}
+	return ret
+}
+
+func firstString(e *WikidataEntity, prop string) string {
+	if v := stringClaims(e, prop); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// returns the first date of a property
+func dateClaim(e *WikidataEntity, prop string) common.Date {
+	for _, i := range claims(e, prop) {
+		v := WikidataTimeValue{}
+		if json.Unmarshal(i, &v) == nil {
+			if d := parseTime(v); d.IsKnown() {
+				return d
+			}
+		}
+	}
+	return common.Date{}
+}
+
+var timeReg = regexp.MustCompile(`^\+(\d+)-(\d{2})-(\d{2})`)
+
+// convert a date of the api (ex: +1952-03-11T00:00:00Z), the unknown parts of the date are set to 00
+// the precision is 9 for a year, 10 for a month and 11 for a day, lower precisions and dates before year 1 are ignored
+func parseTime(v WikidataTimeValue) common.Date {
+	match := timeReg.FindStringSubmatch(v.Time)
+	if len(match) < 4 {
+		return common.Date{}
+	}
+	year, _ := strconv.Atoi(match[1])
+	month, _ := strconv.Atoi(match[2])
+	day, _ := strconv.Atoi(match[3])
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+
+	precision := common.DateUnknown
+	switch {
+	case v.Precision >= 11:
+		precision = common.DateDay
+	case v.Precision == 10:
+		precision = common.DateMonth
+	case v.Precision == 9:
+		precision = common.DateYear
+	}
+	return common.NewDate(time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), precision)
+}
@@ -0,0 +1,65 @@
+package wikidata
+
+import "encoding/json"
+
+type WikidataError struct {
+	Error *struct {
+		Code string `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+type WikidataSearch struct {
+	Query struct {
+		Search []struct {
+			Title string `json:"title"` // id of the entity (ex: Q42)
+		} `json:"search"`
+	} `json:"query"`
+}
+
+type WikidataEntities struct {
+	Entities map[string]WikidataEntity `json:"entities"`
+}
+
+type WikidataText struct {
+	Language string `json:"language"`
+	Value    string `json:"value"`
+}
+
+type WikidataEntity struct {
+	ID           string                     `json:"id"`
+	Missing      *string                    `json:"missing"`
+	Labels       map[string]WikidataText    `json:"labels"`
+	Descriptions map[string]WikidataText    `json:"descriptions"`
+	Aliases      map[string][]WikidataText  `json:"aliases"`
+	Claims       map[string][]WikidataClaim `json:"claims"`
+	Sitelinks    map[string]struct {
+		Site  string `json:"site"`
+		Title string `json:"title"`
+	} `json:"sitelinks"`
+}
+
+type WikidataClaim struct {
+	Mainsnak struct {
+		Snaktype  string `json:"snaktype"`
+		Datavalue struct {
+			// string for ids and file names, object for items and dates
+			Value json.RawMessage `json:"value"`
+			Type  string          `json:"type"`
+		} `json:"datavalue"`
+	} `json:"mainsnak"`
+	Rank string `json:"rank"` // preferred, normal or deprecated
+}
+
+type WikidataItemValue struct {
+	ID string `json:"id"`
+}
+
+type WikidataTimeValue struct {
+	Time      string `json:"time"` // ex: +1952-03-11T00:00:00Z
+	Precision int    `json:"precision"`
+}
+
+type WikipediaSummary struct {
+	Extract string `json:"extract"`
+}